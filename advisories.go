@@ -0,0 +1,67 @@
+package ecosystems
+
+import (
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// advisoryPackage is one entry of an advisory's untyped packages list.
+type advisoryPackage struct {
+	Ecosystem string
+	Name      string
+	Versions  []advisoryVersionRange
+}
+
+// advisoryVersionRange describes a vulnerable range and the first release that fixes it.
+type advisoryVersionRange struct {
+	VulnerableRange     string
+	FirstPatchedVersion string
+}
+
+// parseAdvisoryPackages decodes the packages field of an advisory.
+// The API returns it as loosely typed JSON, so unknown shapes are skipped.
+func parseAdvisoryPackages(adv packages.Advisory) []advisoryPackage {
+	var result []advisoryPackage
+	for _, raw := range adv.Packages {
+		p := advisoryPackage{
+			Ecosystem: stringField(raw, "ecosystem"),
+			Name:      stringField(raw, "package_name"),
+		}
+		if p.Name == "" {
+			continue
+		}
+		if versions, ok := raw["versions"].([]interface{}); ok {
+			for _, v := range versions {
+				m, ok := v.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				p.Versions = append(p.Versions, advisoryVersionRange{
+					VulnerableRange:     stringField(m, "vulnerable_version_range"),
+					FirstPatchedVersion: stringField(m, "first_patched_version"),
+				})
+			}
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// stringField returns m[key] if it is a string, or "" otherwise.
+func stringField(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// parseTimestamp parses the RFC 3339 timestamps the API returns as plain strings.
+func parseTimestamp(s *string) (time.Time, bool) {
+	if s == nil || *s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,206 @@
+package ecosystems
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// Remediation records how long it took for a fix to ship after an advisory was published.
+type Remediation struct {
+	// PURL identifies the package without a version, e.g. "pkg:npm/lodash".
+	PURL                string
+	Package             string
+	AdvisoryUUID        string
+	AdvisoryPublishedAt time.Time
+	FixedVersion        string
+	FixReleasedAt       time.Time
+	// Duration is zero when the fix was released before the advisory was published.
+	Duration time.Duration
+}
+
+// RemediationStats summarizes remediation durations for a set of advisories.
+type RemediationStats struct {
+	Fixed   int
+	Unfixed int
+	Mean    time.Duration
+	Median  time.Duration
+}
+
+// MTTRReport holds mean-time-to-remediate metrics per package and in aggregate.
+// Packages is keyed by versionless PURL, like the versions map passed to
+// ComputeMTTR.
+type MTTRReport struct {
+	Remediations []Remediation
+	Packages     map[string]RemediationStats
+	Overall      RemediationStats
+	// Skipped counts advisories left out because their publication date is
+	// missing or unparseable.
+	Skipped int
+}
+
+// ComputeMTTR correlates advisory publication dates with the release date of
+// the first fixed version of each affected package.
+// The versions map is keyed by versionless PURL, such as "pkg:npm/lodash", so
+// packages with the same name in different ecosystems are kept apart.
+// Advisories that list no version ranges for a package, that have no patched
+// version, or whose patched version is not in versions, are counted as
+// unfixed. Advisories without a usable publication date are counted in
+// Skipped.
+func ComputeMTTR(advisories []packages.Advisory, versions map[string][]packages.Version) *MTTRReport {
+	report := &MTTRReport{Packages: make(map[string]RemediationStats)}
+	durations := make(map[string][]time.Duration)
+	unfixed := make(map[string]int)
+
+	for _, adv := range advisories {
+		published, ok := parseTimestamp(adv.PublishedAt)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+		for _, pkg := range parseAdvisoryPackages(adv) {
+			key, ok := advisoryPackagePURL(pkg)
+			if !ok {
+				continue
+			}
+			if _, ok := versions[key]; !ok {
+				continue
+			}
+			if len(pkg.Versions) == 0 {
+				unfixed[key]++
+				continue
+			}
+			for _, r := range pkg.Versions {
+				released, ok := releaseDate(versions[key], r.FirstPatchedVersion)
+				if !ok {
+					unfixed[key]++
+					continue
+				}
+				d := released.Sub(published)
+				if d < 0 {
+					d = 0
+				}
+				report.Remediations = append(report.Remediations, Remediation{
+					PURL:                key,
+					Package:             pkg.Name,
+					AdvisoryUUID:        adv.Uuid,
+					AdvisoryPublishedAt: published,
+					FixedVersion:        r.FirstPatchedVersion,
+					FixReleasedAt:       released,
+					Duration:            d,
+				})
+				durations[key] = append(durations[key], d)
+			}
+		}
+	}
+
+	var all []time.Duration
+	for key := range versions {
+		if len(durations[key]) == 0 && unfixed[key] == 0 {
+			continue
+		}
+		report.Packages[key] = remediationStats(durations[key], unfixed[key])
+		all = append(all, durations[key]...)
+		report.Overall.Unfixed += unfixed[key]
+	}
+	report.Overall = remediationStats(all, report.Overall.Unfixed)
+
+	return report
+}
+
+// advisoryPackagePURL returns the versionless PURL string for a package
+// affected by an advisory, or false if its ecosystem has no PURL type.
+func advisoryPackagePURL(pkg advisoryPackage) (string, bool) {
+	purl, err := PackageToPURL(packages.Package{Ecosystem: pkg.Ecosystem, Name: pkg.Name})
+	if err != nil {
+		return "", false
+	}
+	return purl.ToString(), true
+}
+
+// releaseDate finds the publish time of the given version number.
+func releaseDate(versions []packages.Version, number string) (time.Time, bool) {
+	if number == "" {
+		return time.Time{}, false
+	}
+	for _, v := range versions {
+		if v.Number == number {
+			return parseTimestamp(v.PublishedAt)
+		}
+	}
+	return time.Time{}, false
+}
+
+func remediationStats(durations []time.Duration, unfixed int) RemediationStats {
+	stats := RemediationStats{Fixed: len(durations), Unfixed: unfixed}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	stats.Mean = total / time.Duration(len(sorted))
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.Median = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		stats.Median = sorted[mid]
+	}
+	return stats
+}
+
+// Popularity holds a package's popularity percentiles within its own registry.
+// A percentile of 99 means the package ranks above 99% of packages in that
+// registry. Fields are nil when the API has no ranking for that signal.
+type Popularity struct {
+	PURL                        string
+	Registry                    string
+	DownloadsPercentile         *float64
+	DependentPackagesPercentile *float64
+	DependentReposPercentile    *float64
+}
+
+// NormalizePopularity returns per-registry popularity percentiles for each PURL,
+// derived from the rankings ecosyste.ms computes across each registry. This
+// lets packages from very differently sized ecosystems be compared directly.
+// PURLs not found by the API are omitted from the result.
+func (c *Client) NormalizePopularity(ctx context.Context, purls []string, opts ...CallOption) (map[string]*Popularity, error) {
+	results, err := c.BulkLookup(ctx, purls, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	popularity := make(map[string]*Popularity, len(results))
+	for purl, pkg := range results {
+		popularity[purl] = &Popularity{
+			PURL:                        purl,
+			Registry:                    pkg.Registry.Name,
+			DownloadsPercentile:         rankingPercentile(pkg.Rankings, "downloads"),
+			DependentPackagesPercentile: rankingPercentile(pkg.Rankings, "dependent_packages_count"),
+			DependentReposPercentile:    rankingPercentile(pkg.Rankings, "dependent_repos_count"),
+		}
+	}
+	return popularity, nil
+}
+
+// rankingPercentile converts an ecosyste.ms ranking (the top N percent of the
+// registry, lower is better) into a percentile (higher is better).
+func rankingPercentile(rankings map[string]interface{}, key string) *float64 {
+	rank, ok := rankings[key].(float64)
+	if !ok {
+		return nil
+	}
+	p := 100 - rank
+	if p < 0 {
+		p = 0
+	}
+	return &p
+}
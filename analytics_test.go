@@ -0,0 +1,222 @@
+package ecosystems
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testAdvisory(uuid, published, name, patched string) packages.Advisory {
+	return packages.Advisory{
+		Uuid:        uuid,
+		PublishedAt: strPtr(published),
+		Packages: []map[string]interface{}{
+			{
+				"ecosystem":    "npm",
+				"package_name": name,
+				"versions": []interface{}{
+					map[string]interface{}{
+						"vulnerable_version_range": "< " + patched,
+						"first_patched_version":    patched,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeMTTR(t *testing.T) {
+	advisories := []packages.Advisory{
+		testAdvisory("a1", "2024-01-01T00:00:00Z", "lodash", "4.17.21"),
+		testAdvisory("a2", "2024-02-01T00:00:00Z", "lodash", "4.17.22"),
+		testAdvisory("a3", "2024-03-01T00:00:00Z", "lodash", "9.9.9"),
+		testAdvisory("a4", "2024-01-10T00:00:00Z", "express", "4.0.1"),
+	}
+	versions := map[string][]packages.Version{
+		"pkg:npm/lodash": {
+			{Number: "4.17.21", PublishedAt: strPtr("2024-01-03T00:00:00Z")},
+			{Number: "4.17.22", PublishedAt: strPtr("2024-02-05T00:00:00Z")},
+		},
+		"pkg:npm/express": {
+			// Fix shipped before disclosure
+			{Number: "4.0.1", PublishedAt: strPtr("2024-01-09T00:00:00Z")},
+		},
+	}
+
+	report := ComputeMTTR(advisories, versions)
+
+	if len(report.Remediations) != 3 {
+		t.Fatalf("Remediations = %d, want 3", len(report.Remediations))
+	}
+
+	day := 24 * time.Hour
+	lodash := report.Packages["pkg:npm/lodash"]
+	if lodash.Fixed != 2 || lodash.Unfixed != 1 {
+		t.Errorf("lodash fixed/unfixed = %d/%d, want 2/1", lodash.Fixed, lodash.Unfixed)
+	}
+	if lodash.Mean != 3*day {
+		t.Errorf("lodash Mean = %v, want %v", lodash.Mean, 3*day)
+	}
+
+	express := report.Packages["pkg:npm/express"]
+	if express.Mean != 0 {
+		t.Errorf("express Mean = %v, want 0", express.Mean)
+	}
+
+	if report.Overall.Fixed != 3 || report.Overall.Unfixed != 1 {
+		t.Errorf("Overall fixed/unfixed = %d/%d, want 3/1", report.Overall.Fixed, report.Overall.Unfixed)
+	}
+	if report.Overall.Median != 2*day {
+		t.Errorf("Overall Median = %v, want %v", report.Overall.Median, 2*day)
+	}
+}
+
+func TestComputeMTTREdgeCases(t *testing.T) {
+	gemAdvisory := testAdvisory("g1", "2024-01-01T00:00:00Z", "json", "2.0.1")
+	gemAdvisory.Packages[0]["ecosystem"] = "rubygems"
+	noRanges := testAdvisory("n2", "2024-01-01T00:00:00Z", "json", "")
+	noRanges.Packages[0]["versions"] = []interface{}{}
+	badDate := testAdvisory("n3", "yesterday", "json", "1.0.1")
+	noDate := testAdvisory("n4", "", "json", "1.0.1")
+	noDate.PublishedAt = nil
+
+	advisories := []packages.Advisory{
+		testAdvisory("n1", "2024-01-01T00:00:00Z", "json", "1.0.1"),
+		gemAdvisory,
+		noRanges,
+		badDate,
+		noDate,
+	}
+	versions := map[string][]packages.Version{
+		"pkg:npm/json": {{Number: "1.0.1", PublishedAt: strPtr("2024-01-02T00:00:00Z")}},
+		"pkg:gem/json": {{Number: "2.0.1", PublishedAt: strPtr("2024-01-04T00:00:00Z")}},
+	}
+
+	report := ComputeMTTR(advisories, versions)
+
+	day := 24 * time.Hour
+	tests := []struct {
+		purl    string
+		fixed   int
+		unfixed int
+		mean    time.Duration
+	}{
+		{"pkg:npm/json", 1, 1, day},
+		{"pkg:gem/json", 1, 0, 3 * day},
+	}
+	for _, tt := range tests {
+		got := report.Packages[tt.purl]
+		if got.Fixed != tt.fixed || got.Unfixed != tt.unfixed || got.Mean != tt.mean {
+			t.Errorf("Packages[%q] = %d/%d/%v, want %d/%d/%v", tt.purl, got.Fixed, got.Unfixed, got.Mean, tt.fixed, tt.unfixed, tt.mean)
+		}
+	}
+	if report.Skipped != 2 {
+		t.Errorf("Skipped = %d, want 2", report.Skipped)
+	}
+	for _, r := range report.Remediations {
+		if r.Package != "json" {
+			t.Errorf("Remediation.Package = %q, want json", r.Package)
+		}
+	}
+}
+
+func TestComputeMTTRIgnoresUnknownPackages(t *testing.T) {
+	advisories := []packages.Advisory{
+		testAdvisory("a1", "2024-01-01T00:00:00Z", "left-pad", "1.0.1"),
+	}
+	report := ComputeMTTR(advisories, map[string][]packages.Version{})
+	if len(report.Packages) != 0 {
+		t.Errorf("Packages = %d, want 0", len(report.Packages))
+	}
+}
+
+func TestRankingPercentile(t *testing.T) {
+	rankings := map[string]interface{}{
+		"downloads":             0.5,
+		"dependent_repos_count": 120.0,
+		"average":               "n/a",
+	}
+
+	if got := rankingPercentile(rankings, "downloads"); got == nil || *got != 99.5 {
+		t.Errorf("downloads percentile = %v, want 99.5", got)
+	}
+	if got := rankingPercentile(rankings, "dependent_repos_count"); got == nil || *got != 0 {
+		t.Errorf("dependent_repos_count percentile = %v, want 0", got)
+	}
+	if got := rankingPercentile(rankings, "average"); got != nil {
+		t.Errorf("average percentile = %v, want nil", *got)
+	}
+	if got := rankingPercentile(nil, "downloads"); got != nil {
+		t.Errorf("nil rankings percentile = %v, want nil", *got)
+	}
+}
+
+func TestNormalizePopularity(t *testing.T) {
+	client, srv := newTestClient(t)
+	// Ties rails on downloads, from a different registry, with no other rankings.
+	srv.AddPackage("pypi.org", packages.PackageWithRegistry{
+		Name:     "requests",
+		Purl:     "pkg:pypi/requests",
+		Rankings: map[string]interface{}{"downloads": 0.1},
+	})
+
+	popularity, err := client.NormalizePopularity(context.Background(), []string{
+		"pkg:gem/rails", "pkg:npm/lodash", "pkg:pypi/requests", "pkg:npm/missing",
+	})
+	if err != nil {
+		t.Fatalf("NormalizePopularity() error = %v", err)
+	}
+	if len(popularity) != 3 {
+		t.Errorf("NormalizePopularity() = %d results, want 3", len(popularity))
+	}
+
+	pct := func(f float64) *float64 { return &f }
+	tests := []struct {
+		purl              string
+		registry          string
+		downloads         *float64
+		dependentPackages *float64
+		dependentRepos    *float64
+	}{
+		{"pkg:gem/rails", "rubygems.org", pct(99.9), pct(99.95), pct(99.98)},
+		{"pkg:npm/lodash", "npmjs.org", pct(99.995), nil, nil},
+		{"pkg:pypi/requests", "pypi.org", pct(99.9), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			got := popularity[tt.purl]
+			if got == nil {
+				t.Fatalf("popularity[%q] = nil", tt.purl)
+			}
+			if got.PURL != tt.purl || got.Registry != tt.registry {
+				t.Errorf("PURL, Registry = %q, %q, want %q, %q", got.PURL, got.Registry, tt.purl, tt.registry)
+			}
+			checkPercentile(t, "DownloadsPercentile", got.DownloadsPercentile, tt.downloads)
+			checkPercentile(t, "DependentPackagesPercentile", got.DependentPackagesPercentile, tt.dependentPackages)
+			checkPercentile(t, "DependentReposPercentile", got.DependentReposPercentile, tt.dependentRepos)
+		})
+	}
+
+	if _, ok := popularity["pkg:npm/missing"]; ok {
+		t.Errorf("popularity[pkg:npm/missing] present, want omitted")
+	}
+	if *popularity["pkg:gem/rails"].DownloadsPercentile != *popularity["pkg:pypi/requests"].DownloadsPercentile {
+		t.Errorf("tied rankings in different registries produced different percentiles")
+	}
+}
+
+func checkPercentile(t *testing.T, field string, got, want *float64) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", field, got, want)
+	case math.Abs(*got-*want) > 1e-9:
+		t.Errorf("%s = %v, want %v", field, *got, *want)
+	}
+}
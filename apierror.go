@@ -0,0 +1,74 @@
+package ecosystems
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySnippet bounds the response body kept in an APIError.
+const maxErrorBodySnippet = 512
+
+// APIError is returned when the API answers with an unexpected status. It
+// carries enough of the request and response to diagnose the failure.
+type APIError struct {
+	// Op names the failed operation, such as "lookup".
+	Op         string
+	StatusCode int
+	Method     string
+	URL        string
+	// RequestID is the response's X-Request-Id header, if any, for
+	// correlating with server logs.
+	RequestID string
+	// Body is the start of the response body, truncated to 512 bytes.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s failed with status %d", e.Op, e.StatusCode)
+	if e.Method != "" {
+		fmt.Fprintf(&b, " (%s %s", e.Method, e.URL)
+		if e.RequestID != "" {
+			fmt.Fprintf(&b, ", request id %s", e.RequestID)
+		}
+		b.WriteString(")")
+	}
+	if e.Body != "" {
+		fmt.Fprintf(&b, ": %s", e.Body)
+	}
+	return b.String()
+}
+
+// newAPIError describes an unexpected response whose body has been read.
+func newAPIError(op string, resp *http.Response, body []byte) *APIError {
+	e := &APIError{Op: op}
+	if resp == nil {
+		return e
+	}
+	e.StatusCode = resp.StatusCode
+	e.RequestID = resp.Header.Get("X-Request-Id")
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.Redacted()
+	}
+	body = bytes.TrimSpace(body)
+	truncated := len(body) > maxErrorBodySnippet
+	if truncated {
+		body = body[:maxErrorBodySnippet]
+	}
+	e.Body = strings.ToValidUTF8(string(body), "")
+	if truncated {
+		e.Body += "..."
+	}
+	return e
+}
+
+// readAPIError is newAPIError for a response whose body has not been read.
+// It reads the start of the body; the caller still closes it.
+func readAPIError(op string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySnippet+1))
+	return newAPIError(op, resp, body)
+}
@@ -0,0 +1,86 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("  {\"error\": \"database unavailable\"}\n"))
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithReposServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		op   string
+	}{
+		{"generated client", func() error {
+			_, err := client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash")
+			return err
+		}, "lookup"},
+		{"raw client", func() error {
+			_, err := client.SyncPackage(context.Background(), "npmjs.org", "lodash")
+			return err
+		}, "sync package"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiErr *APIError
+			if err := tt.call(); !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.Op != tt.op || apiErr.StatusCode != 500 || apiErr.Method != http.MethodGet || apiErr.RequestID != "req-123" {
+				t.Errorf("APIError = %+v", apiErr)
+			}
+			if !strings.HasPrefix(apiErr.URL, srv.URL+"/") || !strings.Contains(apiErr.URL, "lodash") {
+				t.Errorf("APIError.URL = %q", apiErr.URL)
+			}
+			if apiErr.Body != `{"error": "database unavailable"}` {
+				t.Errorf("APIError.Body = %q", apiErr.Body)
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want string
+	}{
+		{"status only", &APIError{Op: "lookup", StatusCode: 502}, "lookup failed with status 502"},
+		{"request", &APIError{Op: "lookup", StatusCode: 500, Method: "GET", URL: "https://x/y"},
+			"lookup failed with status 500 (GET https://x/y)"},
+		{"full", &APIError{Op: "get owner", StatusCode: 503, Method: "GET", URL: "https://x/y", RequestID: "abc", Body: "busy"},
+			"get owner failed with status 503 (GET https://x/y, request id abc): busy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorTruncatesBody(t *testing.T) {
+	body := strings.Repeat("x", maxErrorBodySnippet+100)
+	err := newAPIError("lookup", &http.Response{StatusCode: 500, Header: http.Header{}}, []byte(body))
+	if want := strings.Repeat("x", maxErrorBodySnippet) + "..."; err.Body != want {
+		t.Errorf("Body has %d bytes, want %d", len(err.Body), len(want))
+	}
+}
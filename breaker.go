@@ -0,0 +1,119 @@
+package ecosystems
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request while the circuit
+// breaker for a service host is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// WithCircuitBreaker enables a circuit breaker per service host. After
+// threshold consecutive failed requests (network errors, timeouts or 5xx
+// responses) to a host, requests to it fail immediately with an error
+// wrapping ErrCircuitOpen for the cooldown period. After the cooldown one
+// trial request is let through; if it succeeds the circuit closes again.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *clientConfig) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+// breakerState tracks failures for one host.
+type breakerState struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// breakerTransport fails fast for hosts whose circuit is open.
+type breakerTransport struct {
+	next      http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*breakerState
+}
+
+func newBreakerTransport(next http.RoundTripper, threshold int, cooldown time.Duration) *breakerTransport {
+	return &breakerTransport{
+		next:      next,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		hosts:     make(map[string]*breakerState),
+	}
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.allow(host); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Cancelled by the caller; says nothing about the host.
+		t.release(host)
+	case err != nil || resp.StatusCode >= 500:
+		t.failure(host)
+	default:
+		t.success(host)
+	}
+	return resp, err
+}
+
+// allow reports whether a request to host may proceed, claiming the trial
+// request slot when the cooldown has passed.
+func (t *breakerTransport) allow(host string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := t.hosts[host]
+	if s == nil || s.failures < t.threshold {
+		return nil
+	}
+	if s.probing || t.now().Before(s.openUntil) {
+		return fmt.Errorf("%w for %s", ErrCircuitOpen, host)
+	}
+	s.probing = true
+	return nil
+}
+
+func (t *breakerTransport) failure(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := t.hosts[host]
+	if s == nil {
+		s = &breakerState{}
+		t.hosts[host] = s
+	}
+	s.failures++
+	s.probing = false
+	if s.failures >= t.threshold {
+		s.openUntil = t.now().Add(t.cooldown)
+	}
+}
+
+func (t *breakerTransport) success(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.hosts, host)
+}
+
+func (t *breakerTransport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s := t.hosts[host]; s != nil {
+		s.probing = false
+	}
+}
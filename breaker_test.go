@@ -0,0 +1,81 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.URL),
+		WithCircuitBreaker(2, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListRegistries(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("ListRegistries() call %d error = %v, want status error", i+1, err)
+		}
+	}
+
+	_, err = client.ListRegistries(ctx)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("ListRegistries() error = %v, want ErrCircuitOpen", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestBreakerTransportRecovers(t *testing.T) {
+	status := http.StatusInternalServerError
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	bt := newBreakerTransport(http.DefaultTransport, 1, time.Minute)
+	bt.now = func() time.Time { return now }
+	client := &http.Client{Transport: bt}
+
+	get := func() error {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	if err := get(); err != nil {
+		t.Fatalf("first request error = %v", err)
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("request while open error = %v, want ErrCircuitOpen", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Fatalf("trial request error = %v", err)
+	}
+	if err := get(); err != nil {
+		t.Errorf("request after recovery error = %v", err)
+	}
+}
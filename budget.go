@@ -0,0 +1,78 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDeadlineBudgetExceeded is returned when a call that makes several
+// requests, such as a multi-batch BulkLookup or a multi-page
+// GetAllVersions, runs out of the time allowed by WithOverallTimeout or
+// CallOverallTimeout. Methods returning it also return the results they
+// gathered before the budget ran out.
+var ErrDeadlineBudgetExceeded = errors.New("overall timeout exceeded")
+
+// WithOverallTimeout bounds the total time spent by calls that make several
+// requests, across all of their batches and pages. Each request is still
+// bounded by the per-request timeout. Zero, the default, means no overall
+// limit beyond the caller's context.
+func WithOverallTimeout(d time.Duration) Option {
+	return func(c *clientConfig) {
+		c.overallTimeout = d
+	}
+}
+
+// CallOverallTimeout sets the overall time budget for the call, replacing
+// the client's WithOverallTimeout.
+func CallOverallTimeout(d time.Duration) CallOption {
+	return func(c *callConfig) {
+		c.overallTimeout = d
+	}
+}
+
+// withBudget returns ctx bounded by the call's overall time budget.
+func (c *Client) withBudget(ctx context.Context, call *callConfig) (context.Context, context.CancelFunc) {
+	d := call.overallTimeout
+	if d == 0 {
+		d = c.overallTimeout
+	}
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, d, ErrDeadlineBudgetExceeded)
+}
+
+// CallPartialResults makes BulkLookup, BulkLookupDetailed, GetAllVersions
+// and ListOwnerRepositories return the results gathered so far when ctx is
+// canceled or its deadline passes, along with an error wrapping
+// context.Canceled or context.DeadlineExceeded, as they do when the overall
+// timeout runs out. Long jobs can then checkpoint and resume.
+func CallPartialResults() CallOption {
+	return func(c *callConfig) {
+		c.partial = true
+	}
+}
+
+// keepPartial reports whether a call ending with err should return the
+// results gathered before it: when the overall timeout ran out, or with
+// CallPartialResults when ctx is done.
+func keepPartial(ctx context.Context, call *callConfig, err error) bool {
+	if errors.Is(err, ErrDeadlineBudgetExceeded) {
+		return true
+	}
+	return call.partial && err != nil && ctx.Err() != nil
+}
+
+// budgetErr wraps err with ErrDeadlineBudgetExceeded when it was caused by
+// ctx running out of its overall time budget.
+func budgetErr(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, ErrDeadlineBudgetExceeded) {
+		return err
+	}
+	if errors.Is(context.Cause(ctx), ErrDeadlineBudgetExceeded) {
+		return fmt.Errorf("%w: %w", ErrDeadlineBudgetExceeded, err)
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkLookupOverallTimeout(t *testing.T) {
+	var batches int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Purls []string `json:"purls"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if atomic.AddInt32(&batches, 1) > 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		var pkgs []map[string]string
+		for _, purl := range body.Purls {
+			pkgs = append(pkgs, map[string]string{"purl": purl, "name": purl})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pkgs)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithOverallTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	purls := make([]string, MaxBulkLookupSize+10)
+	for i := range purls {
+		purls[i] = fmt.Sprintf("pkg:npm/p%d@1.0.0", i)
+	}
+
+	start := time.Now()
+	result, err := client.BulkLookupDetailed(context.Background(), purls)
+	if !errors.Is(err, ErrDeadlineBudgetExceeded) {
+		t.Fatalf("BulkLookupDetailed() error = %v, want ErrDeadlineBudgetExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("BulkLookupDetailed() took %v, want about 100ms", elapsed)
+	}
+	if result == nil || len(result.Packages) != MaxBulkLookupSize {
+		t.Fatalf("BulkLookupDetailed() = %v, want %d packages from the first batch", result, MaxBulkLookupSize)
+	}
+
+	atomic.StoreInt32(&batches, 0)
+	pkgs, err := client.BulkLookup(context.Background(), purls, CallOverallTimeout(50*time.Millisecond))
+	if !errors.Is(err, ErrDeadlineBudgetExceeded) {
+		t.Fatalf("BulkLookup() error = %v, want ErrDeadlineBudgetExceeded", err)
+	}
+	if len(pkgs) != MaxBulkLookupSize {
+		t.Errorf("BulkLookup() returned %d packages, want %d", len(pkgs), MaxBulkLookupSize)
+	}
+}
+
+func TestGetAllVersionsOverallTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers bool
+	}{
+		{"with totals", true},
+		{"without totals", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := versionPagesServer(t, 95, tt.headers, 0)
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			versions, err := client.GetAllVersions(context.Background(), "npmjs.org", "big", CallPageSize(10), CallOverallTimeout(35*time.Millisecond))
+			if !errors.Is(err, ErrDeadlineBudgetExceeded) {
+				t.Fatalf("GetAllVersions() error = %v, want ErrDeadlineBudgetExceeded", err)
+			}
+			if len(versions) == 0 || len(versions) >= 95 {
+				t.Errorf("GetAllVersions() = %d versions, want a partial result", len(versions))
+			}
+		})
+	}
+}
+
+func TestOverallTimeoutNotReached(t *testing.T) {
+	srv, _ := versionPagesServer(t, 25, true, 0)
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithOverallTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	versions, err := client.GetAllVersions(context.Background(), "npmjs.org", "big", CallPageSize(10))
+	if err != nil {
+		t.Fatalf("GetAllVersions() error = %v", err)
+	}
+	if len(versions) != 25 {
+		t.Errorf("GetAllVersions() = %d versions, want 25", len(versions))
+	}
+}
+
+func TestBudgetErrCallerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := budgetErr(ctx, ctx.Err())
+	if errors.Is(err, ErrDeadlineBudgetExceeded) {
+		t.Errorf("budgetErr() = %v, want caller's cancellation unchanged", err)
+	}
+}
+
+func TestPartialResultsOnCancel(t *testing.T) {
+	srv, _ := versionPagesServer(t, 95, true, 0)
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		opts        []CallOption
+		wantPartial bool
+	}{
+		{"opted in", []CallOption{CallPartialResults()}, true},
+		{"default", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(35*time.Millisecond, cancel)
+			opts := append([]CallOption{CallPageSize(10)}, tt.opts...)
+
+			versions, err := client.GetAllVersions(ctx, "npmjs.org", "big", opts...)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("GetAllVersions() error = %v, want context.Canceled", err)
+			}
+			if tt.wantPartial && (len(versions) == 0 || len(versions) >= 95) {
+				t.Errorf("GetAllVersions() = %d versions, want a partial result", len(versions))
+			}
+			if !tt.wantPartial && versions != nil {
+				t.Errorf("GetAllVersions() = %d versions, want nil", len(versions))
+			}
+		})
+	}
+}
+
+func TestPartialResultsContiguous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 3 {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Total-Pages", "6")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]string{{"number": strconv.Itoa(page)}})
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	versions, err := client.GetAllVersions(ctx, "npmjs.org", "big", CallPageSize(1), CallPartialResults())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetAllVersions() error = %v, want context.DeadlineExceeded", err)
+	}
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Number)
+	}
+	if !slices.Equal(got, []string{"1", "2"}) {
+		t.Errorf("GetAllVersions() = %v, want pages 1 and 2 only", got)
+	}
+}
+
+func TestBulkLookupPartialResults(t *testing.T) {
+	var batches int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Purls []string `json:"purls"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if atomic.AddInt32(&batches, 1) > 1 {
+			<-r.Context().Done()
+			return
+		}
+		var pkgs []map[string]string
+		for _, purl := range body.Purls {
+			pkgs = append(pkgs, map[string]string{"purl": purl, "name": purl})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pkgs)
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	purls := make([]string, MaxBulkLookupSize+10)
+	for i := range purls {
+		purls[i] = fmt.Sprintf("pkg:npm/p%d@1.0.0", i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	pkgs, err := client.BulkLookup(ctx, purls, CallPartialResults())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BulkLookup() error = %v, want context.DeadlineExceeded", err)
+	}
+	if len(pkgs) != MaxBulkLookupSize {
+		t.Errorf("BulkLookup() returned %d packages, want %d", len(pkgs), MaxBulkLookupSize)
+	}
+}
@@ -0,0 +1,64 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// BulkGetVersions fetches the version metadata, including dependencies, for
+// many versioned PURLs such as the entries of a lockfile, a few at a time.
+// The result is keyed by the PURLs as given; versions the API does not know
+// are left out. Every PURL must have a version. Duplicates, including
+// differently written forms of the same PURL, are only requested once. The
+// first failed lookup stops the rest and is returned.
+func (c *Client) BulkGetVersions(ctx context.Context, purls []string, opts ...CallOption) (map[string]*packages.VersionWithDependencies, error) {
+	canonical := make(map[string]string, len(purls))
+	var unique []string
+	seen := make(map[string]bool)
+	for _, s := range purls {
+		p, err := c.ParsePURL(s)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %w", s, err)
+		}
+		if p.Version == "" {
+			return nil, fmt.Errorf("%s has no version", s)
+		}
+		key := c.FormatPURL(p)
+		canonical[s] = key
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+
+	var mu sync.Mutex
+	found := make(map[string]*packages.VersionWithDependencies, len(unique))
+	err := forEach(ctx, unique, maxRepositoryWorkers, func(ctx context.Context, s string) error {
+		p, err := c.ParsePURL(s)
+		if err != nil {
+			return err
+		}
+		v, err := c.GetVersionPURL(ctx, p, opts...)
+		if err != nil || v == nil {
+			return err
+		}
+		mu.Lock()
+		found[s] = v
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*packages.VersionWithDependencies, len(found))
+	for _, s := range purls {
+		if v := found[canonical[s]]; v != nil {
+			result[s] = v
+		}
+	}
+	return result, nil
+}
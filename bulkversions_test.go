@@ -0,0 +1,66 @@
+package ecosystems
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBulkGetVersions(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purls := []string{
+		"pkg:gem/rails@7.1.0",
+		"gem/rails@7.1.0",
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/lodash@0.0.1",
+		"pkg:gem/missing@1.0.0",
+	}
+	got, err := client.BulkGetVersions(context.Background(), purls)
+	if err != nil {
+		t.Fatalf("BulkGetVersions() error = %v", err)
+	}
+
+	want := map[string]string{
+		"pkg:gem/rails@7.1.0":    "7.1.0",
+		"gem/rails@7.1.0":        "7.1.0",
+		"pkg:npm/lodash@4.17.21": "4.17.21",
+	}
+	if len(got) != len(want) {
+		t.Errorf("BulkGetVersions() returned %d versions, want %d", len(got), len(want))
+	}
+	for purl, number := range want {
+		if v := got[purl]; v == nil || v.Number != number {
+			t.Errorf("BulkGetVersions()[%q] = %v, want %s", purl, v, number)
+		}
+	}
+
+	if n := countRequests(srv.Requests(), "/versions/7.1.0"); n != 1 {
+		t.Errorf("rails 7.1.0 requests = %d, want 1", n)
+	}
+}
+
+func TestBulkGetVersionsInvalid(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	tests := []struct {
+		name  string
+		purls []string
+		want  string
+	}{
+		{"no version", []string{"pkg:gem/rails@7.1.0", "pkg:npm/lodash"}, "has no version"},
+		{"unparseable", []string{"pkg:"}, "parsing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.BulkGetVersions(context.Background(), tt.purls)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("BulkGetVersions() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	if got := srv.Requests(); len(got) != 0 {
+		t.Errorf("requests = %v, want none", got)
+	}
+}
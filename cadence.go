@@ -0,0 +1,65 @@
+package ecosystems
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// ReleaseCadence summarizes how often a package publishes releases.
+type ReleaseCadence struct {
+	// Releases counts the versions with a known publish date.
+	Releases     int
+	FirstRelease time.Time
+	LastRelease  time.Time
+	// AverageInterval is the mean time between consecutive releases, zero
+	// with fewer than two.
+	AverageInterval time.Duration
+	// ReleasesPerYear is the release rate between the first and last
+	// release, zero with fewer than two.
+	ReleasesPerYear float64
+}
+
+// Unreleased reports whether nothing was released within d before now,
+// for freshness policies such as "no packages unreleased for 3 years".
+func (r *ReleaseCadence) Unreleased(d time.Duration, now time.Time) bool {
+	return r.Releases == 0 || now.Sub(r.LastRelease) > d
+}
+
+// ComputeReleaseCadence computes the release cadence of a package from its
+// versions. Versions without a publish date are skipped.
+func ComputeReleaseCadence(versions []packages.Version) *ReleaseCadence {
+	var dates []time.Time
+	for _, v := range versions {
+		if t, ok := parseTimestamp(v.PublishedAt); ok {
+			dates = append(dates, t)
+		}
+	}
+	r := &ReleaseCadence{Releases: len(dates)}
+	if len(dates) == 0 {
+		return r
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	r.FirstRelease, r.LastRelease = dates[0], dates[len(dates)-1]
+
+	span := r.LastRelease.Sub(r.FirstRelease)
+	if len(dates) > 1 && span > 0 {
+		r.AverageInterval = span / time.Duration(len(dates)-1)
+		r.ReleasesPerYear = float64(len(dates)) / (span.Hours() / (365.25 * 24))
+	}
+	return r
+}
+
+// ReleaseCadence fetches every version of the package identified by purl
+// and computes its release cadence. It returns nil if the package does not
+// exist.
+func (c *Client) ReleaseCadence(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*ReleaseCadence, error) {
+	versions, err := c.GetAllVersionsPURL(ctx, purl, opts...)
+	if err != nil || versions == nil {
+		return nil, err
+	}
+	return ComputeReleaseCadence(versions), nil
+}
@@ -0,0 +1,68 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestComputeReleaseCadence(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name         string
+		published    []string
+		wantReleases int
+		wantInterval time.Duration
+		wantPerYear  float64
+	}{
+		{"none", nil, 0, 0, 0},
+		{"single", []string{"2020-01-01T00:00:00Z"}, 1, 0, 0},
+		{"unordered", []string{"2020-01-21T00:00:00Z", "2020-01-01T00:00:00Z", "2020-01-11T00:00:00Z"}, 3, 10 * day, 3 / (20 / 365.25)},
+		{"undated skipped", []string{"2020-01-01T00:00:00Z", "", "2021-01-01T06:00:00Z"}, 2, 366*day + 6*time.Hour, 2 / (366.25 / 365.25)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var versions []packages.Version
+			for _, p := range tt.published {
+				versions = append(versions, packages.Version{PublishedAt: strPtr(p)})
+			}
+			got := ComputeReleaseCadence(versions)
+			if got.Releases != tt.wantReleases || got.AverageInterval != tt.wantInterval {
+				t.Errorf("ComputeReleaseCadence() = %+v, want %d releases %v apart", got, tt.wantReleases, tt.wantInterval)
+			}
+			if diff := got.ReleasesPerYear - tt.wantPerYear; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("ReleasesPerYear = %v, want %v", got.ReleasesPerYear, tt.wantPerYear)
+			}
+		})
+	}
+}
+
+func TestReleaseCadence(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	purl, _ := ParsePURL("pkg:gem/rails")
+	cadence, err := client.ReleaseCadence(ctx, purl)
+	if err != nil {
+		t.Fatalf("ReleaseCadence() error = %v", err)
+	}
+	first := time.Date(2021, 12, 15, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 1, 16, 22, 0, 0, 0, time.UTC)
+	if cadence == nil || cadence.Releases != 3 || !cadence.FirstRelease.Equal(first) || !cadence.LastRelease.Equal(last) {
+		t.Fatalf("ReleaseCadence() = %+v, want 3 releases from %v to %v", cadence, first, last)
+	}
+	if cadence.Unreleased(3*365*24*time.Hour, last.AddDate(1, 0, 0)) {
+		t.Error("Unreleased(3 years) = true a year after the last release")
+	}
+	if !cadence.Unreleased(3*365*24*time.Hour, last.AddDate(4, 0, 0)) {
+		t.Error("Unreleased(3 years) = false four years after the last release")
+	}
+
+	purl, _ = ParsePURL("pkg:gem/does-not-exist")
+	if cadence, err := client.ReleaseCadence(ctx, purl); err != nil || cadence != nil {
+		t.Errorf("ReleaseCadence(missing) = %v, %v, want nil, nil", cadence, err)
+	}
+}
@@ -0,0 +1,154 @@
+package ecosystems
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// CallOption configures a single method call, overriding client-wide
+// settings for that call only.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	timeout        time.Duration
+	overallTimeout time.Duration
+	header         http.Header
+	pageSize       int
+	noCache        bool
+	strict         bool
+	partial        bool
+	meta           *responseSink
+	rawBody        *responseSink
+}
+
+// CallTimeout sets the timeout for each HTTP request made by the call,
+// replacing the client's default. The Timeout of an http.Client passed to
+// WithHTTPClient still applies on top of it.
+func CallTimeout(d time.Duration) CallOption {
+	return func(c *callConfig) {
+		c.timeout = d
+	}
+}
+
+// CallHeader adds a header to each HTTP request made by the call.
+func CallHeader(key, value string) CallOption {
+	return func(c *callConfig) {
+		if c.header == nil {
+			c.header = make(http.Header)
+		}
+		c.header.Add(key, value)
+	}
+}
+
+// CallPageSize sets the number of results requested per page by
+// paginated calls such as GetAllVersions, overriding WithDefaultPageSize.
+// Sizes above MaxPageSize are capped.
+func CallPageSize(n int) CallOption {
+	return func(c *callConfig) {
+		c.pageSize = n
+	}
+}
+
+// CallNoCache asks the API and any caches in between to serve fresh data
+// by sending Cache-Control: no-cache.
+func CallNoCache() CallOption {
+	return func(c *callConfig) {
+		c.noCache = true
+	}
+}
+
+// CallStrict makes bulk lookups fail with a *MissingPURLsError when any
+// requested PURL is not recognized by the API.
+func CallStrict() CallOption {
+	return func(c *callConfig) {
+		c.strict = true
+	}
+}
+
+func newCallConfig(opts []CallOption) *callConfig {
+	cc := &callConfig{}
+	for _, opt := range opts {
+		opt(cc)
+	}
+	return cc
+}
+
+// context returns ctx carrying the call's request timeout and response
+// metadata and body destinations, if they were set.
+func (cc *callConfig) context(ctx context.Context) context.Context {
+	if cc.timeout > 0 {
+		ctx = context.WithValue(ctx, callTimeoutKey{}, cc.timeout)
+	}
+	if cc.meta != nil {
+		ctx = context.WithValue(ctx, responseMetaKey{}, cc.meta)
+	}
+	if cc.rawBody != nil {
+		ctx = context.WithValue(ctx, rawBodyKey{}, cc.rawBody)
+	}
+	return ctx
+}
+
+func (cc *callConfig) editRequest(ctx context.Context, req *http.Request) error {
+	for key, values := range cc.header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	if cc.noCache {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+	return nil
+}
+
+func (cc *callConfig) packagesEditors() []packages.RequestEditorFn {
+	return []packages.RequestEditorFn{cc.editRequest}
+}
+
+func (cc *callConfig) reposEditors() []repos.RequestEditorFn {
+	return []repos.RequestEditorFn{cc.editRequest}
+}
+
+type callTimeoutKey struct{}
+
+// timeoutTransport bounds each request by the per-call timeout from the
+// request context, falling back to the client's default timeout.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeout
+	if d, ok := req.Context().Value(callTimeoutKey{}).(time.Duration); ok {
+		timeout = d
+	}
+	if timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a request's timeout once its body has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
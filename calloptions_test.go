@@ -0,0 +1,145 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+)
+
+func TestCallHeaderAndNoCache(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background(), CallHeader("X-Request-Id", "abc"), CallNoCache()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if v := got.Get("X-Request-Id"); v != "abc" {
+		t.Errorf("X-Request-Id = %q, want %q", v, "abc")
+	}
+	if v := got.Get("Cache-Control"); v != "no-cache" {
+		t.Errorf("Cache-Control = %q, want %q", v, "no-cache")
+	}
+	if v := got.Get("User-Agent"); v != "test-agent/1.0 "+sdkProduct() {
+		t.Errorf("User-Agent = %q, want %q", v, "test-agent/1.0 "+sdkProduct())
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if v := got.Get("X-Request-Id"); v != "" {
+		t.Errorf("X-Request-Id leaked into next call: %q", v)
+	}
+}
+
+func TestCallPageSize(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	versions, err := client.GetAllVersions(context.Background(), "rubygems.org", "rails", CallPageSize(1))
+	if err != nil {
+		t.Fatalf("GetAllVersions() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Errorf("GetAllVersions() = %d versions, want 3", len(versions))
+	}
+
+	// One request per page of one version; the first reports three pages.
+	reqs := srv.Requests()
+	if len(reqs) != 3 {
+		t.Errorf("GetAllVersions() made %d requests, want 3: %v", len(reqs), reqs)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.ListRegistries(context.Background(), CallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListRegistries() with short timeout error = %v, want deadline exceeded", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background(), CallTimeout(5*time.Second)); err != nil {
+		t.Errorf("ListRegistries() with long timeout error = %v", err)
+	}
+}
+
+func TestPerPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientSize int
+		callSize   int
+		want       int
+	}{
+		{"defaults", 0, 0, DefaultPageSize},
+		{"client option", 250, 0, 250},
+		{"call overrides client", 250, 50, 50},
+		{"capped", 5000, 0, MaxPageSize},
+		{"call capped", 0, 5000, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-agent/1.0", WithDefaultPageSize(tt.clientSize))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			call := newCallConfig([]CallOption{CallPageSize(tt.callSize)})
+			if got := client.perPage(call, DefaultPageSize); got != tt.want {
+				t.Errorf("perPage() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDefaultPageSize(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithDefaultPageSize(2),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	versions, err := client.GetAllVersions(context.Background(), "rubygems.org", "rails")
+	if err != nil {
+		t.Fatalf("GetAllVersions() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Errorf("GetAllVersions() = %d versions, want 3", len(versions))
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("GetAllVersions() made %d requests, want 2", got)
+	}
+}
@@ -0,0 +1,86 @@
+package ecosystems
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// namespacedPURLTypes are the PURL types whose packages are always
+// qualified by a namespace, such as a Maven group or a repository owner.
+var namespacedPURLTypes = []string{
+	packageurl.TypeBitbucket,
+	packageurl.TypeComposer,
+	packageurl.TypeGithub,
+	packageurl.TypeGitlab,
+	packageurl.TypeMaven,
+}
+
+// maxNPMNameLength is the longest package name, including its scope, the
+// npm registry accepts.
+const maxNPMNameLength = 214
+
+// ValidatePURL reports whether s is a valid PURL, checking the rules of its
+// type on top of the PURL syntax: Maven, Composer and repository host PURLs
+// need a namespace, npm scopes start with "@", and npm names fit the
+// registry's length limit.
+func ValidatePURL(s string) error {
+	purl, err := ParsePURL(s)
+	if err != nil {
+		return fmt.Errorf("invalid PURL %q: %w", s, err)
+	}
+	if slices.Contains(namespacedPURLTypes, purl.Type) && purl.Namespace == "" {
+		return fmt.Errorf("invalid PURL %q: %s PURLs need a namespace", s, purl.Type)
+	}
+	if purl.Type == packageurl.TypeNPM {
+		if purl.Namespace != "" && !strings.HasPrefix(purl.Namespace, "@") {
+			return fmt.Errorf("invalid PURL %q: npm scope %q does not start with @", s, purl.Namespace)
+		}
+		if len(PURLToName(purl)) > maxNPMNameLength {
+			return fmt.Errorf("invalid PURL %q: npm name exceeds %d characters", s, maxNPMNameLength)
+		}
+	}
+	return nil
+}
+
+// pypiSeparators matches the runs of separators PEP 503 folds into "-".
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// CanonicalizePURL returns purl in canonical form, so that differently
+// written PURLs for the same package compare equal once formatted, for
+// example as BulkLookup keys. On top of the PURL spec's normalization it
+// lowercases npm names, normalizes PyPI names per PEP 503, and drops
+// qualifiers that restate a default: a repository_url naming the type's
+// default registry and the Maven type=jar.
+func CanonicalizePURL(purl packageurl.PackageURL) (packageurl.PackageURL, error) {
+	purl.Qualifiers = slices.Clone(purl.Qualifiers)
+	if err := purl.Normalize(); err != nil {
+		return packageurl.PackageURL{}, err
+	}
+
+	switch purl.Type {
+	case packageurl.TypeNPM:
+		purl.Namespace = strings.ToLower(purl.Namespace)
+		purl.Name = strings.ToLower(purl.Name)
+	case packageurl.TypePyPi:
+		purl.Name = pypiSeparators.ReplaceAllString(strings.ToLower(purl.Name), "-")
+	}
+
+	purl.Qualifiers = slices.DeleteFunc(purl.Qualifiers, func(q packageurl.Qualifier) bool {
+		switch {
+		case q.Key == "repository_url":
+			registry := repositoryURLRegistries[repositoryURLHost(q.Value)]
+			return registry != "" && registry == purlTypeToRegistry[purl.Type]
+		case q.Key == "type" && purl.Type == packageurl.TypeMaven:
+			return q.Value == "jar"
+		}
+		return false
+	})
+	if len(purl.Qualifiers) == 0 {
+		purl.Qualifiers = nil
+	}
+	return purl, nil
+}
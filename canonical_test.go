@@ -0,0 +1,80 @@
+package ecosystems
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePURL(t *testing.T) {
+	tests := []struct {
+		purl    string
+		wantErr bool
+	}{
+		{"pkg:npm/lodash@4.17.21", false},
+		{"pkg:npm/%40babel/core", false},
+		{"pkg:golang/stdlib@1.22.0", false},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.14.0", false},
+		{"pkg:maven/commons-lang3@3.14.0", true},
+		{"pkg:github/rails", true},
+		{"pkg:composer/laravel", true},
+		{"pkg:npm/babel/core", true},
+		{"pkg:npm/" + strings.Repeat("a", maxNPMNameLength+1), true},
+		{"pkg:npm", true},
+		{"not a purl at all", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			if err := ValidatePURL(tt.purl); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePURL(%q) error = %v, wantErr %v", tt.purl, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanonicalizePURL(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{"pkg:npm/JSONStream@1.3.5", "pkg:npm/jsonstream@1.3.5"},
+		{"pkg:npm/%40Babel/Core", "pkg:npm/%40babel/core"},
+		{"pkg:pypi/Django_REST.framework@3.15.1", "pkg:pypi/django-rest-framework@3.15.1"},
+		{"pkg:pypi/zope..interface", "pkg:pypi/zope-interface"},
+		{"pkg:npm/lodash?repository_url=https://registry.npmjs.org/", "pkg:npm/lodash"},
+		{"pkg:gem/rails?repository_url=rubygems.org&platform=ruby", "pkg:gem/rails?platform=ruby"},
+		{"pkg:maven/org.example/lib@1.0?type=jar&repository_url=https://repo.maven.apache.org/maven2", "pkg:maven/org.example/lib@1.0"},
+		{"pkg:maven/androidx.core/core@1.0?repository_url=maven.google.com&type=aar", "pkg:maven/androidx.core/core@1.0?repository_url=maven.google.com&type=aar"},
+		{"pkg:npm/lodash?repository_url=npm.example.com", "pkg:npm/lodash?repository_url=npm.example.com"},
+		{"pkg:gem/Rails", "pkg:gem/Rails"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			purl, err := ParsePURL(tt.purl)
+			if err != nil {
+				t.Fatalf("ParsePURL(%q) error = %v", tt.purl, err)
+			}
+			got, err := CanonicalizePURL(purl)
+			if err != nil {
+				t.Fatalf("CanonicalizePURL() error = %v", err)
+			}
+			if s := got.ToString(); s != tt.want {
+				t.Errorf("CanonicalizePURL(%q) = %q, want %q", tt.purl, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizePURLKeepsInput(t *testing.T) {
+	purl, err := ParsePURL("pkg:npm/lodash?repository_url=registry.npmjs.org&checksum=sha1:abc")
+	if err != nil {
+		t.Fatalf("ParsePURL() error = %v", err)
+	}
+	if _, err := CanonicalizePURL(purl); err != nil {
+		t.Fatalf("CanonicalizePURL() error = %v", err)
+	}
+	if len(purl.Qualifiers) != 2 {
+		t.Errorf("CanonicalizePURL() modified its argument's qualifiers: %v", purl.Qualifiers)
+	}
+}
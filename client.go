@@ -7,12 +7,21 @@ package ecosystems
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ecosyste-ms/ecosystems-go/packages"
 	"github.com/ecosyste-ms/ecosystems-go/repos"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -20,40 +29,117 @@ const (
 	DefaultReposServer    = "https://repos.ecosyste.ms/api/v1"
 	DefaultTimeout        = 30 * time.Second
 	MaxBulkLookupSize     = 100
+	DefaultPageSize       = 100
+	// MaxPageSize caps the page size the client requests. The API does not
+	// document a maximum and may apply a smaller one; paginated calls
+	// follow its pagination headers rather than assuming full pages.
+	MaxPageSize = 1000
 )
 
 type Client struct {
-	packagesClient *packages.ClientWithResponses
-	reposClient    *repos.ClientWithResponses
+	packagesAPI    func() *packages.ClientWithResponses
+	reposAPI       func() *repos.ClientWithResponses
+	services       *serviceRegistry
 	userAgent      string
+	purlParser     PURLParser
+	pageSize       int
+	bulkBatchSize  int
+	overallTimeout time.Duration
+	telemetry      *telemetry
+	transport      *http.Transport // created by NewClient; nil with WithHTTPClient
+	closed         *atomic.Bool
+	registries     *registryCatalogue
+	mavenOrder     []string
+	stats          *clientStats
+	roundTripper   *Transport
+	purlRegistries map[string]string
 }
 
 type Option func(*clientConfig)
 
 type clientConfig struct {
-	packagesServer string
-	reposServer    string
-	httpClient     *http.Client
-	userAgent      string
-	fromEmail      string
-	apiKey         string
+	services         map[string]ServiceConfig
+	baseDomain       string
+	httpClient       *http.Client
+	requestTimeout   time.Duration
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	userAgent        string
+	fromEmail        string
+	apiKey           string
+	tokenProvider    func(ctx context.Context) (string, error)
+	purlParser       PURLParser
+	pageSize         int
+	bulkBatchSize    int
+	overallTimeout   time.Duration
+	proxyURL         string
+	maxConnsPerHost  int
+	hostRateLimits   map[string]float64
+	maxRetries       int
+	dialTimeout      time.Duration
+	requestEditors   []RequestEditorFn
+	recorderDir      string
+	recorderMode     RecorderMode
+	compression      bool
+	maxResponseBytes int64
+	gzipRequests     bool
+	mavenRegistries  []string
+	registryOverride map[string]string
+	debugWriter      io.Writer
+	debugBodies      bool
+	offlineStore     *Snapshot
+	offlineMode      OfflineMode
+	tracerProvider   trace.TracerProvider
+	meterProvider    metric.MeterProvider
+	logger           *slog.Logger
 }
 
 func WithPackagesServer(server string) Option {
 	return func(c *clientConfig) {
-		c.packagesServer = server
+		c.updateService(ServicePackages, func(cfg *ServiceConfig) {
+			cfg.BaseURL = server
+		})
 	}
 }
 
 func WithReposServer(server string) Option {
 	return func(c *clientConfig) {
-		c.reposServer = server
+		c.updateService(ServiceRepos, func(cfg *ServiceConfig) {
+			cfg.BaseURL = server
+		})
 	}
 }
 
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *clientConfig) {
 		c.httpClient = client
+		c.requestTimeout = 0
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL, for example
+// "http://proxy.internal:3128". It has no effect with WithHTTPClient.
+func WithProxy(proxyURL string) Option {
+	return func(c *clientConfig) {
+		c.proxyURL = proxyURL
+	}
+}
+
+// WithMaxConnsPerHost limits the connections the client opens to each
+// service host, including those in use. The default is 100; zero means no
+// limit. It has no effect with WithHTTPClient.
+func WithMaxConnsPerHost(n int) Option {
+	return func(c *clientConfig) {
+		c.maxConnsPerHost = n
+	}
+}
+
+// WithDialTimeout sets how long the client waits for a TCP connection to be
+// established. The default is 10 seconds. It has no effect with
+// WithHTTPClient.
+func WithDialTimeout(d time.Duration) Option {
+	return func(c *clientConfig) {
+		c.dialTimeout = d
 	}
 }
 
@@ -67,28 +153,97 @@ func WithFrom(email string) Option {
 
 // WithAPIKey sets the API key for authenticated requests.
 // This provides higher rate limits and access to additional features.
+// It is sent to every service; WithServiceAPIKey overrides it for one.
 func WithAPIKey(key string) Option {
 	return func(c *clientConfig) {
 		c.apiKey = key
 	}
 }
 
+// WithTokenProvider sets a function asked for the API key before each
+// request, for keys that rotate or live in a secrets manager. It replaces
+// WithAPIKey; WithServiceAPIKey still overrides it for one service. An
+// empty key sends none, and an error aborts the request. The function is
+// called concurrently and should cache keys itself.
+func WithTokenProvider(fn func(ctx context.Context) (string, error)) Option {
+	return func(c *clientConfig) {
+		c.tokenProvider = fn
+	}
+}
+
+// WithPURLParser sets the parser used by the client for PURL strings.
+// The default is PackageURLParser.
+func WithPURLParser(p PURLParser) Option {
+	return func(c *clientConfig) {
+		c.purlParser = p
+	}
+}
+
+// WithDefaultPageSize sets the number of results requested per page by
+// paginated calls such as GetAllVersions. Larger pages mean fewer requests
+// but bigger responses. Sizes above MaxPageSize are capped; CallPageSize
+// overrides it for a single call.
+func WithDefaultPageSize(n int) Option {
+	return func(c *clientConfig) {
+		c.pageSize = n
+	}
+}
+
+// WithMaxRetries sets how often a request rejected with 429 Too Many
+// Requests is retried, by default 8 times. A negative value disables the
+// retries, leaving rate limits to the caller.
+func WithMaxRetries(n int) Option {
+	return func(c *clientConfig) {
+		c.maxRetries = n
+	}
+}
+
+// WithBulkBatchSize sets how many PURLs each bulk lookup request carries,
+// by default MaxBulkLookupSize. Smaller batches suit slow proxies. Sizes
+// above MaxBulkLookupSize, the API's limit for anonymous requests, are only
+// accepted with an API key for the packages service, for keys the server
+// allows larger batches.
+func WithBulkBatchSize(n int) Option {
+	return func(c *clientConfig) {
+		c.bulkBatchSize = n
+	}
+}
+
+// RequestEditorFn is called with each outgoing API request after the client
+// has set its authentication headers. The User-Agent and From headers are
+// added afterwards unless an editor sets them. Returning an error aborts
+// the request.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// WithRequestEditor adds fn to the editors applied to every API request,
+// for custom authentication schemes, correlation IDs or proxy headers.
+// Editors run in the order they were added.
+func WithRequestEditor(fn RequestEditorFn) Option {
+	return func(c *clientConfig) {
+		c.requestEditors = append(c.requestEditors, fn)
+	}
+}
+
 // defaultHTTPClient creates an optimized HTTP client for the ecosyste.ms APIs.
+// It has no overall Timeout so that CallTimeout can extend it; the client
+// applies DefaultTimeout to each request instead.
 // Features:
 //   - HTTP/2 enabled (automatic over HTTPS)
 //   - Connection keep-alive with pooling
 //   - Gzip compression (Accept-Encoding handled by transport)
-func defaultHTTPClient() *http.Client {
+//
+// WithProxy, WithMaxConnsPerHost and WithDialTimeout adjust it.
+func defaultHTTPClient(cfg *clientConfig) (*http.Client, error) {
 	transport := &http.Transport{
 		// Connection pooling
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
-		MaxConnsPerHost:     100,
+		MaxConnsPerHost:     cfg.maxConnsPerHost,
 		IdleConnTimeout:     90 * time.Second,
 
 		// Timeouts
 		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
+			Timeout:   cfg.dialTimeout,
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -102,114 +257,298 @@ func defaultHTTPClient() *http.Client {
 		ForceAttemptHTTP2: true,
 	}
 
+	if cfg.proxyURL != "" {
+		proxy, err := url.Parse(cfg.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
 	return &http.Client{
 		Transport: transport,
-		Timeout:   DefaultTimeout,
-	}
+	}, nil
 }
 
 // NewClient creates a new ecosyste.ms API client.
-// The userAgent parameter is required and should identify your application.
+// The userAgent parameter is required and should identify your application,
+// for example UserAgent("myapp", "1.0"). The library's own product token,
+// such as "ecosystems-go/v1.2.0", is appended if userAgent lacks one.
 func NewClient(userAgent string, opts ...Option) (*Client, error) {
 	if userAgent == "" {
 		return nil, fmt.Errorf("userAgent is required")
 	}
 
 	cfg := &clientConfig{
-		packagesServer: DefaultPackagesServer,
-		reposServer:    DefaultReposServer,
-		httpClient:     defaultHTTPClient(),
-		userAgent:      userAgent,
+		requestTimeout:  DefaultTimeout,
+		userAgent:       userAgent,
+		purlParser:      PackageURLParser{},
+		maxConnsPerHost: 100,
+		dialTimeout:     10 * time.Second,
 	}
 
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
+	if cfg.bulkBatchSize == 0 {
+		cfg.bulkBatchSize = MaxBulkLookupSize
+	}
+	if cfg.bulkBatchSize < 0 || (cfg.bulkBatchSize > MaxBulkLookupSize && !cfg.authenticated(ServicePackages)) {
+		return nil, fmt.Errorf("bulk batch size %d must be between 1 and %d without an API key", cfg.bulkBatchSize, MaxBulkLookupSize)
+	}
+
+	var ownedTransport *http.Transport
+	if cfg.httpClient == nil {
+		hc, err := defaultHTTPClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.httpClient = hc
+		ownedTransport = hc.Transport.(*http.Transport)
+	}
+
+	tel, err := newTelemetry(cfg.tracerProvider, cfg.meterProvider)
+	if err != nil {
+		return nil, fmt.Errorf("creating telemetry: %w", err)
+	}
+	stats := newClientStats()
+	closed := new(atomic.Bool)
+	httpClient, roundTripper := buildHTTPClient(cfg, tel, &Transport{
+		UserAgent:  fullUserAgent(cfg.userAgent),
+		From:       cfg.fromEmail,
+		MaxRetries: cfg.maxRetries,
+		stats:      stats,
+		closed:     closed,
+	})
+
 	// Note: Don't set Accept-Encoding manually - the Transport handles gzip
 	// automatically when DisableCompression is false (the default).
 	// Setting it manually disables automatic decompression.
-	addHeaders := func(ctx context.Context, req *http.Request) error {
-		req.Header.Set("User-Agent", cfg.userAgent)
-		if cfg.fromEmail != "" {
-			req.Header.Set("From", cfg.fromEmail)
+	addHeaders := func(ctx context.Context, req *http.Request, service string) error {
+		key, err := cfg.serviceAPIKey(ctx, service)
+		if err != nil {
+			return err
 		}
-		if cfg.apiKey != "" {
-			req.Header.Set("Authorization", "Bearer "+cfg.apiKey)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		for k, v := range cfg.services[service].Header {
+			req.Header[k] = slices.Clone(v)
+		}
+		for _, edit := range cfg.requestEditors {
+			if err := edit(ctx, req); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
 
-	pkgClient, err := packages.NewClientWithResponses(
-		cfg.packagesServer,
-		packages.WithHTTPClient(cfg.httpClient),
-		packages.WithRequestEditorFn(addHeaders),
-	)
+	services, err := newServiceRegistry(cfg.services, cfg.baseDomain, httpClient, addHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("creating packages client: %w", err)
+		return nil, err
 	}
 
-	repoClient, err := repos.NewClientWithResponses(
-		cfg.reposServer,
-		repos.WithHTTPClient(cfg.httpClient),
-		repos.WithRequestEditorFn(addHeaders),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating repos client: %w", err)
-	}
+	// The generated clients are created on first use. Their constructors
+	// only fail when an option does, and these options cannot.
+	packagesAPI := sync.OnceValue(func() *packages.ClientWithResponses {
+		s := services.lookup(ServicePackages)
+		pc, _ := packages.NewClientWithResponses(s.URL(),
+			packages.WithHTTPClient(httpClient),
+			packages.WithRequestEditorFn(s.raw.edit),
+		)
+		return pc
+	})
+	reposAPI := sync.OnceValue(func() *repos.ClientWithResponses {
+		s := services.lookup(ServiceRepos)
+		rc, _ := repos.NewClientWithResponses(s.URL(),
+			repos.WithHTTPClient(httpClient),
+			repos.WithRequestEditorFn(s.raw.edit),
+		)
+		return rc
+	})
 
 	return &Client{
-		packagesClient: pkgClient,
-		reposClient:    repoClient,
+		packagesAPI:    packagesAPI,
+		reposAPI:       reposAPI,
+		services:       services,
 		userAgent:      cfg.userAgent,
+		purlParser:     cfg.purlParser,
+		pageSize:       cfg.pageSize,
+		bulkBatchSize:  cfg.bulkBatchSize,
+		registries:     &registryCatalogue{},
+		mavenOrder:     cfg.mavenRegistries,
+		stats:          stats,
+		overallTimeout: cfg.overallTimeout,
+		telemetry:      tel,
+		transport:      ownedTransport,
+		closed:         closed,
+		roundTripper:   roundTripper,
+		purlRegistries: cfg.registryOverride,
 	}, nil
 }
 
+// Packages returns the generated packages.ecosyste.ms client, for endpoints
+// the high-level methods do not cover. Requests made with it go through the
+// client's transport and carry its headers and authentication, but per-call
+// options do not apply.
+func (c *Client) Packages() *packages.ClientWithResponses {
+	return c.packagesAPI()
+}
+
+// Repos returns the generated repos.ecosyste.ms client, configured like the
+// one returned by Packages.
+func (c *Client) Repos() *repos.ClientWithResponses {
+	return c.reposAPI()
+}
+
 // BulkLookup looks up multiple packages by PURL.
 // Returns a map keyed by PURL with package data.
-// PURLs are processed in batches of 100.
-func (c *Client) BulkLookup(ctx context.Context, purls []string) (map[string]*packages.PackageWithRegistry, error) {
+// PURLs are processed in batches of MaxBulkLookupSize, or the size set
+// with WithBulkBatchSize. PURLs the API does not recognize
+// are omitted from the map, or reported as a *MissingPURLsError when
+// CallStrict is given; use BulkLookupDetailed to list them. If the
+// overall timeout runs out, the packages found so far are returned along
+// with an error wrapping ErrDeadlineBudgetExceeded.
+func (c *Client) BulkLookup(ctx context.Context, purls []string, opts ...CallOption) (map[string]*packages.PackageWithRegistry, error) {
+	result, err := c.BulkLookupDetailed(ctx, purls, opts...)
+	if result == nil {
+		return nil, err
+	}
+	return result.Packages, err
+}
+
+// BulkLookupResult is the outcome of BulkLookupDetailed.
+type BulkLookupResult struct {
+	// Packages maps each recognized PURL to its package data.
+	Packages map[string]*packages.PackageWithRegistry
+	// Missing lists requested PURLs the API did not recognize, in request order.
+	Missing []string
+}
+
+// MissingPURLsError is returned in strict mode when some requested PURLs
+// were not recognized by the API.
+type MissingPURLsError struct {
+	PURLs []string
+}
+
+func (e *MissingPURLsError) Error() string {
+	const shown = 5
+	if len(e.PURLs) <= shown {
+		return fmt.Sprintf("%d PURLs not found: %s", len(e.PURLs), strings.Join(e.PURLs, ", "))
+	}
+	return fmt.Sprintf("%d PURLs not found: %s, ...", len(e.PURLs), strings.Join(e.PURLs[:shown], ", "))
+}
+
+// BulkLookupDetailed is like BulkLookup but also reports which PURLs the API
+// did not recognize. With CallStrict, any missing PURL makes it return a
+// *MissingPURLsError. Like BulkLookup, it returns a partial result when the
+// overall timeout runs out.
+func (c *Client) BulkLookupDetailed(ctx context.Context, purls []string, opts ...CallOption) (*BulkLookupResult, error) {
+	result := &BulkLookupResult{Packages: make(map[string]*packages.PackageWithRegistry)}
+	err := c.BulkLookupStream(ctx, purls, func(purl string, pkg *packages.PackageWithRegistry) error {
+		if pkg == nil {
+			result.Missing = append(result.Missing, purl)
+		} else {
+			result.Packages[purl] = pkg
+		}
+		return nil
+	}, opts...)
+	if keepPartial(ctx, newCallConfig(opts), err) {
+		return result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkLookupStream looks up packages by PURL like BulkLookup, but calls fn
+// as each batch returns instead of collecting every result in memory.
+// fn receives each package found, then each PURL of the batch the API did not
+// recognize with a nil package. If fn returns an error, BulkLookupStream
+// stops and returns it. With CallStrict, BulkLookupStream returns a
+// *MissingPURLsError after all batches if any PURL was missing.
+func (c *Client) BulkLookupStream(ctx context.Context, purls []string, fn func(purl string, pkg *packages.PackageWithRegistry) error, opts ...CallOption) error {
 	if len(purls) == 0 {
-		return map[string]*packages.PackageWithRegistry{}, nil
+		return nil
 	}
 
-	results := make(map[string]*packages.PackageWithRegistry)
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "BulkLookup", "")
+	ctx, cancel := c.withBudget(ctx, call)
+	defer cancel()
+	var missing []string
 
-	for i := 0; i < len(purls); i += MaxBulkLookupSize {
-		end := i + MaxBulkLookupSize
+	for i := 0; i < len(purls); i += c.bulkBatchSize {
+		end := i + c.bulkBatchSize
 		if end > len(purls) {
 			end = len(purls)
 		}
-		batch := purls[i:end]
+		// Linux package PURLs are sent without their arch and epoch
+		// qualifiers, and reported under the PURLs given.
+		batch := make([]string, 0, end-i)
+		origins := make(map[string][]string, end-i)
+		for _, purl := range purls[i:end] {
+			sent := c.trimDistroPURL(purl)
+			if _, ok := origins[sent]; !ok {
+				batch = append(batch, sent)
+			}
+			if !slices.Contains(origins[sent], purl) {
+				origins[sent] = append(origins[sent], purl)
+			}
+		}
+		c.telemetry.recordBatchSize(ctx, len(batch))
 
-		resp, err := c.packagesClient.BulkLookupPackagesWithResponse(ctx, packages.BulkLookupPackagesJSONRequestBody{
+		resp, err := c.packagesAPI().BulkLookupPackagesWithResponse(ctx, packages.BulkLookupPackagesJSONRequestBody{
 			Purls: &batch,
-		})
+		}, call.packagesEditors()...)
 		if err != nil {
-			return nil, fmt.Errorf("bulk lookup: %w", err)
+			return budgetErr(ctx, fmt.Errorf("bulk lookup: %w", err))
 		}
 
 		if resp.StatusCode() != http.StatusOK {
 			if resp.JSON400 != nil && resp.JSON400.Error != nil {
-				return nil, fmt.Errorf("bulk lookup failed: %s", *resp.JSON400.Error)
+				return fmt.Errorf("bulk lookup failed: %s", *resp.JSON400.Error)
 			}
-			return nil, fmt.Errorf("bulk lookup failed with status %d", resp.StatusCode())
+			return newAPIError("bulk lookup", resp.HTTPResponse, resp.Body)
 		}
 
+		found := make(map[string]bool)
 		if resp.JSON200 != nil {
 			for _, pkg := range *resp.JSON200 {
-				p := pkg
-				results[pkg.Purl] = &p
+				keys, ok := origins[pkg.Purl]
+				if !ok {
+					keys = []string{pkg.Purl}
+				}
+				for _, key := range keys {
+					p := pkg
+					found[key] = true
+					if err := fn(key, &p); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, purl := range purls[i:end] {
+			if !found[purl] {
+				missing = append(missing, purl)
+				if err := fn(purl, nil); err != nil {
+					return err
+				}
 			}
 		}
 	}
 
-	return results, nil
+	if call.strict && len(missing) > 0 {
+		return &MissingPURLsError{PURLs: missing}
+	}
+	return nil
 }
 
 // Lookup looks up a single package by PURL.
-func (c *Client) Lookup(ctx context.Context, purl string) (*packages.PackageWithRegistry, error) {
-	results, err := c.BulkLookup(ctx, []string{purl})
+func (c *Client) Lookup(ctx context.Context, purl string, opts ...CallOption) (*packages.PackageWithRegistry, error) {
+	results, err := c.BulkLookup(ctx, []string{purl}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -217,8 +556,13 @@ func (c *Client) Lookup(ctx context.Context, purl string) (*packages.PackageWith
 }
 
 // LookupByRegistryAndName looks up a package by registry and name.
-func (c *Client) LookupByRegistryAndName(ctx context.Context, registry, name string) (*packages.Package, error) {
-	resp, err := c.packagesClient.GetRegistryPackageWithResponse(ctx, registry, name)
+func (c *Client) LookupByRegistryAndName(ctx context.Context, registry, name string, opts ...CallOption) (*packages.Package, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "LookupByRegistryAndName", registry)
+	if err := checkPathSegments(registry, name); err != nil {
+		return nil, fmt.Errorf("lookup package: %w", err)
+	}
+	resp, err := c.packagesAPI().GetRegistryPackageWithResponse(ctx, registry, name, call.packagesEditors()...)
 	if err != nil {
 		return nil, fmt.Errorf("lookup package: %w", err)
 	}
@@ -228,15 +572,20 @@ func (c *Client) LookupByRegistryAndName(ctx context.Context, registry, name str
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("lookup failed with status %d", resp.StatusCode())
+		return nil, newAPIError("lookup", resp.HTTPResponse, resp.Body)
 	}
 
 	return resp.JSON200, nil
 }
 
 // GetVersion gets a specific version of a package.
-func (c *Client) GetVersion(ctx context.Context, registry, name, version string) (*packages.VersionWithDependencies, error) {
-	resp, err := c.packagesClient.GetRegistryPackageVersionWithResponse(ctx, registry, name, version)
+func (c *Client) GetVersion(ctx context.Context, registry, name, version string, opts ...CallOption) (*packages.VersionWithDependencies, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetVersion", registry)
+	if err := checkPathSegments(registry, name, version); err != nil {
+		return nil, fmt.Errorf("get version: %w", err)
+	}
+	resp, err := c.packagesAPI().GetRegistryPackageVersionWithResponse(ctx, registry, name, version, call.packagesEditors()...)
 	if err != nil {
 		return nil, fmt.Errorf("get version: %w", err)
 	}
@@ -246,55 +595,224 @@ func (c *Client) GetVersion(ctx context.Context, registry, name, version string)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get version failed with status %d", resp.StatusCode())
+		return nil, newAPIError("get version", resp.HTTPResponse, resp.Body)
 	}
 
 	return resp.JSON200, nil
 }
 
-// GetAllVersions gets all versions of a package.
-func (c *Client) GetAllVersions(ctx context.Context, registry, name string) ([]packages.Version, error) {
-	var allVersions []packages.Version
-	page := 1
-	perPage := 100
+// GetVersionNumbers returns just the version numbers of a package, in a
+// single unpaginated request. It is much cheaper than GetAllVersions for
+// checking whether a version exists or finding the newest one. It returns
+// nil if the package does not exist.
+func (c *Client) GetVersionNumbers(ctx context.Context, registry, name string, opts ...CallOption) ([]string, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetVersionNumbers", registry)
+	if err := checkPathSegments(registry, name); err != nil {
+		return nil, fmt.Errorf("get version numbers: %w", err)
+	}
+	resp, err := c.packagesAPI().GetRegistryPackageVersionNumbersWithResponse(ctx, registry, name, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get version numbers: %w", err)
+	}
 
-	for {
-		resp, err := c.packagesClient.GetRegistryPackageVersionsWithResponse(ctx, registry, name, &packages.GetRegistryPackageVersionsParams{
-			Page:    &page,
-			PerPage: &perPage,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("get versions: %w", err)
-		}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
 
-		if resp.StatusCode() == http.StatusNotFound {
-			return nil, nil
-		}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get version numbers", resp.HTTPResponse, resp.Body)
+	}
 
-		if resp.StatusCode() != http.StatusOK {
-			return nil, fmt.Errorf("get versions failed with status %d", resp.StatusCode())
-		}
+	if resp.JSON200 == nil {
+		return []string{}, nil
+	}
+
+	return *resp.JSON200, nil
+}
+
+// perPage returns the page size for a paginated call: the call's
+// CallPageSize, else the client's WithDefaultPageSize, else def, capped at
+// MaxPageSize.
+func (c *Client) perPage(call *callConfig, def int) int {
+	n := def
+	switch {
+	case call.pageSize > 0:
+		n = call.pageSize
+	case c.pageSize > 0:
+		n = c.pageSize
+	}
+	return min(n, MaxPageSize)
+}
+
+// listOptions returns opts with the page size for a listing call:
+// opts.PerPage capped at MaxPageSize, or perPage's size when it is unset.
+func (c *Client) listOptions(call *callConfig, opts ListOptions) ListOptions {
+	if opts.PerPage > 0 {
+		opts.PerPage = min(opts.PerPage, MaxPageSize)
+	} else {
+		opts.PerPage = c.perPage(call, DefaultPageSize)
+	}
+	return opts
+}
+
+// maxPageWorkers bounds the pages GetAllVersions fetches at once.
+const maxPageWorkers = 4
+
+// GetAllVersions gets all versions of a package, DefaultPageSize per page
+// unless WithDefaultPageSize or CallPageSize is given. Once the first page
+// reports how many pages there are, the rest are fetched concurrently and
+// merged in order. If the overall timeout runs out, the versions fetched so
+// far are returned along with an error wrapping ErrDeadlineBudgetExceeded.
+// Such a partial result only holds the pages before the first one that did
+// not complete, never a later page fetched concurrently, so it is a
+// prefix of the full list and has no gaps.
+func (c *Client) GetAllVersions(ctx context.Context, registry, name string, opts ...CallOption) ([]packages.Version, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetAllVersions", registry)
+	ctx, cancelBudget := c.withBudget(ctx, call)
+	defer cancelBudget()
+	perPage := c.perPage(call, DefaultPageSize)
+
+	first, err := c.versionsPage(ctx, call, registry, name, ListOptions{Page: 1, PerPage: perPage})
+	if err != nil || first == nil {
+		return nil, budgetErr(ctx, err)
+	}
+	if first.NextPage == 0 {
+		return first.Items, nil
+	}
+	if first.TotalPages == 0 {
+		return c.versionsSerial(ctx, call, registry, name, first)
+	}
 
-		if resp.JSON200 == nil || len(*resp.JSON200) == 0 {
+	pages := make([][]packages.Version, first.TotalPages)
+	done := make([]bool, first.TotalPages)
+	pages[0], done[0] = first.Items, true
+
+	budgetCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, maxPageWorkers)
+	for n := 2; n <= first.TotalPages; n++ {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
 			break
 		}
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			page, err := c.versionsPage(ctx, call, registry, name, ListOptions{Page: n, PerPage: perPage})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			if page != nil {
+				pages[n-1] = page.Items
+			}
+			done[n-1] = true
+		}(n)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		firstErr = budgetErr(budgetCtx, firstErr)
+		if !keepPartial(budgetCtx, call, firstErr) {
+			return nil, firstErr
+		}
+	}
 
-		allVersions = append(allVersions, *resp.JSON200...)
-
-		if len(*resp.JSON200) < perPage {
+	var allVersions []packages.Version
+	for i, items := range pages {
+		if !done[i] {
 			break
 		}
-		page++
+		allVersions = append(allVersions, items...)
 	}
+	return allVersions, firstErr
+}
 
+// versionsSerial fetches the pages after first one at a time, for
+// responses that do not report a page count.
+func (c *Client) versionsSerial(ctx context.Context, call *callConfig, registry, name string, first *Page[packages.Version]) ([]packages.Version, error) {
+	allVersions := first.Items
+	for next := first.NextPage; next != 0; {
+		page, err := c.versionsPage(ctx, call, registry, name, ListOptions{Page: next, PerPage: first.PerPage})
+		if err != nil {
+			if err = budgetErr(ctx, err); keepPartial(ctx, call, err) {
+				return allVersions, err
+			}
+			return nil, err
+		}
+		if page == nil || len(page.Items) == 0 {
+			break
+		}
+		allVersions = append(allVersions, page.Items...)
+		next = page.NextPage
+	}
 	return allVersions, nil
 }
 
-// GetRepository looks up a repository by URL.
-func (c *Client) GetRepository(ctx context.Context, url string) (*repos.Repository, error) {
-	resp, err := c.reposClient.RepositoriesLookupWithResponse(ctx, &repos.RepositoriesLookupParams{
+// GetVersionsPage returns one page of a package's versions along with the
+// total number of versions and pages. It returns nil if the package is not
+// known.
+func (c *Client) GetVersionsPage(ctx context.Context, registry, name string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Version], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "GetVersionsPage", registry)
+	return c.versionsPage(ctx, call, registry, name, c.listOptions(call, opts))
+}
+
+func (c *Client) versionsPage(ctx context.Context, call *callConfig, registry, name string, opts ListOptions) (*Page[packages.Version], error) {
+	if err := checkPathSegments(registry, name); err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+	resp, err := c.packagesAPI().GetRegistryPackageVersionsWithResponse(ctx, registry, name, &packages.GetRegistryPackageVersionsParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+		Sort:    stringParam(opts.Sort),
+		Order:   stringParam(opts.Order),
+	}, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get versions", resp.HTTPResponse, resp.Body)
+	}
+
+	var items []packages.Version
+	if resp.JSON200 != nil {
+		items = *resp.JSON200
+	}
+	return newPage(items, resp.HTTPResponse, opts), nil
+}
+
+// GetRepository looks up a repository by URL. The URL is passed through
+// NormalizeRepoURL first, so forms such as "git+https://...git" or
+// "git@github.com:owner/name.git" from package metadata are found.
+func (c *Client) GetRepository(ctx context.Context, url string, opts ...CallOption) (*repos.Repository, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetRepository", "")
+	url = NormalizeRepoURL(url)
+	resp, err := c.reposAPI().RepositoriesLookupWithResponse(ctx, &repos.RepositoriesLookupParams{
 		Url: &url,
-	})
+	}, call.reposEditors()...)
 	if err != nil {
 		return nil, fmt.Errorf("lookup repository: %w", err)
 	}
@@ -304,26 +822,72 @@ func (c *Client) GetRepository(ctx context.Context, url string) (*repos.Reposito
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("lookup repository failed with status %d", resp.StatusCode())
+		return nil, newAPIError("lookup repository", resp.HTTPResponse, resp.Body)
 	}
 
 	return resp.JSON200, nil
 }
 
-// ListRegistries returns all available registries.
-func (c *Client) ListRegistries(ctx context.Context) ([]packages.Registry, error) {
-	resp, err := c.packagesClient.GetRegistriesWithResponse(ctx, nil)
+// GetRepositoryByHostAndName looks up a repository by host name, such as
+// "GitHub" or "GitLab.com", and full name, such as "rails/rails" or
+// "group/subgroup/project". The full name is sent as a single escaped
+// path segment, so names containing slashes are looked up as one
+// repository. It returns nil if the repository is not known.
+func (c *Client) GetRepositoryByHostAndName(ctx context.Context, host, fullName string, opts ...CallOption) (*repos.Repository, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetRepositoryByHostAndName", "")
+	fullName = strings.Trim(fullName, "/")
+	if err := checkPathSegments(host, fullName); err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+	resp, err := c.reposAPI().GetHostRepositoryWithResponse(ctx, host, fullName, call.reposEditors()...)
 	if err != nil {
-		return nil, fmt.Errorf("list registries: %w", err)
+		return nil, fmt.Errorf("get repository: %w", err)
 	}
 
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("list registries failed with status %d", resp.StatusCode())
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
 	}
 
-	if resp.JSON200 == nil {
-		return nil, nil
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get repository", resp.HTTPResponse, resp.Body)
 	}
 
-	return *resp.JSON200, nil
+	return resp.JSON200, nil
+}
+
+// ListRegistries returns all available registries, fetching all pages.
+func (c *Client) ListRegistries(ctx context.Context, opts ...CallOption) ([]packages.Registry, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "ListRegistries", "")
+	ctx, cancel := c.withBudget(ctx, call)
+	defer cancel()
+	perPage := c.perPage(call, DefaultPageSize)
+
+	var all []packages.Registry
+	for registry, err := range paginate(ctx, func(page int) (*Page[packages.Registry], error) {
+		resp, err := c.packagesAPI().GetRegistriesWithResponse(ctx, &packages.GetRegistriesParams{
+			Page:    &page,
+			PerPage: &perPage,
+		}, call.packagesEditors()...)
+		if err != nil {
+			return nil, fmt.Errorf("list registries: %w", err)
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, newAPIError("list registries", resp.HTTPResponse, resp.Body)
+		}
+
+		if resp.JSON200 == nil {
+			return nil, nil
+		}
+
+		return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{Page: page, PerPage: perPage}), nil
+	}) {
+		if err != nil {
+			return nil, budgetErr(ctx, err)
+		}
+		all = append(all, registry)
+	}
+	return all, nil
 }
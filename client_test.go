@@ -2,7 +2,20 @@ package ecosystems
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
 )
 
 func TestNewClient(t *testing.T) {
@@ -38,6 +51,226 @@ func TestNewClientWithOptions(t *testing.T) {
 	}
 }
 
+func TestWithRequestEditor(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.URL),
+		WithAPIKey("key"),
+		WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Correlation-Id", "123")
+			return nil
+		}),
+		WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Token custom")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if v := got.Get("X-Correlation-Id"); v != "123" {
+		t.Errorf("X-Correlation-Id = %q, want %q", v, "123")
+	}
+	if v := got.Get("Authorization"); v != "Token custom" {
+		t.Errorf("Authorization = %q, want %q", v, "Token custom")
+	}
+}
+
+func TestWithRequestEditorError(t *testing.T) {
+	errEditor := errors.New("no credentials")
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://127.0.0.1:1"),
+		WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+			return errEditor
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); !errors.Is(err, errEditor) {
+		t.Errorf("ListRegistries() error = %v, want %v", err, errEditor)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://packages.invalid/api/v1"),
+		WithProxy(proxy.URL),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if want := "http://packages.invalid/api/v1/registries?page=1&per_page=100"; gotURL != want {
+		t.Errorf("proxied URL = %q, want %q", gotURL, want)
+	}
+}
+
+func TestWithProxyInvalid(t *testing.T) {
+	if _, err := NewClient("test-agent/1.0", WithProxy("://bad")); err == nil {
+		t.Error("NewClient() with invalid proxy URL should error")
+	}
+}
+
+func TestTransportOptions(t *testing.T) {
+	cfg := &clientConfig{maxConnsPerHost: 100, dialTimeout: 10 * time.Second}
+	WithMaxConnsPerHost(8)(cfg)
+	WithDialTimeout(time.Second)(cfg)
+
+	hc, err := defaultHTTPClient(cfg)
+	if err != nil {
+		t.Fatalf("defaultHTTPClient() error = %v", err)
+	}
+	transport := hc.Transport.(*http.Transport)
+	if transport.MaxConnsPerHost != 8 {
+		t.Errorf("MaxConnsPerHost = %d, want 8", transport.MaxConnsPerHost)
+	}
+	if transport.Proxy != nil {
+		t.Error("Proxy set without WithProxy")
+	}
+}
+
+func TestWithBulkBatchSize(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.PackagesURL()), WithBulkBatchSize(2))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results, err := client.BulkLookup(context.Background(), []string{"pkg:gem/rails", "pkg:npm/lodash", "pkg:npm/a", "pkg:npm/b", "pkg:npm/c"})
+	if err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("BulkLookup() returned %d packages, want 2", len(results))
+	}
+	if got := len(srv.Requests()); got != 3 {
+		t.Errorf("BulkLookup() made %d requests, want 3", got)
+	}
+}
+
+func TestWithTokenProvider(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	tokens := []string{"first", "second", ""}
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.URL),
+		WithAPIKey("static"),
+		WithTokenProvider(func(ctx context.Context) (string, error) {
+			token := tokens[0]
+			tokens = tokens[1:]
+			return token, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	for range 3 {
+		if _, err := client.ListRegistries(context.Background()); err != nil {
+			t.Fatalf("ListRegistries() error = %v", err)
+		}
+	}
+	want := []string{"Bearer first", "Bearer second", ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Authorization headers = %q, want %q", got, want)
+	}
+}
+
+func TestWithTokenProviderError(t *testing.T) {
+	errVault := errors.New("vault sealed")
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://127.0.0.1:1"),
+		WithTokenProvider(func(ctx context.Context) (string, error) {
+			return "", errVault
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); !errors.Is(err, errVault) {
+		t.Errorf("ListRegistries() error = %v, want %v", err, errVault)
+	}
+}
+
+func TestWithBulkBatchSizeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"default", nil, false},
+		{"smaller", []Option{WithBulkBatchSize(10)}, false},
+		{"negative", []Option{WithBulkBatchSize(-1)}, true},
+		{"above maximum", []Option{WithBulkBatchSize(500)}, true},
+		{"above maximum with API key", []Option{WithBulkBatchSize(500), WithAPIKey("key")}, false},
+		{"above maximum with token provider", []Option{WithBulkBatchSize(500), WithTokenProvider(func(context.Context) (string, error) { return "key", nil })}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("test-agent/1.0", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratedClients(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	pkgResp, err := client.Packages().GetRegistriesWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Packages().GetRegistriesWithResponse() error = %v", err)
+	}
+	if pkgResp.StatusCode() != http.StatusOK || pkgResp.JSON200 == nil {
+		t.Errorf("Packages().GetRegistriesWithResponse() status = %d", pkgResp.StatusCode())
+	}
+
+	ownerResp, err := client.Repos().GetHostOwnerWithResponse(context.Background(), "GitHub", "rails")
+	if err != nil {
+		t.Fatalf("Repos().GetHostOwnerWithResponse() error = %v", err)
+	}
+	if ownerResp.StatusCode() != http.StatusOK || ownerResp.JSON200 == nil {
+		t.Errorf("Repos().GetHostOwnerWithResponse() status = %d", ownerResp.StatusCode())
+	}
+}
+
 func TestBulkLookupEmpty(t *testing.T) {
 	client, err := NewClient("test-agent/1.0")
 	if err != nil {
@@ -52,3 +285,341 @@ func TestBulkLookupEmpty(t *testing.T) {
 		t.Errorf("BulkLookup([]) = %d results, want 0", len(results))
 	}
 }
+
+func newTestClient(t *testing.T) (*Client, *ecosystemstest.Server) {
+	t.Helper()
+	srv := ecosystemstest.NewServer()
+	t.Cleanup(srv.Close)
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithReposServer(srv.ReposURL()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, srv
+}
+
+func TestBulkLookup(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	results, err := client.BulkLookup(context.Background(), []string{"pkg:gem/rails", "pkg:npm/lodash", "pkg:npm/missing"})
+	if err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("BulkLookup() = %d results, want 2", len(results))
+	}
+	if results["pkg:gem/rails"].Registry.Name != "rubygems.org" {
+		t.Errorf("rails registry = %q, want %q", results["pkg:gem/rails"].Registry.Name, "rubygems.org")
+	}
+}
+
+func TestBulkLookupBatches(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purls := make([]string, MaxBulkLookupSize+1)
+	for i := range purls {
+		purls[i] = fmt.Sprintf("pkg:npm/pkg-%d", i)
+	}
+	if _, err := client.BulkLookup(context.Background(), purls); err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestBulkLookupDetailed(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	result, err := client.BulkLookupDetailed(context.Background(), []string{"pkg:gem/rails", "pkg:npm/nope", "pkg:npm/lodash", "pkg:gem/nope"})
+	if err != nil {
+		t.Fatalf("BulkLookupDetailed() error = %v", err)
+	}
+	if len(result.Packages) != 2 {
+		t.Errorf("BulkLookupDetailed() = %d packages, want 2", len(result.Packages))
+	}
+	want := []string{"pkg:npm/nope", "pkg:gem/nope"}
+	if !reflect.DeepEqual(result.Missing, want) {
+		t.Errorf("BulkLookupDetailed() missing = %v, want %v", result.Missing, want)
+	}
+}
+
+func TestBulkLookupStrict(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.BulkLookup(ctx, []string{"pkg:gem/rails"}, CallStrict()); err != nil {
+		t.Errorf("BulkLookup() with all PURLs found error = %v", err)
+	}
+
+	_, err := client.BulkLookup(ctx, []string{"pkg:gem/rails", "pkg:npm/nope"}, CallStrict())
+	var missing *MissingPURLsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("BulkLookup() error = %v, want *MissingPURLsError", err)
+	}
+	if !reflect.DeepEqual(missing.PURLs, []string{"pkg:npm/nope"}) {
+		t.Errorf("MissingPURLsError.PURLs = %v, want [pkg:npm/nope]", missing.PURLs)
+	}
+}
+
+func TestBulkLookupStream(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	found := map[string]string{}
+	var missing []string
+	err := client.BulkLookupStream(context.Background(), []string{"pkg:gem/rails", "pkg:npm/nope"}, func(purl string, pkg *packages.PackageWithRegistry) error {
+		if pkg == nil {
+			missing = append(missing, purl)
+		} else {
+			found[purl] = pkg.Name
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("BulkLookupStream() error = %v", err)
+	}
+	if found["pkg:gem/rails"] != "rails" {
+		t.Errorf("BulkLookupStream() found = %v, want rails", found)
+	}
+	if !reflect.DeepEqual(missing, []string{"pkg:npm/nope"}) {
+		t.Errorf("BulkLookupStream() missing = %v, want [pkg:npm/nope]", missing)
+	}
+}
+
+func TestBulkLookupStreamStopsOnError(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purls := make([]string, MaxBulkLookupSize+1)
+	for i := range purls {
+		purls[i] = fmt.Sprintf("pkg:npm/pkg-%d", i)
+	}
+	errStop := errors.New("stop")
+	err := client.BulkLookupStream(context.Background(), purls, func(string, *packages.PackageWithRegistry) error {
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("BulkLookupStream() error = %v, want %v", err, errStop)
+	}
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestLookupByRegistryAndName(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	pkg, err := client.LookupByRegistryAndName(context.Background(), "rubygems.org", "rails")
+	if err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if pkg == nil || pkg.Name != "rails" {
+		t.Fatalf("LookupByRegistryAndName() = %v, want rails", pkg)
+	}
+
+	pkg, err = client.LookupByRegistryAndName(context.Background(), "rubygems.org", "missing")
+	if err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if pkg != nil {
+		t.Errorf("LookupByRegistryAndName(missing) = %v, want nil", pkg)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	v, err := client.GetVersion(context.Background(), "rubygems.org", "rails", "7.1.0")
+	if err != nil {
+		t.Fatalf("GetVersion() error = %v", err)
+	}
+	if v == nil || v.Number != "7.1.0" {
+		t.Fatalf("GetVersion() = %v, want 7.1.0", v)
+	}
+	if len(v.Dependencies) != 1 {
+		t.Errorf("Dependencies = %d, want 1", len(v.Dependencies))
+	}
+}
+
+func TestGetAllVersions(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	versions, err := client.GetAllVersions(context.Background(), "rubygems.org", "rails")
+	if err != nil {
+		t.Fatalf("GetAllVersions() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Errorf("GetAllVersions() = %d versions, want 3", len(versions))
+	}
+}
+
+func TestGetVersionNumbers(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	numbers, err := client.GetVersionNumbers(context.Background(), "rubygems.org", "rails")
+	if err != nil {
+		t.Fatalf("GetVersionNumbers() error = %v", err)
+	}
+	if len(numbers) != 3 || !slices.Contains(numbers, "7.1.0") {
+		t.Errorf("GetVersionNumbers() = %v, want 3 numbers including 7.1.0", numbers)
+	}
+	if got := countRequests(srv.Requests(), "/version_numbers"); got != 1 {
+		t.Errorf("version_numbers requests = %d, want 1", got)
+	}
+
+	numbers, err = client.GetVersionNumbers(context.Background(), "rubygems.org", "does-not-exist")
+	if err != nil || numbers != nil {
+		t.Errorf("GetVersionNumbers(missing) = %v, %v, want nil, nil", numbers, err)
+	}
+}
+
+// versionPagesServer serves total versions numbered 1..total, optionally
+// without pagination headers, and records the peak number of requests in
+// flight.
+func versionPagesServer(t *testing.T, total int, headers bool, failPage int) (*httptest.Server, *int32) {
+	t.Helper()
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if headers {
+			w.Header().Set("Total-Count", strconv.Itoa(total))
+			w.Header().Set("Total-Pages", strconv.Itoa((total+perPage-1)/perPage))
+		}
+		var versions []map[string]string
+		for i := (page-1)*perPage + 1; i <= page*perPage && i <= total; i++ {
+			versions = append(versions, map[string]string{"number": strconv.Itoa(i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(versions)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &peak
+}
+
+func TestGetAllVersionsParallel(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  bool
+		wantPeak int32
+	}{
+		{"with totals", true, maxPageWorkers},
+		{"without totals", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, peak := versionPagesServer(t, 95, tt.headers, 0)
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			versions, err := client.GetAllVersions(context.Background(), "npmjs.org", "big", CallPageSize(10))
+			if err != nil {
+				t.Fatalf("GetAllVersions() error = %v", err)
+			}
+			if len(versions) != 95 {
+				t.Fatalf("GetAllVersions() = %d versions, want 95", len(versions))
+			}
+			for i, v := range versions {
+				if v.Number != strconv.Itoa(i+1) {
+					t.Fatalf("versions[%d] = %q, want %q", i, v.Number, strconv.Itoa(i+1))
+				}
+			}
+			if got := atomic.LoadInt32(peak); got > tt.wantPeak {
+				t.Errorf("peak concurrent requests = %d, want at most %d", got, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestGetAllVersionsParallelError(t *testing.T) {
+	srv, _ := versionPagesServer(t, 95, true, 5)
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	versions, err := client.GetAllVersions(context.Background(), "npmjs.org", "big", CallPageSize(10))
+	if err == nil {
+		t.Fatal("GetAllVersions() error = nil, want status error")
+	}
+	if versions != nil {
+		t.Errorf("GetAllVersions() = %d versions, want nil", len(versions))
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	repo, err := client.GetRepository(context.Background(), "https://github.com/rails/rails")
+	if err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+	if repo == nil || *repo.FullName != "rails/rails" {
+		t.Fatalf("GetRepository() = %v, want rails/rails", repo)
+	}
+}
+
+func TestGetRepositoryByHostAndName(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		host     string
+		fullName string
+		want     string
+	}{
+		{"GitHub", "rails/rails", "rails/rails"},
+		{"GitHub", "/lodash/lodash/", "lodash/lodash"},
+		{"GitLab.com", "gitlab-org/gitlab", "gitlab-org/gitlab"},
+		{"GitHub", "gitlab-org/gitlab", ""},
+		{"GitHub", "rails/missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host+"/"+tt.fullName, func(t *testing.T) {
+			repo, err := client.GetRepositoryByHostAndName(context.Background(), tt.host, tt.fullName)
+			if err != nil {
+				t.Fatalf("GetRepositoryByHostAndName() error = %v", err)
+			}
+			var got string
+			if repo != nil {
+				got = *repo.FullName
+			}
+			if got != tt.want {
+				t.Errorf("GetRepositoryByHostAndName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRegistries(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	registries, err := client.ListRegistries(context.Background())
+	if err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if len(registries) != 2 {
+		t.Errorf("ListRegistries() = %d registries, want 2", len(registries))
+	}
+}
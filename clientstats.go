@@ -0,0 +1,164 @@
+package ecosystems
+
+import (
+	"io"
+	"maps"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RequestKey identifies a group of requests counted in ClientStats.
+type RequestKey struct {
+	// Endpoint is the client method the requests were made for, such as
+	// "BulkLookup", or the URL path for requests made outside one.
+	Endpoint string
+	// StatusCode is the response status, or 0 for requests that failed
+	// without a response.
+	StatusCode int
+}
+
+// ClientStats is a snapshot of a client's counters since it was created.
+type ClientStats struct {
+	// Requests counts requests by endpoint and status.
+	Requests map[RequestKey]int64
+	// Retries counts requests sent again, after a 429 response or a
+	// compressed body the server rejected.
+	Retries int64
+	// CacheHits counts responses answered by the offline snapshot or marked
+	// with X-From-Cache by a caching transport. CacheMisses counts requests
+	// the offline snapshot could not answer.
+	CacheHits   int64
+	CacheMisses int64
+	// BytesSent and BytesReceived count request and response body bytes,
+	// before compression.
+	BytesSent     int64
+	BytesReceived int64
+	// RateLimitWaits counts waits after 429 responses, and RateLimitWaitTime
+	// is their total duration.
+	RateLimitWaits    int64
+	RateLimitWaitTime time.Duration
+}
+
+// TotalRequests returns the number of requests across all endpoints and
+// statuses.
+func (s ClientStats) TotalRequests() int64 {
+	var n int64
+	for _, count := range s.Requests {
+		n += count
+	}
+	return n
+}
+
+// Stats returns the client's request, retry, cache, transfer and rate
+// limit counters, for monitoring without a telemetry stack.
+func (c *Client) Stats() ClientStats {
+	return c.stats.snapshot()
+}
+
+// clientStats holds a client's counters. A nil *clientStats counts nothing.
+type clientStats struct {
+	mu       sync.Mutex
+	requests map[RequestKey]int64
+
+	retries        atomic.Int64
+	cacheHits      atomic.Int64
+	cacheMisses    atomic.Int64
+	bytesSent      atomic.Int64
+	bytesReceived  atomic.Int64
+	rateLimitWaits atomic.Int64
+	rateLimitWait  atomic.Int64
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{requests: make(map[RequestKey]int64)}
+}
+
+func (s *clientStats) snapshot() ClientStats {
+	s.mu.Lock()
+	requests := maps.Clone(s.requests)
+	s.mu.Unlock()
+	return ClientStats{
+		Requests:          requests,
+		Retries:           s.retries.Load(),
+		CacheHits:         s.cacheHits.Load(),
+		CacheMisses:       s.cacheMisses.Load(),
+		BytesSent:         s.bytesSent.Load(),
+		BytesReceived:     s.bytesReceived.Load(),
+		RateLimitWaits:    s.rateLimitWaits.Load(),
+		RateLimitWaitTime: time.Duration(s.rateLimitWait.Load()),
+	}
+}
+
+func (s *clientStats) request(endpoint string, status int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.requests[RequestKey{Endpoint: endpoint, StatusCode: status}]++
+	s.mu.Unlock()
+}
+
+func (s *clientStats) retry() {
+	if s != nil {
+		s.retries.Add(1)
+	}
+}
+
+func (s *clientStats) cache(hit bool) {
+	switch {
+	case s == nil:
+	case hit:
+		s.cacheHits.Add(1)
+	default:
+		s.cacheMisses.Add(1)
+	}
+}
+
+func (s *clientStats) rateLimited(d time.Duration) {
+	if s != nil {
+		s.rateLimitWaits.Add(1)
+		s.rateLimitWait.Add(int64(d))
+	}
+}
+
+// statsTransport counts requests, their status and body sizes.
+type statsTransport struct {
+	next  http.RoundTripper
+	stats *clientStats
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.URL.Path
+	if op, ok := operationFrom(req.Context()); ok {
+		endpoint = op.name
+	}
+	if req.ContentLength > 0 {
+		t.stats.bytesSent.Add(req.ContentLength)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.stats.request(endpoint, 0)
+		return nil, err
+	}
+	t.stats.request(endpoint, resp.StatusCode)
+	if resp.Header.Get("X-From-Cache") != "" {
+		t.stats.cache(true)
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, n: &t.stats.bytesReceived}
+	return resp, nil
+}
+
+// countingBody adds the bytes read from a response body to n.
+type countingBody struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
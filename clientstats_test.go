@@ -0,0 +1,93 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientStats(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.LookupByRegistryAndName(ctx, "rubygems.org", "rails"); err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if _, err := client.LookupByRegistryAndName(ctx, "rubygems.org", "missing"); err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if _, err := client.BulkLookup(ctx, []string{"pkg:npm/lodash"}); err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+
+	stats := client.Stats()
+	want := map[RequestKey]int64{
+		{"LookupByRegistryAndName", http.StatusOK}:       1,
+		{"LookupByRegistryAndName", http.StatusNotFound}: 1,
+		{"BulkLookup", http.StatusOK}:                    1,
+	}
+	for key, n := range want {
+		if stats.Requests[key] != n {
+			t.Errorf("Requests[%v] = %d, want %d", key, stats.Requests[key], n)
+		}
+	}
+	if stats.TotalRequests() != 3 {
+		t.Errorf("TotalRequests() = %d, want 3", stats.TotalRequests())
+	}
+	if stats.BytesSent == 0 || stats.BytesReceived == 0 {
+		t.Errorf("BytesSent = %d, BytesReceived = %d, want both counted", stats.BytesSent, stats.BytesReceived)
+	}
+	if stats.Retries != 0 || stats.CacheHits != 0 || stats.CacheMisses != 0 || stats.RateLimitWaits != 0 {
+		t.Errorf("Stats() = %+v, want no retries, cache use or waits", stats)
+	}
+
+	stats.Requests[RequestKey{"BulkLookup", http.StatusOK}] = 100
+	if n := client.Stats().Requests[RequestKey{"BulkLookup", http.StatusOK}]; n != 1 {
+		t.Errorf("Stats() shares its Requests map with the client: %d", n)
+	}
+}
+
+func TestClientStatsOffline(t *testing.T) {
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://127.0.0.1:1"),
+		WithOfflineStore(offlineSnapshot(), OfflineOnly),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	_, _ = client.LookupByRegistryAndName(ctx, "npmjs.org", "@scope/offline")
+	_, _ = client.BulkLookup(ctx, []string{"pkg:npm/%40scope/offline"})
+	_, _ = client.LookupByRegistryAndName(ctx, "npmjs.org", "absent")
+	_, _ = client.GetAllVersions(ctx, "npmjs.org", "@scope/offline")
+
+	stats := client.Stats()
+	if stats.CacheHits != 2 || stats.CacheMisses != 2 {
+		t.Errorf("CacheHits = %d, CacheMisses = %d, want 2 and 2", stats.CacheHits, stats.CacheMisses)
+	}
+	if n := stats.Requests[RequestKey{"GetAllVersions", 0}]; n != 1 {
+		t.Errorf("Requests[GetAllVersions, 0] = %d, want 1", n)
+	}
+}
+
+func TestClientStatsHTTPCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-From-Cache", "1")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if stats := client.Stats(); stats.CacheHits != 1 || stats.BytesReceived != 2 {
+		t.Errorf("CacheHits = %d, BytesReceived = %d, want 1 and 2", stats.CacheHits, stats.BytesReceived)
+	}
+}
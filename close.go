@@ -0,0 +1,20 @@
+package ecosystems
+
+import "errors"
+
+// ErrClientClosed is returned by requests made after Close.
+var ErrClientClosed = errors.New("client closed")
+
+// Close releases the idle connections held by the client's transport when
+// NewClient created it; a client given WithHTTPClient leaves its transport
+// alone. Requests made after Close fail with ErrClientClosed. Requests in
+// flight are not interrupted. Close always returns nil.
+func (c *Client) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClose(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("ListRegistries() after Close error = %v, want ErrClientClosed", err)
+	}
+	if _, err := client.SyncPackage(context.Background(), "npmjs.org", "lodash"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("SyncPackage() after Close error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestCloseOwnedTransport(t *testing.T) {
+	client, err := NewClient("test-agent/1.0")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.transport == nil {
+		t.Error("transport = nil, want the default transport")
+	}
+
+	client, err = NewClient("test-agent/1.0", WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.transport != nil {
+		t.Error("transport set for a caller-supplied http.Client")
+	}
+}
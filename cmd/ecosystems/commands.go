@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func runLookup(ctx context.Context, client *ecosystems.Client, call []ecosystems.CallOption, out *printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ecosystems lookup <purl>")
+	}
+	pkg, err := client.Lookup(ctx, args[0], call...)
+	if err != nil {
+		return err
+	}
+	if pkg == nil {
+		return fmt.Errorf("package not found: %s", args[0])
+	}
+	return out.packages([]*packages.PackageWithRegistry{pkg})
+}
+
+func runBulk(ctx context.Context, client *ecosystems.Client, call []ecosystems.CallOption, out *printer, args []string, stdin io.Reader) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: ecosystems bulk [file]")
+	}
+
+	r := stdin
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	purls, err := readPURLs(r)
+	if err != nil {
+		return err
+	}
+
+	results, err := client.BulkLookup(ctx, purls, call...)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(results))
+	for purl := range results {
+		keys = append(keys, purl)
+	}
+	sort.Strings(keys)
+
+	pkgs := make([]*packages.PackageWithRegistry, 0, len(keys))
+	for _, k := range keys {
+		pkgs = append(pkgs, results[k])
+	}
+	return out.packages(pkgs)
+}
+
+func runVersions(ctx context.Context, client *ecosystems.Client, call []ecosystems.CallOption, out *printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ecosystems versions <purl>")
+	}
+	purl, err := client.ParsePURL(args[0])
+	if err != nil {
+		return err
+	}
+	versions, err := client.GetAllVersionsPURL(ctx, purl, call...)
+	if err != nil {
+		return err
+	}
+	return out.versions(versions)
+}
+
+func runRepo(ctx context.Context, client *ecosystems.Client, call []ecosystems.CallOption, out *printer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ecosystems repo <url>")
+	}
+	repo, err := client.GetRepository(ctx, args[0], call...)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return fmt.Errorf("repository not found: %s", args[0])
+	}
+	return out.repository(repo)
+}
+
+func runRegistries(ctx context.Context, client *ecosystems.Client, call []ecosystems.CallOption, out *printer, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: ecosystems registries")
+	}
+	registries, err := client.ListRegistries(ctx, call...)
+	if err != nil {
+		return err
+	}
+	return out.registries(registries)
+}
+
+// readPURLs reads one PURL per line, skipping blank lines and # comments.
+func readPURLs(r io.Reader) ([]string, error) {
+	var purls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		purls = append(purls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading PURLs: %w", err)
+	}
+	return purls, nil
+}
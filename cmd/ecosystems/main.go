@@ -0,0 +1,191 @@
+// Command ecosystems is a command-line interface to the ecosyste.ms APIs.
+//
+// Usage:
+//
+//	ecosystems [flags] <command> [args]
+//
+// Commands:
+//
+//	lookup <purl>       look up a single package
+//	bulk [file]         look up PURLs read one per line from file or stdin
+//	versions <purl>     list all versions of a package
+//	repo <url>          look up a repository by URL
+//	registries          list available registries
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+)
+
+const defaultUserAgent = "ecosystems-cli"
+
+// options holds the command-line flags. They cover the client options that
+// take plain values; options for Go callbacks and integrations, such as
+// WithTokenProvider, WithRequestEditor, WithLogger, WithTracerProvider,
+// WithRecorder and WithOfflineStore, have no flag.
+type options struct {
+	json             bool
+	table            bool
+	userAgent        string
+	from             string
+	apiKey           string
+	packagesServer   string
+	reposServer      string
+	baseDomain       string
+	timeout          time.Duration
+	overallTimeout   time.Duration
+	dialTimeout      time.Duration
+	proxy            string
+	maxConnsPerHost  int
+	batchSize        int
+	pageSize         int
+	hostRateLimits   map[string]float64
+	maxRetries       int
+	compression      bool
+	maxResponseBytes int64
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	debug            bool
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "ecosystems:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts options
+	fs := flag.NewFlagSet("ecosystems", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&opts.json, "json", false, "output JSON")
+	fs.BoolVar(&opts.table, "table", false, "output a table (default)")
+	fs.StringVar(&opts.userAgent, "user-agent", defaultUserAgent, "User-Agent header to send")
+	fs.StringVar(&opts.from, "from", os.Getenv("ECOSYSTEMS_FROM"), "From header (email address)")
+	fs.StringVar(&opts.apiKey, "api-key", os.Getenv("ECOSYSTEMS_API_KEY"), "API key for authenticated requests")
+	fs.StringVar(&opts.packagesServer, "packages-server", "", "packages API base URL (default "+ecosystems.DefaultPackagesServer+")")
+	fs.StringVar(&opts.reposServer, "repos-server", "", "repos API base URL (default "+ecosystems.DefaultReposServer+")")
+	fs.StringVar(&opts.baseDomain, "base-domain", "", "derive service URLs from this domain, as https://<service>.<domain>/api/v1")
+	fs.DurationVar(&opts.timeout, "timeout", ecosystems.DefaultTimeout, "timeout for each HTTP request")
+	fs.DurationVar(&opts.overallTimeout, "overall-timeout", 0, "total time limit for commands that make several requests (0 means none)")
+	fs.DurationVar(&opts.dialTimeout, "dial-timeout", 10*time.Second, "TCP connect timeout")
+	fs.StringVar(&opts.proxy, "proxy", "", "send requests through this proxy URL")
+	fs.IntVar(&opts.maxConnsPerHost, "max-conns", 100, "maximum connections per host (0 means no limit)")
+	fs.IntVar(&opts.batchSize, "batch-size", 0, fmt.Sprintf("PURLs per bulk lookup request (default %d)", ecosystems.MaxBulkLookupSize))
+	fs.IntVar(&opts.pageSize, "page-size", 0, fmt.Sprintf("results per page for paginated requests (default %d)", ecosystems.DefaultPageSize))
+	fs.Func("rate-limit", "throttle a host to `host=rps` requests per second (repeatable)", func(v string) error {
+		host, rps, ok := strings.Cut(v, "=")
+		if !ok || host == "" {
+			return fmt.Errorf("want host=rps, got %q", v)
+		}
+		n, err := strconv.ParseFloat(rps, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid requests per second %q", rps)
+		}
+		if opts.hostRateLimits == nil {
+			opts.hostRateLimits = make(map[string]float64)
+		}
+		opts.hostRateLimits[host] = n
+		return nil
+	})
+	fs.IntVar(&opts.maxRetries, "max-retries", 0, "retries of rate limited requests (default 8, -1 disables)")
+	fs.BoolVar(&opts.compression, "compression", false, "accept zstd and brotli compressed responses")
+	fs.Int64Var(&opts.maxResponseBytes, "max-response-bytes", 0, "limit each response body to this many bytes (0 means no limit)")
+	fs.IntVar(&opts.breakerThreshold, "breaker-threshold", 0, "consecutive failures before failing fast for a host (0 disables)")
+	fs.DurationVar(&opts.breakerCooldown, "breaker-cooldown", 30*time.Second, "how long a host fails fast once -breaker-threshold is reached")
+	fs.BoolVar(&opts.debug, "debug", false, "write requests and responses to stderr")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: ecosystems [flags] <lookup|bulk|versions|repo|registries> [args]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if opts.json && opts.table {
+		return fmt.Errorf("-json and -table are mutually exclusive")
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no command given")
+	}
+
+	client, err := newClient(opts, stderr)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	out := &printer{w: stdout, json: opts.json}
+	ctx := context.Background()
+	call := []ecosystems.CallOption{ecosystems.CallTimeout(opts.timeout)}
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+
+	switch cmd {
+	case "lookup":
+		return runLookup(ctx, client, call, out, cmdArgs)
+	case "bulk":
+		return runBulk(ctx, client, call, out, cmdArgs, stdin)
+	case "versions":
+		return runVersions(ctx, client, call, out, cmdArgs)
+	case "repo":
+		return runRepo(ctx, client, call, out, cmdArgs)
+	case "registries":
+		return runRegistries(ctx, client, call, out, cmdArgs)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func newClient(opts options, stderr io.Writer) (*ecosystems.Client, error) {
+	clientOpts := []ecosystems.Option{
+		ecosystems.WithOverallTimeout(opts.overallTimeout),
+		ecosystems.WithDialTimeout(opts.dialTimeout),
+		ecosystems.WithMaxConnsPerHost(opts.maxConnsPerHost),
+		ecosystems.WithBulkBatchSize(opts.batchSize),
+		ecosystems.WithDefaultPageSize(opts.pageSize),
+		ecosystems.WithMaxRetries(opts.maxRetries),
+		ecosystems.WithMaxResponseBytes(opts.maxResponseBytes),
+	}
+	if opts.baseDomain != "" {
+		clientOpts = append(clientOpts, ecosystems.WithBaseDomain(opts.baseDomain))
+	}
+	if opts.packagesServer != "" {
+		clientOpts = append(clientOpts, ecosystems.WithPackagesServer(opts.packagesServer))
+	}
+	if opts.reposServer != "" {
+		clientOpts = append(clientOpts, ecosystems.WithReposServer(opts.reposServer))
+	}
+	if opts.proxy != "" {
+		clientOpts = append(clientOpts, ecosystems.WithProxy(opts.proxy))
+	}
+	for host, rps := range opts.hostRateLimits {
+		clientOpts = append(clientOpts, ecosystems.WithHostRateLimit(host, rps))
+	}
+	if opts.compression {
+		clientOpts = append(clientOpts, ecosystems.WithCompression())
+	}
+	if opts.breakerThreshold > 0 {
+		clientOpts = append(clientOpts, ecosystems.WithCircuitBreaker(opts.breakerThreshold, opts.breakerCooldown))
+	}
+	if opts.debug {
+		clientOpts = append(clientOpts, ecosystems.WithDebug(stderr))
+	}
+	if opts.from != "" {
+		clientOpts = append(clientOpts, ecosystems.WithFrom(opts.from))
+	}
+	if opts.apiKey != "" {
+		clientOpts = append(clientOpts, ecosystems.WithAPIKey(opts.apiKey))
+	}
+	return ecosystems.NewClient(opts.userAgent, clientOpts...)
+}
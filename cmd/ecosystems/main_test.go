@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestReadPURLs(t *testing.T) {
+	input := "pkg:gem/rails\n\n# comment\n  pkg:npm/lodash  \n"
+	got, err := readPURLs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readPURLs() error = %v", err)
+	}
+	want := []string{"pkg:gem/rails", "pkg:npm/lodash"}
+	if len(got) != len(want) {
+		t.Fatalf("readPURLs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("readPURLs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunRequiresCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run(nil, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Fatal("run() with no command should error")
+	}
+}
+
+func TestRunRejectsConflictingFormats(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-json", "-table", "registries"}, strings.NewReader(""), &stdout, &stderr)
+	if err == nil {
+		t.Fatal("run() with -json and -table should error")
+	}
+}
+
+func TestRunClientFlags(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		// -page-size 7 marks the request the timeout case expects to be slow.
+		if r.URL.Query().Get("per_page") == "7" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"defaults", nil, ""},
+		{"page size and rate limit", []string{"-page-size", "3", "-rate-limit", "127.0.0.1=100"}, ""},
+		{"max retries", []string{"-max-retries", "-1"}, ""},
+		{"request timeout", []string{"-page-size", "7", "-timeout", "20ms"}, "deadline exceeded"},
+		{"bad rate limit", []string{"-rate-limit", "127.0.0.1"}, "host=rps"},
+		{"bad batch size", []string{"-batch-size", "500"}, "bulk batch size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{"-packages-server", srv.URL}, tt.args...), "registries")
+			err := run(args, strings.NewReader(""), &stdout, &stderr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("run() error = %v", err)
+				}
+				if !strings.Contains(userAgent, "ecosystems-go/") {
+					t.Errorf("User-Agent = %q, want the client's own transport", userAgent)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error()+stderr.String(), tt.wantErr) {
+				t.Errorf("run() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrinterPackagesTable(t *testing.T) {
+	latest := "7.1.0"
+	var buf bytes.Buffer
+	p := &printer{w: &buf}
+	err := p.packages([]*packages.PackageWithRegistry{
+		{Purl: "pkg:gem/rails", LatestReleaseNumber: &latest, Registry: packages.Registry{Name: "rubygems.org"}},
+	})
+	if err != nil {
+		t.Fatalf("packages() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"PURL", "pkg:gem/rails", "rubygems.org", "7.1.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// printer writes command results as JSON or as an aligned table.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func (p *printer) encode(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p *printer) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	writeRow(tw, header)
+	for _, row := range rows {
+		writeRow(tw, row)
+	}
+	return tw.Flush()
+}
+
+func writeRow(w io.Writer, cols []string) {
+	for i, col := range cols {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, col)
+	}
+	fmt.Fprintln(w)
+}
+
+func (p *printer) packages(pkgs []*packages.PackageWithRegistry) error {
+	if p.json {
+		return p.encode(pkgs)
+	}
+	rows := make([][]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		rows = append(rows, []string{
+			pkg.Purl,
+			pkg.Registry.Name,
+			deref(pkg.LatestReleaseNumber),
+			deref(pkg.Licenses),
+			deref(pkg.RepositoryUrl),
+		})
+	}
+	return p.table([]string{"PURL", "REGISTRY", "LATEST", "LICENSES", "REPOSITORY"}, rows)
+}
+
+func (p *printer) versions(versions []packages.Version) error {
+	if p.json {
+		return p.encode(versions)
+	}
+	rows := make([][]string, 0, len(versions))
+	for _, v := range versions {
+		rows = append(rows, []string{v.Number, deref(v.PublishedAt), deref(v.Licenses)})
+	}
+	return p.table([]string{"NUMBER", "PUBLISHED", "LICENSES"}, rows)
+}
+
+func (p *printer) repository(repo *repos.Repository) error {
+	if p.json {
+		return p.encode(repo)
+	}
+	rows := [][]string{
+		{"full_name", deref(repo.FullName)},
+		{"url", deref(repo.HtmlUrl)},
+		{"description", deref(repo.Description)},
+		{"language", deref(repo.Language)},
+		{"license", deref(repo.License)},
+		{"stars", derefInt(repo.StargazersCount)},
+		{"forks", derefInt(repo.ForksCount)},
+		{"archived", derefBool(repo.Archived)},
+		{"pushed_at", derefTime(repo.PushedAt)},
+	}
+	return p.table([]string{"FIELD", "VALUE"}, rows)
+}
+
+func (p *printer) registries(registries []packages.Registry) error {
+	if p.json {
+		return p.encode(registries)
+	}
+	rows := make([][]string, 0, len(registries))
+	for _, r := range registries {
+		rows = append(rows, []string{
+			r.Name,
+			r.Ecosystem,
+			r.PurlType,
+			strconv.FormatInt(r.PackagesCount, 10),
+		})
+	}
+	return p.table([]string{"NAME", "ECOSYSTEM", "PURL TYPE", "PACKAGES"}, rows)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func derefBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func derefTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
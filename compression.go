@@ -0,0 +1,88 @@
+package ecosystems
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// acceptEncoding lists the encodings requested by WithCompression, most
+// preferred first.
+const acceptEncoding = "zstd, br, gzip"
+
+// WithCompression asks the API for zstd or brotli compressed responses, in
+// addition to the gzip the default transport requests, and decompresses
+// them transparently. They are smaller than gzip for large responses such
+// as full version lists and host repository pages.
+func WithCompression() Option {
+	return func(c *clientConfig) {
+		c.compression = true
+	}
+}
+
+// decompressTransport requests compressed responses and decompresses them.
+// Setting Accept-Encoding turns off http.Transport's own gzip handling, so
+// gzip is decompressed here too.
+type decompressTransport struct {
+	next http.RoundTripper
+}
+
+func (t *decompressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "zstd":
+		zr, err := zstd.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("zstd response: %w", err)
+		}
+		body = &decodedBody{Reader: zr, close: zr.Close, raw: resp.Body}
+	case "br":
+		body = &decodedBody{Reader: brotli.NewReader(resp.Body), raw: resp.Body}
+	case "gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("gzip response: %w", err)
+		}
+		body = &decodedBody{Reader: zr, raw: resp.Body}
+	default:
+		return resp, nil
+	}
+
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// decodedBody reads a decompressed response body and closes both the
+// decoder and the underlying body.
+type decodedBody struct {
+	io.Reader
+	close func()
+	raw   io.ReadCloser
+}
+
+func (b *decodedBody) Close() error {
+	if b.close != nil {
+		b.close()
+	}
+	return b.raw.Close()
+}
@@ -0,0 +1,100 @@
+package ecosystems
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+func compressBody(t *testing.T, encoding string, body []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	switch encoding {
+	case "zstd":
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = zw.Write(body)
+		_ = zw.Close()
+	case "br":
+		bw := brotli.NewWriter(&buf)
+		_, _ = bw.Write(body)
+		_ = bw.Close()
+	case "gzip":
+		gw := gzip.NewWriter(&buf)
+		_, _ = gw.Write(body)
+		_ = gw.Close()
+	default:
+		buf.Write(body)
+	}
+	return buf.Bytes()
+}
+
+func TestWithCompression(t *testing.T) {
+	body := []byte(`{"name":"lodash","ecosystem":"npm"}`)
+
+	tests := []struct {
+		name     string
+		encoding string
+	}{
+		{"zstd", "zstd"},
+		{"brotli", "br"},
+		{"gzip", "gzip"},
+		{"identity", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Type", "application/json")
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				_, _ = w.Write(compressBody(t, tt.encoding, body))
+			}))
+			defer srv.Close()
+
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithCompression())
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			pkg, err := client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash")
+			if err != nil {
+				t.Fatalf("LookupByRegistryAndName() error = %v", err)
+			}
+			if pkg == nil || pkg.Name != "lodash" {
+				t.Errorf("LookupByRegistryAndName() = %v, want lodash", pkg)
+			}
+			if accept != acceptEncoding {
+				t.Errorf("Accept-Encoding = %q, want %q", accept, acceptEncoding)
+			}
+		})
+	}
+}
+
+func TestWithCompressionInvalidBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte("not gzip"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithCompression())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	_, err = client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash")
+	if err == nil || !strings.Contains(err.Error(), "gzip response") {
+		t.Errorf("LookupByRegistryAndName() error = %v, want gzip response error", err)
+	}
+}
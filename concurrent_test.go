@@ -0,0 +1,50 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEach(t *testing.T) {
+	var sum, running, peak int32
+	err := forEach(context.Background(), []int32{1, 2, 3, 4, 5, 6}, 2, func(ctx context.Context, n int32) error {
+		cur := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if cur <= p || atomic.CompareAndSwapInt32(&peak, p, cur) {
+				break
+			}
+		}
+		atomic.AddInt32(&sum, n)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEach() error = %v", err)
+	}
+	if sum != 21 {
+		t.Errorf("sum = %d, want 21", sum)
+	}
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak)
+	}
+}
+
+func TestForEachError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls int32
+	err := forEach(context.Background(), make([]int, 100), 1, func(ctx context.Context, _ int) error {
+		if atomic.AddInt32(&calls, 1) == 3 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("forEach() error = %v, want %v", err, boom)
+	}
+	if calls > 4 {
+		t.Errorf("forEach() made %d calls after the error, want it to stop", calls)
+	}
+}
@@ -0,0 +1,105 @@
+package ecosystems
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// PackageFamily is a set of packages, possibly in different registries and
+// under different names, published from the same upstream repository.
+type PackageFamily struct {
+	RepositoryURL string
+	Packages      []packages.PackageWithRegistry
+}
+
+// FindPackagesByRepository returns the packages in every registry whose
+// repository URL is repoURL, after NormalizeRepoURL, ordered by registry
+// and name.
+func (c *Client) FindPackagesByRepository(ctx context.Context, repoURL string, opts ...CallOption) ([]packages.PackageWithRegistry, error) {
+	repoURL = NormalizeRepoURL(repoURL)
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "FindPackagesByRepository", "")
+	pkgs, err := c.lookupPackages(ctx, call, &packages.LookupPackageParams{RepositoryUrl: &repoURL})
+	if err != nil {
+		return nil, err
+	}
+	sortRegistryPackages(pkgs)
+	return pkgs, nil
+}
+
+// FindPackageEverywhere searches all registries for packages named name and
+// returns, for each upstream repository they point at, every package
+// published from that repository. This discovers a library published to
+// npm, Maven and NuGet under different names from any one of them. Families
+// with the most packages come first; packages without a repository URL are
+// left out, as nothing ties them to the others.
+func (c *Client) FindPackageEverywhere(ctx context.Context, name string, opts ...CallOption) ([]PackageFamily, error) {
+	call := newCallConfig(opts)
+	named, err := c.lookupPackages(withOperation(call.context(ctx), "FindPackageEverywhere", ""), call, &packages.LookupPackageParams{Name: &name})
+	if err != nil {
+		return nil, err
+	}
+
+	var families []PackageFamily
+	seen := make(map[string]bool)
+	for _, pkg := range named {
+		repoURL := packageRepoURL(pkg.RepositoryUrl, pkg.Homepage)
+		if repoURL == "" || seen[repoURL] {
+			continue
+		}
+		seen[repoURL] = true
+
+		pkgs, err := c.FindPackagesByRepository(ctx, repoURL, opts...)
+		if err != nil {
+			return nil, err
+		}
+		// The lookup matches the repository URL exactly, so keep the named
+		// packages whose metadata spells it differently.
+		for _, p := range named {
+			if packageRepoURL(p.RepositoryUrl, p.Homepage) == repoURL && !slices.ContainsFunc(pkgs, func(q packages.PackageWithRegistry) bool {
+				return q.Registry.Name == p.Registry.Name && q.Name == p.Name
+			}) {
+				pkgs = append(pkgs, p)
+			}
+		}
+		sortRegistryPackages(pkgs)
+		families = append(families, PackageFamily{RepositoryURL: repoURL, Packages: pkgs})
+	}
+
+	slices.SortStableFunc(families, func(a, b PackageFamily) int {
+		return cmp.Or(cmp.Compare(len(b.Packages), len(a.Packages)), cmp.Compare(a.RepositoryURL, b.RepositoryURL))
+	})
+	return families, nil
+}
+
+func (c *Client) lookupPackages(ctx context.Context, call *callConfig, params *packages.LookupPackageParams) ([]packages.PackageWithRegistry, error) {
+	resp, err := c.packagesAPI().LookupPackageWithResponse(ctx, params, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("lookup packages: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("lookup packages", resp.HTTPResponse, resp.Body)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+
+	return *resp.JSON200, nil
+}
+
+func sortRegistryPackages(pkgs []packages.PackageWithRegistry) {
+	slices.SortFunc(pkgs, func(a, b packages.PackageWithRegistry) int {
+		return cmp.Or(cmp.Compare(a.Registry.Name, b.Registry.Name), cmp.Compare(a.Name, b.Name))
+	})
+}
@@ -0,0 +1,75 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFindPackageEverywhere(t *testing.T) {
+	client, srv := newTestClient(t)
+	add := func(registry, name, repoURL string) {
+		pkg := registryPackage(registry, name)
+		if repoURL != "" {
+			pkg.RepositoryUrl = &repoURL
+		}
+		srv.AddPackage(registry, pkg)
+	}
+	protobuf := "https://github.com/protocolbuffers/protobuf"
+	add("npmjs.org", "protobuf", "git+https://github.com/other/protobuf-js.git")
+	add("pypi.org", "protobuf", protobuf)
+	add("repo1.maven.org", "com.google.protobuf:protobuf-java", protobuf)
+	add("nuget.org", "Google.Protobuf", protobuf)
+	add("rubygems.org", "protobuf", "")
+	ctx := context.Background()
+
+	families, err := client.FindPackageEverywhere(ctx, "protobuf")
+	if err != nil {
+		t.Fatalf("FindPackageEverywhere() error = %v", err)
+	}
+	var got []string
+	for _, f := range families {
+		got = append(got, f.RepositoryURL)
+		for _, p := range f.Packages {
+			got = append(got, p.Registry.Name+" "+p.Name)
+		}
+	}
+	want := []string{
+		protobuf,
+		"nuget.org Google.Protobuf",
+		"pypi.org protobuf",
+		"repo1.maven.org com.google.protobuf:protobuf-java",
+		"https://github.com/other/protobuf-js",
+		"npmjs.org protobuf",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPackageEverywhere() = %q, want %q", got, want)
+	}
+
+	families, err = client.FindPackageEverywhere(ctx, "does-not-exist")
+	if err != nil || len(families) != 0 {
+		t.Errorf("FindPackageEverywhere(unknown) = %v, %v, want none", families, err)
+	}
+}
+
+func TestFindPackagesByRepository(t *testing.T) {
+	client, srv := newTestClient(t)
+	repoURL := "https://github.com/example/lib"
+	for _, registry := range []string{"pypi.org", "npmjs.org"} {
+		pkg := registryPackage(registry, "lib")
+		pkg.RepositoryUrl = &repoURL
+		srv.AddPackage(registry, pkg)
+	}
+
+	pkgs, err := client.FindPackagesByRepository(context.Background(), "git@github.com:example/lib.git")
+	if err != nil {
+		t.Fatalf("FindPackagesByRepository() error = %v", err)
+	}
+	var registries []string
+	for _, p := range pkgs {
+		registries = append(registries, p.Registry.Name)
+	}
+	if want := []string{"npmjs.org", "pypi.org"}; !reflect.DeepEqual(registries, want) {
+		t.Errorf("FindPackagesByRepository() registries = %v, want %v", registries, want)
+	}
+}
@@ -0,0 +1,78 @@
+package ecosystems
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// WithDebug writes each request and response to w in HTTP/1.1 wire format,
+// for reporting API discrepancies. The Authorization and Cookie headers are
+// redacted. Bodies are left out unless WithDebugBodies is also given.
+func WithDebug(w io.Writer) Option {
+	return func(c *clientConfig) {
+		c.debugWriter = w
+	}
+}
+
+// WithDebugBodies includes request and response bodies in the output of
+// WithDebug. Response bodies are read into memory before being returned.
+func WithDebugBodies() Option {
+	return func(c *clientConfig) {
+		c.debugBodies = true
+	}
+}
+
+// debugTransport dumps requests and responses made through it.
+type debugTransport struct {
+	next   http.RoundTripper
+	bodies bool
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqDump, err := t.dumpRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("dumping request: %w", err)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	var respDump []byte
+	if err == nil {
+		respDump, err = httputil.DumpResponse(resp, t.bodies)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("dumping response: %w", err)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, "--> %s %s\n%s\n", req.Method, req.URL, reqDump)
+	if err != nil {
+		fmt.Fprintf(t.w, "<-- error: %v\n\n", err)
+		return nil, err
+	}
+	fmt.Fprintf(t.w, "<-- %s\n%s\n", resp.Status, respDump)
+	return resp, nil
+}
+
+// dumpRequest dumps req with sensitive headers redacted. The body is read
+// from a copy made by req.GetBody, leaving req untouched.
+func (t *debugTransport) dumpRequest(req *http.Request) ([]byte, error) {
+	clone := req.Clone(req.Context())
+	clone.Header = redactHeaders(req.Header)
+	withBody := t.bodies && req.GetBody != nil
+	if withBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return httputil.DumpRequest(clone, withBody)
+}
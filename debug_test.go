@@ -0,0 +1,56 @@
+package ecosystems
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithDebug(t *testing.T) {
+	tests := []struct {
+		name       string
+		bodies     bool
+		wantBodies bool
+	}{
+		{"headers only", false, false},
+		{"with bodies", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, srv := newTestClient(t)
+			var out strings.Builder
+			opts := []Option{WithPackagesServer(srv.PackagesURL()), WithAPIKey("secret-key"), WithDebug(&out)}
+			if tt.bodies {
+				opts = append(opts, WithDebugBodies())
+			}
+			client, err := NewClient("test-agent/1.0", opts...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			results, err := client.BulkLookup(context.Background(), []string{"pkg:gem/rails"})
+			if err != nil {
+				t.Fatalf("BulkLookup() error = %v", err)
+			}
+			if results["pkg:gem/rails"] == nil {
+				t.Fatal("BulkLookup() missing pkg:gem/rails")
+			}
+
+			dump := out.String()
+			if strings.Contains(dump, "secret-key") {
+				t.Errorf("debug output contains the API key:\n%s", dump)
+			}
+			for _, want := range []string{"--> POST ", "Authorization: REDACTED", "<-- 200 OK", "User-Agent: test-agent/1.0"} {
+				if !strings.Contains(dump, want) {
+					t.Errorf("debug output missing %q:\n%s", want, dump)
+				}
+			}
+			for _, body := range []string{`"purls":["pkg:gem/rails"]`, `"name":"rails"`} {
+				if got := strings.Contains(dump, body); got != tt.wantBodies {
+					t.Errorf("debug output contains %q = %v, want %v", body, got, tt.wantBodies)
+				}
+			}
+		})
+	}
+}
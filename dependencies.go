@@ -0,0 +1,93 @@
+package ecosystems
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// Dependency kinds as returned by NormalizeDependencyKind.
+const (
+	DependencyRuntime     = "runtime"
+	DependencyDevelopment = "development"
+	DependencyTest        = "test"
+	DependencyBuild       = "build"
+	DependencyOptional    = "optional"
+	DependencyPeer        = "peer"
+)
+
+// dependencyKinds maps the kinds and scopes registries report, lowercased,
+// to the kinds above.
+var dependencyKinds = map[string]string{
+	"":                     DependencyRuntime,
+	"runtime":              DependencyRuntime,
+	"normal":               DependencyRuntime,
+	"compile":              DependencyRuntime,
+	"dependencies":         DependencyRuntime,
+	"required":             DependencyRuntime,
+	"install":              DependencyRuntime,
+	"development":          DependencyDevelopment,
+	"dev":                  DependencyDevelopment,
+	"devdependencies":      DependencyDevelopment,
+	"dev-dependencies":     DependencyDevelopment,
+	"test":                 DependencyTest,
+	"build":                DependencyBuild,
+	"build-dependencies":   DependencyBuild,
+	"optional":             DependencyOptional,
+	"optionaldependencies": DependencyOptional,
+	"peer":                 DependencyPeer,
+	"peerdependencies":     DependencyPeer,
+}
+
+// NormalizeDependencyKind maps the dependency kind or scope reported by a
+// registry, such as Cargo's "normal" and "dev" or Maven's "compile", to
+// one of the Dependency kind constants. Unknown kinds are returned
+// lowercased.
+func NormalizeDependencyKind(kind string) string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if k, ok := dependencyKinds[kind]; ok {
+		return k
+	}
+	return kind
+}
+
+// dependencyKind returns the normalized kind of dep, treating dependencies
+// flagged optional as DependencyOptional.
+func dependencyKind(dep packages.Dependency) string {
+	kind := NormalizeDependencyKind(deref(dep.Kind))
+	if kind == DependencyRuntime && dep.Optional != nil && *dep.Optional {
+		return DependencyOptional
+	}
+	return kind
+}
+
+// FilterDependenciesByKind returns the dependencies of v whose normalized
+// kind is one of kinds.
+func FilterDependenciesByKind(v *packages.VersionWithDependencies, kinds ...string) []packages.Dependency {
+	var deps []packages.Dependency
+	for _, dep := range v.Dependencies {
+		if slices.Contains(kinds, dependencyKind(dep)) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// RuntimeDependencies returns the dependencies of v needed wherever it is
+// used, leaving out development, test and build dependencies, for SBOMs
+// and vulnerability reports of what actually ships. Dependencies of
+// unknown kinds are kept.
+func RuntimeDependencies(v *packages.VersionWithDependencies) []packages.Dependency {
+	var deps []packages.Dependency
+	for _, dep := range v.Dependencies {
+		if !isDevelopmentKind(dependencyKind(dep)) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+func isDevelopmentKind(kind string) bool {
+	return kind == DependencyDevelopment || kind == DependencyTest || kind == DependencyBuild
+}
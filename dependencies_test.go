@@ -0,0 +1,65 @@
+package ecosystems
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestNormalizeDependencyKind(t *testing.T) {
+	tests := []struct {
+		kind string
+		want string
+	}{
+		{"", DependencyRuntime},
+		{"runtime", DependencyRuntime},
+		{"normal", DependencyRuntime},
+		{"compile", DependencyRuntime},
+		{"Development", DependencyDevelopment},
+		{"dev", DependencyDevelopment},
+		{"devDependencies", DependencyDevelopment},
+		{"test", DependencyTest},
+		{"build", DependencyBuild},
+		{"optionalDependencies", DependencyOptional},
+		{"peerDependencies", DependencyPeer},
+		{"Provided", "provided"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeDependencyKind(tt.kind); got != tt.want {
+			t.Errorf("NormalizeDependencyKind(%q) = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestDependencyFilters(t *testing.T) {
+	kind := func(s string) *string { return &s }
+	optional := true
+	v := &packages.VersionWithDependencies{Dependencies: []packages.Dependency{
+		{PackageName: "runtime", Kind: kind("runtime")},
+		{PackageName: "dev", Kind: kind("development")},
+		{PackageName: "test", Kind: kind("test")},
+		{PackageName: "optional", Kind: kind("runtime"), Optional: &optional},
+		{PackageName: "peer", Kind: kind("peerDependencies")},
+		{PackageName: "provided", Kind: kind("provided")},
+		{PackageName: "unset"},
+	}}
+	names := func(deps []packages.Dependency) []string {
+		var out []string
+		for _, d := range deps {
+			out = append(out, d.PackageName)
+		}
+		return out
+	}
+
+	if got, want := names(RuntimeDependencies(v)), []string{"runtime", "optional", "peer", "provided", "unset"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RuntimeDependencies() = %v, want %v", got, want)
+	}
+	if got, want := names(FilterDependenciesByKind(v, DependencyDevelopment, DependencyTest)), []string{"dev", "test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterDependenciesByKind(development, test) = %v, want %v", got, want)
+	}
+	if got, want := names(FilterDependenciesByKind(v, DependencyOptional)), []string{"optional"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterDependenciesByKind(optional) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// GetDependentRepositories iterates over the repositories whose manifests
+// depend on a package, fetching pages as the loop advances. Popular
+// packages have millions of dependents, so pages are fetched by keyset
+// pagination on repository id, which stays fast however deep the iteration
+// goes; stop early by breaking out of the loop. Rate limited pages are
+// retried like any API request. An unknown registry or package yields
+// nothing.
+func (c *Client) GetDependentRepositories(ctx context.Context, registry, name string, opts ...CallOption) iter.Seq2[repos.Repository, error] {
+	return func(yield func(repos.Repository, error) bool) {
+		reg, err := c.GetRegistry(ctx, registry, opts...)
+		if err != nil {
+			yield(repos.Repository{}, err)
+			return
+		}
+		if reg == nil {
+			return
+		}
+
+		call := newCallConfig(opts)
+		ctx := withOperation(call.context(ctx), "GetDependentRepositories", registry)
+		perPage := c.perPage(call, DefaultPageSize)
+		afterID := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(repos.Repository{}, err)
+				return
+			}
+			page, err := c.dependentRepositoriesPage(ctx, call, reg.Ecosystem, name, perPage, afterID)
+			if err != nil {
+				yield(repos.Repository{}, err)
+				return
+			}
+			if page == nil {
+				return
+			}
+			for _, repo := range page.Items {
+				if !yield(repo, nil) {
+					return
+				}
+			}
+			if page.NextPage == 0 || len(page.Items) == 0 {
+				return
+			}
+			last := page.Items[len(page.Items)-1].Id
+			if last == nil || *last <= afterID {
+				yield(repos.Repository{}, fmt.Errorf("list dependent repositories: page without increasing ids"))
+				return
+			}
+			afterID = *last
+		}
+	}
+}
+
+// dependentRepositoriesPage fetches the dependents of a package with ids
+// greater than afterID. The page's NextPage is non-zero if more follow.
+func (c *Client) dependentRepositoriesPage(ctx context.Context, call *callConfig, ecosystem, name string, perPage, afterID int) (*Page[repos.Repository], error) {
+	resp, err := c.reposAPI().UsagePackageDependentRepositoriesWithResponse(ctx, ecosystem, name, &repos.UsagePackageDependentRepositoriesParams{
+		PerPage: &perPage,
+		AfterId: &afterID,
+	}, call.reposEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("list dependent repositories: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("list dependent repositories", resp.HTTPResponse, resp.Body)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+
+	return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{PerPage: perPage}), nil
+}
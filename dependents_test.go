@@ -0,0 +1,50 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+func TestGetDependentRepositories(t *testing.T) {
+	client, srv := newTestClient(t)
+	for _, id := range []int{5, 1, 4, 2, 3} {
+		name := "example/dependent-" + string(rune('0'+id))
+		srv.AddDependentRepository("npm", "@scope/pkg", repos.Repository{Id: &id, FullName: &name})
+	}
+	ctx := context.Background()
+
+	var ids []int
+	for repo, err := range client.GetDependentRepositories(ctx, "npmjs.org", "@scope/pkg", CallPageSize(2)) {
+		if err != nil {
+			t.Fatalf("GetDependentRepositories() error = %v", err)
+		}
+		ids = append(ids, *repo.Id)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("GetDependentRepositories() ids = %v, want %v", ids, want)
+	}
+	if got := countRequests(srv.Requests(), "/dependent_repositories"); got != 3 {
+		t.Errorf("dependent_repositories requests = %d, want 3", got)
+	}
+
+	var count int
+	for range client.GetDependentRepositories(ctx, "npmjs.org", "@scope/pkg", CallPageSize(2)) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("GetDependentRepositories() yielded %d after break, want 1", count)
+	}
+
+	for _, tt := range []struct{ registry, name string }{
+		{"npmjs.org", "no-dependents"},
+		{"nowhere.example", "@scope/pkg"},
+	} {
+		for repo, err := range client.GetDependentRepositories(ctx, tt.registry, tt.name) {
+			t.Errorf("GetDependentRepositories(%s, %s) yielded %v, %v, want nothing", tt.registry, tt.name, repo, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package dumps reads the bulk data exports ecosyste.ms publishes, so
+// large-scale analyses can skip the API. Records decode into the same
+// packages and repos types the client returns.
+//
+// Dumps may be JSON lines, one object per line, or CSV with a header row
+// naming the API's JSON fields. Gzip-compressed input is detected and
+// decompressed automatically.
+package dumps
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// Format is the encoding of a dump file.
+type Format int
+
+const (
+	// JSONLines holds one JSON object per line.
+	JSONLines Format = iota
+	// CSV holds a header row of JSON field names, then one record per row.
+	// Array and object fields are JSON encoded within their cell.
+	CSV
+)
+
+// FormatFromName guesses a dump's format from its file name, such as
+// "packages.csv.gz". Names that do not end in .csv are read as JSON lines.
+func FormatFromName(name string) Format {
+	if path.Ext(strings.TrimSuffix(name, ".gz")) == ".csv" {
+		return CSV
+	}
+	return JSONLines
+}
+
+// Packages reads package records from a dump.
+func Packages(r io.Reader, format Format) iter.Seq2[packages.Package, error] {
+	return Read[packages.Package](r, format)
+}
+
+// Repositories reads repository records from a dump.
+func Repositories(r io.Reader, format Format) iter.Seq2[repos.Repository, error] {
+	return Read[repos.Repository](r, format)
+}
+
+// Read decodes each record of a dump into T, a struct with JSON field tags.
+// Iteration stops after the first error, which names the failing record.
+func Read[T any](r io.Reader, format Format) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		r, err := decompress(r)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if format == CSV {
+			readCSV(r, yield)
+		} else {
+			readJSONLines(r, yield)
+		}
+	}
+}
+
+// decompress returns r, or a gzip reader over it if it starts with the
+// gzip magic bytes.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading dump: %w", err)
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading dump: %w", err)
+		}
+		return zr, nil
+	}
+	return br, nil
+}
+
+func readJSONLines[T any](r io.Reader, yield func(T, error) bool) {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var record T
+		if err := dec.Decode(&record); err != nil {
+			if !errors.Is(err, io.EOF) {
+				yield(record, fmt.Errorf("record %d: %w", n, err))
+			}
+			return
+		}
+		if !yield(record, nil) {
+			return
+		}
+	}
+}
+
+func readCSV[T any](r io.Reader, yield func(T, error) bool) {
+	var zero T
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return
+	}
+	if err != nil {
+		yield(zero, fmt.Errorf("reading CSV header: %w", err))
+		return
+	}
+	header = slices.Clone(header)
+	fields := jsonFields(reflect.TypeFor[T]())
+	columns := make([][]int, len(header))
+	for i, name := range header {
+		columns[i] = fields[strings.TrimSpace(name)]
+	}
+
+	for n := 1; ; n++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		var record T
+		if err != nil {
+			yield(record, fmt.Errorf("record %d: %w", n, err))
+			return
+		}
+		v := reflect.ValueOf(&record).Elem()
+		for i, cell := range row {
+			if i >= len(columns) || columns[i] == nil || cell == "" {
+				continue
+			}
+			if err := setField(v.FieldByIndex(columns[i]), cell); err != nil {
+				yield(record, fmt.Errorf("record %d, column %s: %w", n, header[i], err))
+				return
+			}
+		}
+		if !yield(record, nil) {
+			return
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's fields to their indexes.
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name != "" && name != "-" && f.IsExported() {
+			fields[name] = f.Index
+		}
+	}
+	return fields
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// setField decodes a CSV cell into a struct field. Strings are taken as
+// is; other values, including arrays and objects, are decoded as JSON.
+func setField(field reflect.Value, cell string) error {
+	target := reflect.New(field.Type())
+	elem := field.Type()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	data := []byte(cell)
+	if elem.Kind() == reflect.String || elem == timeType {
+		quoted, err := json.Marshal(cell)
+		if err != nil {
+			return err
+		}
+		data = quoted
+	}
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return err
+	}
+	field.Set(target.Elem())
+	return nil
+}
@@ -0,0 +1,122 @@
+package dumps
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPackagesJSONLines(t *testing.T) {
+	input := `{"name": "rails", "ecosystem": "rubygems", "downloads": 500, "keywords_array": ["web"]}
+{"name": "lodash", "ecosystem": "npm", "latest_release_number": "4.17.21"}
+`
+	var names []string
+	for pkg, err := range Packages(strings.NewReader(input), JSONLines) {
+		if err != nil {
+			t.Fatalf("Packages() error = %v", err)
+		}
+		names = append(names, pkg.Name)
+	}
+	if strings.Join(names, ",") != "rails,lodash" {
+		t.Errorf("Packages() names = %v, want [rails lodash]", names)
+	}
+}
+
+func TestPackagesJSONLinesError(t *testing.T) {
+	input := "{\"name\": \"rails\"}\n{not json}\n{\"name\": \"lodash\"}\n"
+	var count int
+	var lastErr error
+	for _, err := range Packages(strings.NewReader(input), JSONLines) {
+		count++
+		lastErr = err
+	}
+	if count != 2 || lastErr == nil || !strings.Contains(lastErr.Error(), "record 2") {
+		t.Errorf("Packages() yielded %d records, last error %v, want error for record 2", count, lastErr)
+	}
+}
+
+func TestPackagesCSV(t *testing.T) {
+	input := "name,ecosystem,downloads,latest_release_number,keywords_array,latest_release_published_at,critical,unknown\n" +
+		"rails,rubygems,500,7.1.3,\"[\"\"web\"\",\"\"mvc\"\"]\",2024-01-16T22:00:00Z,true,x\n" +
+		"lodash,npm,,,,,,\n"
+
+	var got []string
+	for pkg, err := range Packages(strings.NewReader(input), CSV) {
+		if err != nil {
+			t.Fatalf("Packages() error = %v", err)
+		}
+		got = append(got, pkg.Name)
+		if pkg.Name != "rails" {
+			if pkg.LatestReleaseNumber != nil {
+				t.Errorf("lodash LatestReleaseNumber = %q, want nil", *pkg.LatestReleaseNumber)
+			}
+			continue
+		}
+		if pkg.Ecosystem != "rubygems" || pkg.Downloads != 500 || !pkg.Critical {
+			t.Errorf("rails = %+v", pkg)
+		}
+		if pkg.LatestReleaseNumber == nil || *pkg.LatestReleaseNumber != "7.1.3" {
+			t.Errorf("rails LatestReleaseNumber = %v, want 7.1.3", pkg.LatestReleaseNumber)
+		}
+		if len(pkg.KeywordsArray) != 2 || pkg.KeywordsArray[1] != "mvc" {
+			t.Errorf("rails KeywordsArray = %v, want [web mvc]", pkg.KeywordsArray)
+		}
+		want := time.Date(2024, 1, 16, 22, 0, 0, 0, time.UTC)
+		if pkg.LatestReleasePublishedAt == nil || !pkg.LatestReleasePublishedAt.Equal(want) {
+			t.Errorf("rails LatestReleasePublishedAt = %v, want %v", pkg.LatestReleasePublishedAt, want)
+		}
+	}
+	if strings.Join(got, ",") != "rails,lodash" {
+		t.Errorf("Packages() names = %v, want [rails lodash]", got)
+	}
+}
+
+func TestPackagesCSVError(t *testing.T) {
+	input := "name,downloads\nrails,many\n"
+	for _, err := range Packages(strings.NewReader(input), CSV) {
+		if err == nil || !strings.Contains(err.Error(), "column downloads") {
+			t.Errorf("Packages() error = %v, want error for column downloads", err)
+		}
+	}
+}
+
+func TestRepositoriesGzip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"full_name": "rails/rails", "stargazers_count": 55000, "archived": false}` + "\n"))
+	zw.Close()
+
+	var count int
+	for repo, err := range Repositories(&buf, JSONLines) {
+		if err != nil {
+			t.Fatalf("Repositories() error = %v", err)
+		}
+		count++
+		if repo.FullName == nil || *repo.FullName != "rails/rails" || repo.StargazersCount == nil || *repo.StargazersCount != 55000 {
+			t.Errorf("Repositories() = %+v", repo)
+		}
+	}
+	if count != 1 {
+		t.Errorf("Repositories() yielded %d records, want 1", count)
+	}
+}
+
+func TestFormatFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want Format
+	}{
+		{"packages.csv", CSV},
+		{"dumps/packages.csv.gz", CSV},
+		{"packages.ndjson.gz", JSONLines},
+		{"repositories.jsonl", JSONLines},
+	}
+
+	for _, tt := range tests {
+		if got := FormatFromName(tt.name); got != tt.want {
+			t.Errorf("FormatFromName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package ecosystemstest
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+//go:embed testdata/default.json
+var defaultFixtures []byte
+
+// Fixtures is the JSON fixture format used to seed a Server.
+type Fixtures struct {
+	Registries   []packages.Registry `json:"registries"`
+	Packages     []FixturePackage    `json:"packages"`
+	Repositories []repos.Repository  `json:"repositories"`
+	Owners       []FixtureOwner      `json:"owners"`
+}
+
+// FixturePackage is a package fixture together with its versions.
+// The package's registry.name field selects the registry it is served from.
+type FixturePackage struct {
+	packages.PackageWithRegistry
+	Versions []packages.VersionWithDependencies `json:"versions"`
+}
+
+// FixtureOwner is a repository owner fixture. The host field selects the
+// host it is served from.
+type FixtureOwner struct {
+	repos.Owner
+	Host string `json:"host"`
+}
+
+// Seed adds all registries, packages, versions and repositories in f.
+func (s *Server) Seed(f Fixtures) {
+	for _, r := range f.Registries {
+		s.AddRegistry(r)
+	}
+	for _, p := range f.Packages {
+		s.AddPackage(p.Registry.Name, p.PackageWithRegistry)
+		for _, v := range p.Versions {
+			s.AddVersion(p.Registry.Name, p.Name, v)
+		}
+	}
+	for _, r := range f.Repositories {
+		s.AddRepository(r)
+	}
+	for _, o := range f.Owners {
+		s.AddOwner(o.Host, o.Owner)
+	}
+}
+
+// LoadFixtures seeds the server from fixture JSON read from r.
+func (s *Server) LoadFixtures(r io.Reader) error {
+	var f Fixtures
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("decoding fixtures: %w", err)
+	}
+	s.Seed(f)
+	return nil
+}
+
+// LoadFixturesFile seeds the server from a fixture JSON file.
+func (s *Server) LoadFixturesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.LoadFixtures(f)
+}
+
+// LoadDefaultFixtures seeds the server with a small built-in data set
+// covering rubygems.org, npmjs.org and a few GitHub repositories and owners.
+func (s *Server) LoadDefaultFixtures() error {
+	return s.LoadFixtures(bytes.NewReader(defaultFixtures))
+}
@@ -0,0 +1,721 @@
+// Package ecosystemstest provides an in-process fake of the ecosyste.ms
+// packages and repos APIs, so code using the client can be tested without
+// network access.
+//
+// The fake implements the subset of endpoints wrapped by the ecosystems
+// client and serves data seeded from Go values or fixture JSON:
+//
+//	srv := ecosystemstest.NewServer()
+//	defer srv.Close()
+//	if err := srv.LoadDefaultFixtures(); err != nil {
+//		t.Fatal(err)
+//	}
+//	client, err := ecosystems.NewClient("test/1.0",
+//		ecosystems.WithPackagesServer(srv.PackagesURL()),
+//		ecosystems.WithReposServer(srv.ReposURL()),
+//	)
+package ecosystemstest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+const (
+	packagesPrefix = "/packages/api/v1"
+	reposPrefix    = "/repos/api/v1"
+
+	defaultPerPage = 30
+)
+
+// Server is a fake ecosyste.ms API server backed by httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	registries   []packages.Registry
+	packages     map[string]map[string]*packages.PackageWithRegistry
+	versions     map[string]map[string][]packages.VersionWithDependencies
+	repositories map[string]*repos.Repository
+	owners       map[string]map[string]*repos.Owner
+	dependents   map[string][]repos.Repository
+	requests     []string
+}
+
+// NewServer starts a fake server with no data. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		packages:     make(map[string]map[string]*packages.PackageWithRegistry),
+		versions:     make(map[string]map[string][]packages.VersionWithDependencies),
+		repositories: make(map[string]*repos.Repository),
+		owners:       make(map[string]map[string]*repos.Owner),
+		dependents:   make(map[string][]repos.Repository),
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// PackagesURL returns the base URL to pass to ecosystems.WithPackagesServer.
+func (s *Server) PackagesURL() string {
+	return s.URL + packagesPrefix
+}
+
+// ReposURL returns the base URL to pass to ecosystems.WithReposServer.
+func (s *Server) ReposURL() string {
+	return s.URL + reposPrefix
+}
+
+// Requests returns the method and path of every request received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// AddRegistry adds a registry to the registries listing.
+func (s *Server) AddRegistry(r packages.Registry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.registries = append(s.registries, r)
+}
+
+// AddPackage adds a package to the given registry.
+func (s *Server) AddPackage(registry string, pkg packages.PackageWithRegistry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pkg.Registry.Name == "" {
+		pkg.Registry.Name = registry
+	}
+	if s.packages[registry] == nil {
+		s.packages[registry] = make(map[string]*packages.PackageWithRegistry)
+	}
+	s.packages[registry][pkg.Name] = &pkg
+}
+
+// AddVersion adds a version to a package in the given registry.
+func (s *Server) AddVersion(registry, name string, v packages.VersionWithDependencies) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions[registry] == nil {
+		s.versions[registry] = make(map[string][]packages.VersionWithDependencies)
+	}
+	s.versions[registry][name] = append(s.versions[registry][name], v)
+}
+
+// AddRepository adds a repository, found by lookups of its HTML or repository URL.
+func (s *Server) AddRepository(repo repos.Repository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range []*string{repo.HtmlUrl, repo.RepositoryUrl} {
+		if u != nil && *u != "" {
+			r := repo
+			s.repositories[*u] = &r
+		}
+	}
+}
+
+// AddOwner adds a repository owner to the given host, such as "GitHub".
+func (s *Server) AddOwner(host string, owner repos.Owner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if owner.Login == nil {
+		return
+	}
+	if s.owners[host] == nil {
+		s.owners[host] = make(map[string]*repos.Owner)
+	}
+	s.owners[host][*owner.Login] = &owner
+}
+
+// AddDependentRepository records that repo depends on the named package of
+// an ecosystem, such as "npm".
+func (s *Server) AddDependentRepository(ecosystem, name string, repo repos.Repository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := ecosystem + "/" + name
+	s.dependents[key] = append(s.dependents[key], repo)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+packagesPrefix+"/registries", s.handleRegistries)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}", s.handleRegistry)
+	mux.HandleFunc("GET "+packagesPrefix+"/critical", s.handleCritical)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages", s.handleRegistryPackages)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/package_names", s.handlePackageNames)
+	mux.HandleFunc("GET "+packagesPrefix+"/keywords", s.handleKeywords)
+	mux.HandleFunc("GET "+packagesPrefix+"/keywords/{keyword}", s.handleKeyword)
+	mux.HandleFunc("GET "+packagesPrefix+"/packages/lookup", s.handleLookup)
+	mux.HandleFunc("POST "+packagesPrefix+"/packages/bulk_lookup", s.handleBulkLookup)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages/{name}", s.handlePackage)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages/{name}/ping", s.handlePackagePing)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages/{name}/versions", s.handleVersions)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages/{name}/version_numbers", s.handleVersionNumbers)
+	mux.HandleFunc("GET "+packagesPrefix+"/registries/{registry}/packages/{name}/versions/{version}", s.handleVersion)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts", s.handleHosts)
+	mux.HandleFunc("GET "+reposPrefix+"/repositories/lookup", s.handleRepositoryLookup)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts/{host}/repositories", s.handleHostRepositories)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts/{host}/repositories/{name}", s.handleHostRepository)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts/{host}/repositories/{name}/ping", s.handleRepositoryPing)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts/{host}/owners/{login}", s.handleOwner)
+	mux.HandleFunc("GET "+reposPrefix+"/hosts/{host}/owners/{login}/repositories", s.handleOwnerRepositories)
+	mux.HandleFunc("GET "+reposPrefix+"/usage/{ecosystem}/{package}/dependent_repositories", s.handleDependentRepositories)
+	mux.HandleFunc("GET "+reposPrefix+"/topics", s.handleTopics)
+	mux.HandleFunc("GET "+reposPrefix+"/topics/{topic}", s.handleTopic)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleRegistries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registries := s.registries
+	if ecosystem := r.URL.Query().Get("ecosystem"); ecosystem != "" {
+		registries = nil
+		for _, reg := range s.registries {
+			if strings.EqualFold(reg.Ecosystem, ecosystem) {
+				registries = append(registries, reg)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, registries))
+}
+
+func (s *Server) handleRegistry(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, reg := range s.registries {
+		if reg.Name == r.PathValue("registry") {
+			writeJSON(w, http.StatusOK, reg)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+}
+
+func (s *Server) handleBulkLookup(w http.ResponseWriter, r *http.Request) {
+	reqBody := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid gzip body"})
+			return
+		}
+		reqBody = zr
+	}
+	var body packages.BulkLookupPackagesJSONBody
+	if err := json.NewDecoder(reqBody).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+	if body.Purls == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "purls is required"})
+		return
+	}
+	if len(*body.Purls) > 100 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "maximum 100 purls"})
+		return
+	}
+
+	wanted := make(map[string]bool, len(*body.Purls))
+	for _, p := range *body.Purls {
+		wanted[p] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := []packages.PackageWithRegistry{}
+	for _, byName := range s.packages {
+		for _, pkg := range byName {
+			if wanted[pkg.Purl] {
+				results = append(results, *pkg)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// handleLookup finds packages across registries by repository URL, purl,
+// or name optionally limited to an ecosystem.
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := []packages.PackageWithRegistry{}
+	for _, byName := range s.packages {
+		for _, pkg := range byName {
+			switch {
+			case q.Has("repository_url") && (pkg.RepositoryUrl == nil || *pkg.RepositoryUrl != q.Get("repository_url")):
+			case q.Has("purl") && pkg.Purl != q.Get("purl"):
+			case q.Has("name") && pkg.Name != q.Get("name"):
+			case q.Has("ecosystem") && pkg.Registry.Ecosystem != q.Get("ecosystem"):
+			default:
+				results = append(results, *pkg)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleCritical(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registry := r.URL.Query().Get("registry")
+	var critical []*packages.PackageWithRegistry
+	for name, pkgs := range s.packages {
+		if registry != "" && name != registry {
+			continue
+		}
+		for _, pkg := range pkgs {
+			if pkg.Critical {
+				critical = append(critical, pkg)
+			}
+		}
+	}
+	sortPackages(critical, r)
+	writeJSON(w, http.StatusOK, paginate(w, r, critical))
+}
+
+func (s *Server) handleRegistryPackages(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var updatedAfter time.Time
+	if v := r.URL.Query().Get("updated_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid updated_after"})
+			return
+		}
+		updatedAfter = t
+	}
+	var pkgs []*packages.PackageWithRegistry
+	for _, pkg := range s.packages[r.PathValue("registry")] {
+		if updatedAfter.IsZero() || pkg.UpdatedAt.After(updatedAfter) {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sortPackages(pkgs, r)
+	writeJSON(w, http.StatusOK, paginate(w, r, pkgs))
+}
+
+func (s *Server) handlePackageNames(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := []string{}
+	for name := range s.packages[r.PathValue("registry")] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writeJSON(w, http.StatusOK, paginate(w, r, names))
+}
+
+func (s *Server) handleKeywords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int)
+	for _, pkgs := range s.packages {
+		for _, pkg := range pkgs {
+			for _, k := range pkg.KeywordsArray {
+				counts[k]++
+			}
+		}
+	}
+	keywords := make([]packages.Keyword, 0, len(counts))
+	for name, n := range counts {
+		keywords = append(keywords, packages.Keyword{Name: name, PackagesCount: n})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].PackagesCount != keywords[j].PackagesCount {
+			return keywords[i].PackagesCount > keywords[j].PackagesCount
+		}
+		return keywords[i].Name < keywords[j].Name
+	})
+	writeJSON(w, http.StatusOK, paginate(w, r, keywords))
+}
+
+func (s *Server) handleKeyword(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keyword := r.PathValue("keyword")
+	var tagged []*packages.PackageWithRegistry
+	for _, pkgs := range s.packages {
+		for _, pkg := range pkgs {
+			if slices.Contains(pkg.KeywordsArray, keyword) {
+				tagged = append(tagged, pkg)
+			}
+		}
+	}
+	if len(tagged) == 0 {
+		notFound(w)
+		return
+	}
+	sortPackages(tagged, r)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":             keyword,
+		"packages_count":   len(tagged),
+		"packages":         paginate(w, r, tagged),
+		"related_keywords": []packages.Keyword{},
+	})
+}
+
+func (s *Server) handlePackage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pkg, ok := s.packages[r.PathValue("registry")][r.PathValue("name")]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, pkg)
+}
+
+// handlePackagePing accepts a sync request for a known package.
+func (s *Server) handlePackagePing(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.packages[r.PathValue("registry")][r.PathValue("name")]; !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "pong"})
+}
+
+func (s *Server) handleVersions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registry, name := r.PathValue("registry"), r.PathValue("name")
+	if _, ok := s.packages[registry][name]; !ok {
+		notFound(w)
+		return
+	}
+	versions := s.versions[registry][name]
+	if versions == nil {
+		versions = []packages.VersionWithDependencies{}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, versions))
+}
+
+func (s *Server) handleVersionNumbers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registry, name := r.PathValue("registry"), r.PathValue("name")
+	if _, ok := s.packages[registry][name]; !ok {
+		notFound(w)
+		return
+	}
+	numbers := []string{}
+	for _, v := range s.versions[registry][name] {
+		numbers = append(numbers, v.Number)
+	}
+	writeJSON(w, http.StatusOK, numbers)
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.versions[r.PathValue("registry")][r.PathValue("name")] {
+		if v.Number == r.PathValue("version") {
+			writeJSON(w, http.StatusOK, v)
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (s *Server) handleRepositoryLookup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo, ok := s.repositories[r.URL.Query().Get("url")]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, repo)
+}
+
+// handleHostRepository serves a repository by host and full name. As on
+// the real API, slashes in the full name must be escaped as %2F.
+func (s *Server) handleHostRepositories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	host := r.PathValue("host")
+	hosted := []repos.Repository{}
+	for _, repo := range s.uniqueRepositories() {
+		if repo.Host != nil && repo.Host.Name != nil && *repo.Host.Name == host {
+			hosted = append(hosted, *repo)
+		}
+	}
+	if len(hosted) == 0 {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, hosted))
+}
+
+func (s *Server) handleHostRepository(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.PathValue("host") + "/" + r.PathValue("name")
+	for _, repo := range s.uniqueRepositories() {
+		if repositoryKey(repo) == key {
+			writeJSON(w, http.StatusOK, repo)
+			return
+		}
+	}
+	notFound(w)
+}
+
+// handleDependentRepositories lists the dependents of a package in id
+// order, supporting keyset pagination with after_id.
+func (s *Server) handleDependentRepositories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dependents, ok := s.dependents[r.PathValue("ecosystem")+"/"+r.PathValue("package")]
+	if !ok {
+		notFound(w)
+		return
+	}
+	id := func(repo repos.Repository) int {
+		if repo.Id == nil {
+			return 0
+		}
+		return *repo.Id
+	}
+	sorted := slices.SortedFunc(slices.Values(dependents), func(a, b repos.Repository) int {
+		return id(a) - id(b)
+	})
+	if after, err := strconv.Atoi(r.URL.Query().Get("after_id")); err == nil {
+		sorted = slices.DeleteFunc(sorted, func(repo repos.Repository) bool { return id(repo) <= after })
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, sorted))
+}
+
+// handleRepositoryPing accepts a sync request for a known repository.
+func (s *Server) handleRepositoryPing(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.PathValue("host") + "/" + r.PathValue("name")
+	for _, repo := range s.uniqueRepositories() {
+		if repositoryKey(repo) == key {
+			writeJSON(w, http.StatusOK, map[string]string{"message": "pong"})
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (s *Server) handleOwner(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owner, ok := s.owners[r.PathValue("host")][r.PathValue("login")]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, owner)
+}
+
+func (s *Server) handleOwnerRepositories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	host, login := r.PathValue("host"), r.PathValue("login")
+	if _, ok := s.owners[host][login]; !ok {
+		notFound(w)
+		return
+	}
+	owned := []repos.Repository{}
+	for _, repo := range s.uniqueRepositories() {
+		if repo.Owner != nil && *repo.Owner == login && repo.Host != nil && repo.Host.Name != nil && *repo.Host.Name == host {
+			owned = append(owned, *repo)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(w, r, owned))
+}
+
+func (s *Server) handleHosts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]bool)
+	hosts := []repos.Host{}
+	for _, repo := range s.uniqueRepositories() {
+		if repo.Host == nil || repo.Host.Name == nil || seen[*repo.Host.Name] {
+			continue
+		}
+		seen[*repo.Host.Name] = true
+		hosts = append(hosts, *repo.Host)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return *hosts[i].Name < *hosts[j].Name })
+	writeJSON(w, http.StatusOK, paginate(w, r, hosts))
+}
+
+func (s *Server) handleTopics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int)
+	for _, repo := range s.uniqueRepositories() {
+		if repo.Topics != nil {
+			for _, t := range *repo.Topics {
+				counts[t]++
+			}
+		}
+	}
+	topics := make([]repos.Topic, 0, len(counts))
+	for name, n := range counts {
+		topics = append(topics, repos.Topic{Name: &name, RepositoriesCount: &n})
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if *topics[i].RepositoriesCount != *topics[j].RepositoriesCount {
+			return *topics[i].RepositoriesCount > *topics[j].RepositoriesCount
+		}
+		return *topics[i].Name < *topics[j].Name
+	})
+	writeJSON(w, http.StatusOK, paginate(w, r, topics))
+}
+
+func (s *Server) handleTopic(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	topic := r.PathValue("topic")
+	tagged := []repos.Repository{}
+	for _, repo := range s.uniqueRepositories() {
+		if repo.Topics != nil && slices.Contains(*repo.Topics, topic) {
+			tagged = append(tagged, *repo)
+		}
+	}
+	if len(tagged) == 0 {
+		notFound(w)
+		return
+	}
+	page := paginate(w, r, tagged)
+	count := len(tagged)
+	writeJSON(w, http.StatusOK, repos.TopicWithRepositories{
+		Name:              &topic,
+		Repositories:      &page,
+		RepositoriesCount: &count,
+	})
+}
+
+// uniqueRepositories returns each repository once, ordered by full name.
+// The caller must hold s.mu.
+func (s *Server) uniqueRepositories() []*repos.Repository {
+	seen := make(map[string]bool)
+	var all []*repos.Repository
+	for _, repo := range s.repositories {
+		key := repositoryKey(repo)
+		if !seen[key] {
+			seen[key] = true
+			all = append(all, repo)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return repositoryKey(all[i]) < repositoryKey(all[j])
+	})
+	return all
+}
+
+func repositoryKey(repo *repos.Repository) string {
+	var host, name string
+	if repo.Host != nil && repo.Host.Name != nil {
+		host = *repo.Host.Name
+	}
+	if repo.FullName != nil {
+		name = *repo.FullName
+	}
+	return host + "/" + name
+}
+
+// sortPackages orders pkgs by the sort and order query parameters, or by
+// name when no sort is given.
+func sortPackages(pkgs []*packages.PackageWithRegistry, r *http.Request) {
+	field := r.URL.Query().Get("sort")
+	desc := field != "" && r.URL.Query().Get("order") != "asc"
+	key := func(p *packages.PackageWithRegistry) int64 {
+		switch field {
+		case "downloads":
+			return int64(p.Downloads)
+		case "dependent_packages_count":
+			return int64(p.DependentPackagesCount)
+		case "dependent_repos_count":
+			return int64(p.DependentReposCount)
+		case "updated_at":
+			return p.UpdatedAt.UnixNano()
+		}
+		return 0
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		ki, kj := key(pkgs[i]), key(pkgs[j])
+		if ki == kj {
+			return pkgs[i].Name < pkgs[j].Name
+		}
+		if desc {
+			return ki > kj
+		}
+		return ki < kj
+	})
+}
+
+// paginate applies the page and per_page query parameters to items and
+// sets the pagination headers the real API sends: Current-Page,
+// Page-Items, Total-Pages, Total-Count and a Link to the next page.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+
+	totalPages := (len(items) + perPage - 1) / perPage
+	h := w.Header()
+	h.Set("Current-Page", strconv.Itoa(page))
+	h.Set("Page-Items", strconv.Itoa(perPage))
+	h.Set("Total-Pages", strconv.Itoa(totalPages))
+	h.Set("Total-Count", strconv.Itoa(len(items)))
+	if page < totalPages {
+		next := *r.URL
+		q := next.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = q.Encode()
+		h.Set("Link", `<`+next.String()+`>; rel="next"`)
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+}
@@ -0,0 +1,51 @@
+package ecosystemstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestDefaultFixtures(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+
+	resp, err := http.Get(srv.PackagesURL() + "/registries/rubygems.org/packages/rails")
+	if err != nil {
+		t.Fatalf("GET package error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var pkg packages.Package
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		t.Fatalf("decode error = %v", err)
+	}
+	if pkg.Name != "rails" {
+		t.Errorf("Name = %q, want %q", pkg.Name, "rails")
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.PackagesURL() + "/registries/rubygems.org/packages/missing")
+	if err != nil {
+		t.Fatalf("GET package error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	if got := srv.Requests(); len(got) != 1 || got[0] != "GET /packages/api/v1/registries/rubygems.org/packages/missing" {
+		t.Errorf("Requests() = %v", got)
+	}
+}
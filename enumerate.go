@@ -0,0 +1,87 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// GetRegistryPackageNames iterates over the name of every package in a
+// registry, fetching pages as the loop advances. Large registries such as
+// npmjs.org hold millions of names, so stop early by breaking out of the
+// loop. A failed request ends the iteration with its error.
+func (c *Client) GetRegistryPackageNames(ctx context.Context, registry string, opts ...CallOption) iter.Seq2[string, error] {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetRegistryPackageNames", registry)
+	perPage := c.perPage(call, DefaultPageSize)
+
+	return paginate(ctx, func(page int) (*Page[string], error) {
+		resp, err := c.packagesAPI().GetRegistryPackageNamesWithResponse(ctx, registry, &packages.GetRegistryPackageNamesParams{
+			Page:    &page,
+			PerPage: &perPage,
+		}, call.packagesEditors()...)
+		if err != nil {
+			return nil, fmt.Errorf("get package names: %w", err)
+		}
+
+		if resp.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, newAPIError("get package names", resp.HTTPResponse, resp.Body)
+		}
+
+		if resp.JSON200 == nil {
+			return nil, nil
+		}
+
+		return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{Page: page, PerPage: perPage}), nil
+	})
+}
+
+// GetRecentlyUpdatedPackages iterates over the packages in a registry that
+// ecosyste.ms updated after since, oldest update first. Passing the
+// UpdatedAt of the last package seen as since on the next run resumes an
+// incremental sync.
+func (c *Client) GetRecentlyUpdatedPackages(ctx context.Context, registry string, since time.Time, opts ...CallOption) iter.Seq2[packages.Package, error] {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetRecentlyUpdatedPackages", registry)
+	perPage := c.perPage(call, DefaultPageSize)
+	sort, order := "updated_at", "asc"
+	var updatedAfter *time.Time
+	if !since.IsZero() {
+		updatedAfter = &since
+	}
+
+	return paginate(ctx, func(page int) (*Page[packages.Package], error) {
+		resp, err := c.packagesAPI().GetRegistryPackagesWithResponse(ctx, registry, &packages.GetRegistryPackagesParams{
+			Page:         &page,
+			PerPage:      &perPage,
+			UpdatedAfter: updatedAfter,
+			Sort:         &sort,
+			Order:        &order,
+		}, call.packagesEditors()...)
+		if err != nil {
+			return nil, fmt.Errorf("get updated packages: %w", err)
+		}
+
+		if resp.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, newAPIError("get updated packages", resp.HTTPResponse, resp.Body)
+		}
+
+		if resp.JSON200 == nil {
+			return nil, nil
+		}
+
+		return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{Page: page, PerPage: perPage}), nil
+	})
+}
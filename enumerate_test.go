@@ -0,0 +1,70 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetRegistryPackageNames(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	var names []string
+	for name, err := range client.GetRegistryPackageNames(context.Background(), "npmjs.org", CallPageSize(1)) {
+		if err != nil {
+			t.Fatalf("GetRegistryPackageNames() error = %v", err)
+		}
+		names = append(names, name)
+	}
+	if len(names) != 2 || names[0] != "@babel/core" || names[1] != "lodash" {
+		t.Errorf("GetRegistryPackageNames() = %v, want [@babel/core lodash]", names)
+	}
+	// Two pages of one; the second has no next link.
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("made %d requests, want 2", got)
+	}
+}
+
+func TestGetRegistryPackageNamesBreak(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	for range client.GetRegistryPackageNames(context.Background(), "npmjs.org", CallPageSize(1)) {
+		break
+	}
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("made %d requests after break, want 1", got)
+	}
+}
+
+func TestGetRecentlyUpdatedPackages(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		since time.Time
+		want  []string
+	}{
+		{time.Time{}, []string{"lodash", "@babel/core"}},
+		{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), []string{"@babel/core"}},
+		{time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.since.Format(time.DateOnly), func(t *testing.T) {
+			var got []string
+			for pkg, err := range client.GetRecentlyUpdatedPackages(context.Background(), "npmjs.org", tt.since) {
+				if err != nil {
+					t.Fatalf("GetRecentlyUpdatedPackages() error = %v", err)
+				}
+				got = append(got, pkg.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetRecentlyUpdatedPackages() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetRecentlyUpdatedPackages()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
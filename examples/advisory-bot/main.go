@@ -0,0 +1,80 @@
+// Command advisory-bot reads PURLs from stdin, one per line, and prints the
+// security advisories ecosyste.ms knows about for each package, along with
+// the first patched version so a pinned version can be checked against it.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+)
+
+func main() {
+	pinned := make(map[string]string)
+	var order []string
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		purl, err := ecosystems.ParsePURL(line)
+		if err != nil {
+			log.Printf("skipping %s: %v", line, err)
+			continue
+		}
+		version := purl.Version
+		purl.Version = ""
+		key := purl.ToString()
+		if _, ok := pinned[key]; !ok {
+			order = append(order, key)
+		}
+		pinned[key] = version
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := ecosystems.NewClient("ecosystems-go-examples/advisory-bot")
+	if err != nil {
+		log.Fatal(err)
+	}
+	results, err := client.BulkLookup(context.Background(), order)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, key := range order {
+		pkg, ok := results[key]
+		if !ok || len(pkg.Advisories) == 0 {
+			continue
+		}
+		fmt.Printf("%s (pinned %s)\n", key, pinned[key])
+		for _, adv := range pkg.Advisories {
+			fmt.Printf("  [%s] %s\n", deref(adv.Severity), deref(adv.Title))
+			if len(adv.Identifiers) > 0 {
+				fmt.Printf("    ids: %s\n", strings.Join(adv.Identifiers, ", "))
+			}
+			for _, p := range adv.Packages {
+				versions, _ := p["versions"].([]interface{})
+				for _, v := range versions {
+					r, _ := v.(map[string]interface{})
+					fmt.Printf("    vulnerable: %v, patched: %v\n", r["vulnerable_version_range"], r["first_patched_version"])
+				}
+			}
+		}
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "unknown"
+	}
+	return *s
+}
@@ -0,0 +1,19 @@
+module github.com/ecosyste-ms/ecosystems-go/examples
+
+go 1.24.2
+
+require github.com/ecosyste-ms/ecosystems-go v0.0.0
+
+require (
+	github.com/andybalholm/brotli v1.2.5 // indirect
+	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/git-pkgs/packageurl-go v0.3.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/oapi-codegen/runtime v1.4.0 // indirect
+	go.opentelemetry.io/otel v1.35.0 // indirect
+	go.opentelemetry.io/otel/metric v1.35.0 // indirect
+	go.opentelemetry.io/otel/trace v1.35.0 // indirect
+)
+
+replace github.com/ecosyste-ms/ecosystems-go => ../
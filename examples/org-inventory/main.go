@@ -0,0 +1,63 @@
+// Command org-inventory reads repository URLs from stdin, one per line, and
+// prints an inventory of language, stars, license and archived status.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+)
+
+func main() {
+	client, err := ecosystems.NewClient("ecosystems-go-examples/org-inventory")
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITORY\tLANGUAGE\tSTARS\tLICENSE\tARCHIVED")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		url := strings.TrimSpace(scanner.Text())
+		if url == "" {
+			continue
+		}
+		repo, err := client.GetRepository(ctx, url)
+		if err != nil {
+			log.Printf("%s: %v", url, err)
+			continue
+		}
+		if repo == nil {
+			fmt.Fprintf(tw, "%s\t(not found)\t\t\t\n", url)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%t\n", deref(repo.FullName), deref(repo.Language),
+			derefInt(repo.StargazersCount), deref(repo.License), repo.Archived != nil && *repo.Archived)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	tw.Flush()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,72 @@
+// Command outdated-reporter reads versioned PURLs from stdin, one per line,
+// and reports which ones are behind the latest published release.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+)
+
+func main() {
+	pinned := make(map[string]string)
+	var order []string
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		purl, err := ecosystems.ParsePURL(line)
+		if err != nil {
+			log.Printf("skipping %s: %v", line, err)
+			continue
+		}
+		if purl.Version == "" {
+			log.Printf("skipping %s: no version", line)
+			continue
+		}
+		version := purl.Version
+		purl.Version = ""
+		key := purl.ToString()
+		if _, ok := pinned[key]; !ok {
+			order = append(order, key)
+		}
+		pinned[key] = version
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := ecosystems.NewClient("ecosystems-go-examples/outdated-reporter")
+	if err != nil {
+		log.Fatal(err)
+	}
+	results, err := client.BulkLookup(context.Background(), order)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tPINNED\tLATEST\tSTATUS")
+	for _, key := range order {
+		pkg, ok := results[key]
+		if !ok || pkg.LatestReleaseNumber == nil {
+			fmt.Fprintf(tw, "%s\t%s\t\tunknown\n", key, pinned[key])
+			continue
+		}
+		status := "up to date"
+		if *pkg.LatestReleaseNumber != pinned[key] {
+			status = "outdated"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", key, pinned[key], *pkg.LatestReleaseNumber, status)
+	}
+	tw.Flush()
+}
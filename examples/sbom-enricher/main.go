@@ -0,0 +1,117 @@
+// Command sbom-enricher reads a CycloneDX or SPDX JSON SBOM and prints
+// ecosyste.ms registry, license and repository data for every PURL in it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+)
+
+// sbom holds the fields of CycloneDX and SPDX JSON documents that carry PURLs.
+type sbom struct {
+	Components []struct {
+		Purl string `json:"purl"`
+	} `json:"components"`
+	Packages []struct {
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func (s *sbom) purls() []string {
+	seen := make(map[string]bool)
+	var purls []string
+	add := func(p string) {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			purls = append(purls, p)
+		}
+	}
+	for _, c := range s.Components {
+		add(c.Purl)
+	}
+	for _, p := range s.Packages {
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				add(ref.ReferenceLocator)
+			}
+		}
+	}
+	return purls
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("usage: sbom-enricher <sbom.json>")
+	}
+
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	var doc sbom
+	if err := json.Unmarshal(data, &doc); err != nil {
+		log.Fatalf("parsing SBOM: %v", err)
+	}
+
+	// Look up packages without versions; the SBOM's versions are kept for display.
+	byPackage := make(map[string][]string)
+	for _, p := range doc.purls() {
+		purl, err := ecosystems.ParsePURL(p)
+		if err != nil {
+			log.Printf("skipping %s: %v", p, err)
+			continue
+		}
+		purl.Version = ""
+		purl.Qualifiers = nil
+		purl.Subpath = ""
+		byPackage[purl.ToString()] = append(byPackage[purl.ToString()], p)
+	}
+
+	keys := make([]string, 0, len(byPackage))
+	for k := range byPackage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	client, err := ecosystems.NewClient("ecosystems-go-examples/sbom-enricher")
+	if err != nil {
+		log.Fatal(err)
+	}
+	results, err := client.BulkLookup(context.Background(), keys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PURL\tREGISTRY\tLICENSES\tREPOSITORY")
+	for _, k := range keys {
+		pkg, ok := results[k]
+		for _, original := range byPackage[k] {
+			if !ok {
+				fmt.Fprintf(tw, "%s\t(not found)\t\t\n", original)
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", original, pkg.Registry.Name,
+				strings.Join(pkg.NormalizedLicenses, ","), deref(pkg.RepositoryUrl))
+		}
+	}
+	tw.Flush()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,199 @@
+package ecosystems
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// ndjsonRecord is one line of NDJSON output.
+type ndjsonRecord struct {
+	PURL    string                        `json:"purl"`
+	Package *packages.PackageWithRegistry `json:"package"`
+}
+
+// ExportNDJSON writes bulk lookup results as newline-delimited JSON, one
+// {"purl": ..., "package": {...}} object per line, sorted by PURL.
+func ExportNDJSON(w io.Writer, results map[string]*packages.PackageWithRegistry) error {
+	write := NDJSONWriter(w)
+	for _, purl := range slices.Sorted(maps.Keys(results)) {
+		if err := write(purl, results[purl]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NDJSONWriter returns a callback for BulkLookupStream that writes each
+// package found as a line of NDJSON in the format of ExportNDJSON, in the
+// order results arrive. PURLs the API did not recognize are skipped.
+//
+//	err := client.BulkLookupStream(ctx, purls, ecosystems.NDJSONWriter(os.Stdout))
+func NDJSONWriter(w io.Writer) func(purl string, pkg *packages.PackageWithRegistry) error {
+	enc := json.NewEncoder(w)
+	return func(purl string, pkg *packages.PackageWithRegistry) error {
+		if pkg == nil {
+			return nil
+		}
+		if err := enc.Encode(ndjsonRecord{PURL: purl, Package: pkg}); err != nil {
+			return fmt.Errorf("writing NDJSON: %w", err)
+		}
+		return nil
+	}
+}
+
+// CSVColumn names a column of CSV export.
+type CSVColumn string
+
+// Columns for ExportPackagesCSV and ExportVersionsCSV. Not every column
+// applies to both; see each function.
+const (
+	ColumnPURL          CSVColumn = "purl"
+	ColumnName          CSVColumn = "name"
+	ColumnRegistry      CSVColumn = "registry"
+	ColumnLatestVersion CSVColumn = "latest_version"
+	ColumnVersion       CSVColumn = "version"
+	ColumnLicenses      CSVColumn = "licenses"
+	ColumnRepositoryURL CSVColumn = "repository_url"
+	ColumnDownloads     CSVColumn = "downloads"
+	ColumnPublishedAt   CSVColumn = "published_at"
+)
+
+// DefaultPackageCSVColumns are used by ExportPackagesCSV when no columns
+// are given.
+var DefaultPackageCSVColumns = []CSVColumn{
+	ColumnPURL, ColumnName, ColumnRegistry, ColumnLatestVersion,
+	ColumnLicenses, ColumnRepositoryURL, ColumnDownloads,
+}
+
+// DefaultVersionCSVColumns are used by ExportVersionsCSV when no columns
+// are given.
+var DefaultVersionCSVColumns = []CSVColumn{ColumnPURL, ColumnVersion, ColumnLicenses, ColumnPublishedAt}
+
+var packageCSVColumns = map[CSVColumn]func(purl string, pkg *packages.PackageWithRegistry) string{
+	ColumnPURL:          func(purl string, _ *packages.PackageWithRegistry) string { return purl },
+	ColumnName:          func(_ string, pkg *packages.PackageWithRegistry) string { return pkg.Name },
+	ColumnRegistry:      func(_ string, pkg *packages.PackageWithRegistry) string { return pkg.Registry.Name },
+	ColumnLatestVersion: func(_ string, pkg *packages.PackageWithRegistry) string { return deref(pkg.LatestReleaseNumber) },
+	ColumnLicenses: func(_ string, pkg *packages.PackageWithRegistry) string {
+		if len(pkg.NormalizedLicenses) > 0 {
+			return strings.Join(pkg.NormalizedLicenses, ", ")
+		}
+		return deref(pkg.Licenses)
+	},
+	ColumnRepositoryURL: func(_ string, pkg *packages.PackageWithRegistry) string { return deref(pkg.RepositoryUrl) },
+	ColumnDownloads:     func(_ string, pkg *packages.PackageWithRegistry) string { return strconv.Itoa(pkg.Downloads) },
+	ColumnPublishedAt: func(_ string, pkg *packages.PackageWithRegistry) string {
+		if pkg.LatestReleasePublishedAt == nil {
+			return ""
+		}
+		return pkg.LatestReleasePublishedAt.UTC().Format(time.RFC3339)
+	},
+}
+
+var versionCSVColumns = map[CSVColumn]func(v packages.Version) string{
+	ColumnPURL:        func(v packages.Version) string { return v.Purl },
+	ColumnVersion:     func(v packages.Version) string { return v.Number },
+	ColumnLicenses:    func(v packages.Version) string { return deref(v.Licenses) },
+	ColumnPublishedAt: func(v packages.Version) string { return deref(v.PublishedAt) },
+}
+
+// ExportPackagesCSV writes bulk lookup results as CSV with a header row,
+// one package per row sorted by PURL. ColumnPublishedAt is the latest
+// release's publish time and ColumnVersion is not supported. A nil columns
+// uses DefaultPackageCSVColumns.
+func ExportPackagesCSV(w io.Writer, results map[string]*packages.PackageWithRegistry, columns []CSVColumn) error {
+	if columns == nil {
+		columns = DefaultPackageCSVColumns
+	}
+	fields, err := csvFields(packageCSVColumns, columns)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, columns, func(write func([]string) error) error {
+		for _, purl := range slices.Sorted(maps.Keys(results)) {
+			row := make([]string, len(fields))
+			for i, f := range fields {
+				row[i] = f(purl, results[purl])
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExportVersionsCSV writes versions as CSV with a header row, one version
+// per row in the order given. It supports ColumnPURL, ColumnVersion,
+// ColumnLicenses and ColumnPublishedAt. A nil columns uses
+// DefaultVersionCSVColumns.
+func ExportVersionsCSV(w io.Writer, versions []packages.Version, columns []CSVColumn) error {
+	if columns == nil {
+		columns = DefaultVersionCSVColumns
+	}
+	fields, err := csvFields(versionCSVColumns, columns)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, columns, func(write func([]string) error) error {
+		for _, v := range versions {
+			row := make([]string, len(fields))
+			for i, f := range fields {
+				row[i] = f(v)
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// csvFields looks up the value function of each column.
+func csvFields[F any](available map[CSVColumn]F, columns []CSVColumn) ([]F, error) {
+	fields := make([]F, len(columns))
+	for i, col := range columns {
+		f, ok := available[col]
+		if !ok {
+			return nil, fmt.Errorf("unsupported CSV column %q", col)
+		}
+		fields[i] = f
+	}
+	return fields, nil
+}
+
+// writeCSV writes the header row, then the rows produced by rows.
+func writeCSV(w io.Writer, columns []CSVColumn, rows func(write func([]string) error) error) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = string(col)
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	if err := rows(cw.Write); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
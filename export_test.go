@@ -0,0 +1,107 @@
+package ecosystems
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestExportNDJSON(t *testing.T) {
+	results := map[string]*packages.PackageWithRegistry{
+		"pkg:npm/lodash": {Name: "lodash", Purl: "pkg:npm/lodash"},
+		"pkg:gem/rails":  {Name: "rails", Purl: "pkg:gem/rails"},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportNDJSON(&buf, results); err != nil {
+		t.Fatalf("ExportNDJSON() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ExportNDJSON() wrote %d lines, want 2", len(lines))
+	}
+	for i, want := range []string{"pkg:gem/rails", "pkg:npm/lodash"} {
+		var record struct {
+			PURL    string                       `json:"purl"`
+			Package packages.PackageWithRegistry `json:"package"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		if record.PURL != want || record.Package.Purl != want {
+			t.Errorf("line %d purl = %q, want %q", i, record.PURL, want)
+		}
+	}
+}
+
+func TestNDJSONWriterStream(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	var buf bytes.Buffer
+	err := client.BulkLookupStream(context.Background(), []string{"pkg:gem/rails", "pkg:npm/nope"}, NDJSONWriter(&buf))
+	if err != nil {
+		t.Fatalf("BulkLookupStream() error = %v", err)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 1 {
+		t.Errorf("NDJSONWriter() wrote %d lines, want 1", got)
+	}
+	if !strings.HasPrefix(buf.String(), `{"purl":"pkg:gem/rails","package":{`) {
+		t.Errorf("NDJSONWriter() = %s", buf.String())
+	}
+}
+
+func TestExportPackagesCSV(t *testing.T) {
+	latest, repo, licenses := "7.1.3", "https://github.com/rails/rails", "MIT"
+	results := map[string]*packages.PackageWithRegistry{
+		"pkg:gem/rails": {
+			Name: "rails", Registry: packages.Registry{Name: "rubygems.org"}, LatestReleaseNumber: &latest,
+			NormalizedLicenses: []string{"MIT", "Ruby"}, RepositoryUrl: &repo, Downloads: 500,
+		},
+		"pkg:npm/left-pad": {Name: "left-pad", Registry: packages.Registry{Name: "npmjs.org"}, Licenses: &licenses},
+	}
+
+	tests := []struct {
+		name    string
+		columns []CSVColumn
+		want    string
+	}{
+		{"default", nil, "purl,name,registry,latest_version,licenses,repository_url,downloads\n" +
+			"pkg:gem/rails,rails,rubygems.org,7.1.3,\"MIT, Ruby\",https://github.com/rails/rails,500\n" +
+			"pkg:npm/left-pad,left-pad,npmjs.org,,MIT,,0\n"},
+		{"selected", []CSVColumn{ColumnName, ColumnDownloads}, "name,downloads\nrails,500\nleft-pad,0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportPackagesCSV(&buf, results, tt.columns); err != nil {
+				t.Fatalf("ExportPackagesCSV() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("ExportPackagesCSV() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExportVersionsCSV(t *testing.T) {
+	published := "2021-02-20T15:42:16Z"
+	versions := []packages.Version{{Purl: "pkg:npm/lodash@4.17.21", Number: "4.17.21", PublishedAt: &published}}
+
+	var buf bytes.Buffer
+	if err := ExportVersionsCSV(&buf, versions, nil); err != nil {
+		t.Fatalf("ExportVersionsCSV() error = %v", err)
+	}
+	want := "purl,version,licenses,published_at\npkg:npm/lodash@4.17.21,4.17.21,,2021-02-20T15:42:16Z\n"
+	if buf.String() != want {
+		t.Errorf("ExportVersionsCSV() = %q, want %q", buf.String(), want)
+	}
+
+	if err := ExportVersionsCSV(&buf, versions, []CSVColumn{ColumnDownloads}); err == nil {
+		t.Error("ExportVersionsCSV() error = nil, want error for unsupported column")
+	}
+}
@@ -0,0 +1,238 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Where a FundingSource was found.
+const (
+	FundingFromPackage    = "package"
+	FundingFromRepository = "repository"
+	FundingFromSponsors   = "sponsors"
+)
+
+// FundingSource is one way to fund a package.
+type FundingSource struct {
+	// Platform is the funding platform, using the FUNDING.yml key such as
+	// "github", "open_collective" or "patreon", or "custom" for other links.
+	Platform string
+	// Account is the account or project on the platform, if known.
+	Account string
+	URL     string
+	// From says where the source was found, one of the FundingFrom
+	// constants.
+	From string
+}
+
+// SponsorsAccount is a GitHub Sponsors account known to
+// sponsors.ecosyste.ms. No OpenAPI spec of the sponsors service is
+// vendored, so its endpoint and these fields are unverified; a response
+// that does not match them is ignored rather than failing the lookup.
+type SponsorsAccount struct {
+	Login              string `json:"login"`
+	HasSponsorsListing bool   `json:"has_sponsors_listing"`
+	SponsorsCount      int    `json:"sponsors_count"`
+}
+
+// Funding is the answer to "can I fund this package?".
+type Funding struct {
+	PURL string
+	// Sources lists each distinct funding URL, first from the package
+	// metadata, then from the repository's FUNDING.yml, then the owner's
+	// GitHub Sponsors listing.
+	Sources []FundingSource
+	// Sponsors is the repository owner's sponsors.ecosyste.ms account, or
+	// nil if it has none or the repository is not on GitHub.
+	Sponsors *SponsorsAccount
+}
+
+// fundingPlatforms maps FUNDING.yml keys to the URL of an account.
+var fundingPlatforms = map[string]string{
+	"buy_me_a_coffee":  "https://buymeacoffee.com/%s",
+	"community_bridge": "https://funding.communitybridge.org/projects/%s",
+	"github":           "https://github.com/sponsors/%s",
+	"issuehunt":        "https://issuehunt.io/r/%s",
+	"ko_fi":            "https://ko-fi.com/%s",
+	"lfx_crowdfunding": "https://crowdfunding.lfx.linuxfoundation.org/projects/%s",
+	"liberapay":        "https://liberapay.com/%s",
+	"open_collective":  "https://opencollective.com/%s",
+	"patreon":          "https://www.patreon.com/%s",
+	"polar":            "https://polar.sh/%s",
+	"thanks_dev":       "https://thanks.dev/%s",
+	"tidelift":         "https://tidelift.com/funding/github/%s",
+}
+
+// fundingHosts recognizes the platform of a funding link by its host and
+// the path prefix before the account.
+var fundingHosts = []struct {
+	host, prefix, platform string
+}{
+	{"github.com", "/sponsors/", "github"},
+	{"opencollective.com", "/", "open_collective"},
+	{"patreon.com", "/", "patreon"},
+	{"ko-fi.com", "/", "ko_fi"},
+	{"liberapay.com", "/", "liberapay"},
+	{"buymeacoffee.com", "/", "buy_me_a_coffee"},
+	{"polar.sh", "/", "polar"},
+	{"thanks.dev", "/", "thanks_dev"},
+	{"tidelift.com", "/funding/github/", "tidelift"},
+}
+
+// GetFundingForPURL combines the funding links in a package's metadata,
+// its repository's FUNDING.yml and the repository owner's GitHub Sponsors
+// account into one answer. It returns nil if the package is not known.
+// Sponsors accounts are skipped when the sponsors service is disabled.
+func (c *Client) GetFundingForPURL(ctx context.Context, purl string, opts ...CallOption) (*Funding, error) {
+	p, err := c.ParsePURL(purl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %w", purl, err)
+	}
+	pkg, err := c.LookupPURL(ctx, p, opts...)
+	if err != nil || pkg == nil {
+		return nil, err
+	}
+
+	funding := &Funding{PURL: purl}
+	for _, link := range pkg.FundingLinks {
+		funding.add(fundingLink(link, FundingFromPackage))
+	}
+
+	if pkg.RepositoryUrl == nil || *pkg.RepositoryUrl == "" {
+		return funding, nil
+	}
+	repo, err := c.GetRepository(ctx, *pkg.RepositoryUrl, opts...)
+	if err != nil || repo == nil {
+		return funding, err
+	}
+	if repo.Metadata != nil {
+		if yml, ok := (*repo.Metadata)["funding"].(map[string]interface{}); ok {
+			for _, key := range slices.Sorted(maps.Keys(yml)) {
+				for _, account := range fundingAccounts(yml[key]) {
+					funding.add(fundingYAMLSource(key, account))
+				}
+			}
+		}
+	}
+
+	if repo.Host == nil || !strings.EqualFold(deref(repo.Host.Kind), "github") || deref(repo.Owner) == "" {
+		return funding, nil
+	}
+	account, err := c.getSponsorsAccount(ctx, deref(repo.Owner), opts...)
+	if err != nil {
+		return funding, err
+	}
+	if account != nil {
+		funding.Sponsors = account
+		if account.HasSponsorsListing {
+			funding.add(FundingSource{
+				Platform: "github", Account: account.Login,
+				URL: fmt.Sprintf(fundingPlatforms["github"], account.Login), From: FundingFromSponsors,
+			})
+		}
+	}
+	return funding, nil
+}
+
+// getSponsorsAccount returns the sponsors.ecosyste.ms account of a GitHub
+// login, or nil if it has none, the service is disabled or its response
+// does not have the expected shape.
+func (c *Client) getSponsorsAccount(ctx context.Context, login string, opts ...CallOption) (*SponsorsAccount, error) {
+	var raw json.RawMessage
+	err := c.services.lookup(ServiceSponsors).GetJSON(ctx, "accounts/"+login, nil, &raw, opts...)
+	var (
+		apiErr    *APIError
+		syntaxErr *json.SyntaxError
+	)
+	switch {
+	case errors.Is(err, ErrServiceDisabled):
+		return nil, nil
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case errors.As(err, &syntaxErr):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	var account SponsorsAccount
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, nil
+	}
+	if account.Login == "" {
+		account.Login = login
+	}
+	return &account, nil
+}
+
+// add appends s unless a source with the same URL is already listed.
+func (f *Funding) add(s FundingSource) {
+	if s.URL == "" {
+		return
+	}
+	for _, existing := range f.Sources {
+		if strings.EqualFold(strings.TrimSuffix(existing.URL, "/"), strings.TrimSuffix(s.URL, "/")) {
+			return
+		}
+	}
+	f.Sources = append(f.Sources, s)
+}
+
+// fundingLink describes a funding URL, recognizing well-known platforms.
+func fundingLink(link, from string) FundingSource {
+	s := FundingSource{Platform: "custom", URL: link, From: from}
+	u, err := url.Parse(link)
+	if err != nil {
+		return s
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, h := range fundingHosts {
+		if host == h.host && strings.HasPrefix(u.Path, h.prefix) {
+			if account := strings.Trim(strings.TrimPrefix(u.Path, h.prefix), "/"); account != "" {
+				s.Platform, s.Account = h.platform, account
+			}
+			break
+		}
+	}
+	return s
+}
+
+// fundingYAMLSource describes one account from a FUNDING.yml key.
+func fundingYAMLSource(key, account string) FundingSource {
+	if pattern, ok := fundingPlatforms[key]; ok {
+		return FundingSource{Platform: key, Account: account, URL: fmt.Sprintf(pattern, account), From: FundingFromRepository}
+	}
+	if strings.Contains(account, "://") {
+		return fundingLink(account, FundingFromRepository)
+	}
+	if key == "custom" {
+		return FundingSource{Platform: "custom", URL: "https://" + account, From: FundingFromRepository}
+	}
+	return FundingSource{}
+}
+
+// fundingAccounts returns the accounts of a FUNDING.yml value, which is a
+// string or a list of strings.
+func fundingAccounts(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		var accounts []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				accounts = append(accounts, s)
+			}
+		}
+		return accounts
+	}
+	return nil
+}
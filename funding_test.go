@@ -0,0 +1,152 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+func TestGetFundingForPURL(t *testing.T) {
+	_, srv := newTestClient(t)
+	sponsors := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/sindresorhus" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"login": "sindresorhus", "has_sponsors_listing": true, "sponsors_count": 42}`))
+	}))
+	defer sponsors.Close()
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithReposServer(srv.ReposURL()),
+		WithService(ServiceSponsors, ServiceConfig{BaseURL: sponsors.URL}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	repoURL := "https://github.com/sindresorhus/got"
+	pkg := registryPackage("npmjs.org", "got")
+	pkg.RepositoryUrl = &repoURL
+	pkg.FundingLinks = []string{"https://github.com/sponsors/sindresorhus", "https://example.com/donate"}
+	srv.AddPackage("npmjs.org", pkg)
+	kind, owner := "github", "sindresorhus"
+	srv.AddRepository(repos.Repository{
+		HtmlUrl: &repoURL,
+		Owner:   &owner,
+		Host:    &repos.Host{Kind: &kind},
+		Metadata: &map[string]interface{}{
+			"funding": map[string]interface{}{
+				"github":          "sindresorhus",
+				"open_collective": "got",
+				"custom":          []interface{}{"https://sindresorhus.com/donate"},
+			},
+		},
+	})
+
+	funding, err := client.GetFundingForPURL(context.Background(), "pkg:npm/got")
+	if err != nil {
+		t.Fatalf("GetFundingForPURL() error = %v", err)
+	}
+	want := []FundingSource{
+		{"github", "sindresorhus", "https://github.com/sponsors/sindresorhus", FundingFromPackage},
+		{"custom", "", "https://example.com/donate", FundingFromPackage},
+		{"custom", "", "https://sindresorhus.com/donate", FundingFromRepository},
+		{"open_collective", "got", "https://opencollective.com/got", FundingFromRepository},
+	}
+	if !reflect.DeepEqual(funding.Sources, want) {
+		t.Errorf("Sources = %+v, want %+v", funding.Sources, want)
+	}
+	if funding.Sponsors == nil || funding.Sponsors.SponsorsCount != 42 || !funding.Sponsors.HasSponsorsListing {
+		t.Errorf("Sponsors = %+v", funding.Sponsors)
+	}
+
+	missing, err := client.GetFundingForPURL(context.Background(), "pkg:npm/does-not-exist")
+	if err != nil || missing != nil {
+		t.Errorf("GetFundingForPURL(unknown) = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestGetFundingForPURLSponsorsDisabled(t *testing.T) {
+	_, srv := newTestClient(t)
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithReposServer(srv.ReposURL()),
+		WithService(ServiceSponsors, ServiceConfig{Disabled: true}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	repoURL := "https://github.com/lodash/lodash"
+	pkg := registryPackage("npmjs.org", "lodash-funded")
+	pkg.RepositoryUrl = &repoURL
+	srv.AddPackage("npmjs.org", pkg)
+	kind, owner := "github", "lodash"
+	srv.AddRepository(repos.Repository{HtmlUrl: &repoURL, Owner: &owner, Host: &repos.Host{Kind: &kind}})
+
+	funding, err := client.GetFundingForPURL(context.Background(), "pkg:npm/lodash-funded")
+	if err != nil {
+		t.Fatalf("GetFundingForPURL() error = %v", err)
+	}
+	if funding.Sponsors != nil || len(funding.Sources) != 0 {
+		t.Errorf("GetFundingForPURL() = %+v, want no funding", funding)
+	}
+}
+
+func TestGetFundingForPURLUnexpectedSponsorsResponse(t *testing.T) {
+	_, srv := newTestClient(t)
+	repoURL := "https://github.com/lodash/lodash"
+	pkg := registryPackage("npmjs.org", "lodash-funded")
+	pkg.RepositoryUrl = &repoURL
+	srv.AddPackage("npmjs.org", pkg)
+	kind, owner := "github", "lodash"
+	srv.AddRepository(repos.Repository{HtmlUrl: &repoURL, Owner: &owner, Host: &repos.Host{Kind: &kind}})
+
+	for _, body := range []string{`{"login": 42}`, `[]`, `<html>maintenance</html>`} {
+		t.Run(body, func(t *testing.T) {
+			sponsors := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			}))
+			defer sponsors.Close()
+			client, err := NewClient("test-agent/1.0",
+				WithPackagesServer(srv.PackagesURL()),
+				WithReposServer(srv.ReposURL()),
+				WithService(ServiceSponsors, ServiceConfig{BaseURL: sponsors.URL}),
+			)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			funding, err := client.GetFundingForPURL(context.Background(), "pkg:npm/lodash-funded")
+			if err != nil {
+				t.Fatalf("GetFundingForPURL() error = %v", err)
+			}
+			if funding.Sponsors != nil {
+				t.Errorf("Sponsors = %+v, want nil", funding.Sponsors)
+			}
+		})
+	}
+}
+
+func TestFundingLink(t *testing.T) {
+	tests := []struct {
+		link string
+		want FundingSource
+	}{
+		{"https://opencollective.com/webpack", FundingSource{"open_collective", "webpack", "https://opencollective.com/webpack", FundingFromPackage}},
+		{"https://www.patreon.com/user", FundingSource{"patreon", "user", "https://www.patreon.com/user", FundingFromPackage}},
+		{"https://tidelift.com/funding/github/npm/lodash", FundingSource{"tidelift", "npm/lodash", "https://tidelift.com/funding/github/npm/lodash", FundingFromPackage}},
+		{"https://github.com/lodash", FundingSource{"custom", "", "https://github.com/lodash", FundingFromPackage}},
+	}
+
+	for _, tt := range tests {
+		if got := fundingLink(tt.link, FundingFromPackage); got != tt.want {
+			t.Errorf("fundingLink(%q) = %+v, want %+v", tt.link, got, tt.want)
+		}
+	}
+}
@@ -3,8 +3,13 @@ module github.com/ecosyste-ms/ecosystems-go
 go 1.24.2
 
 require (
+	github.com/andybalholm/brotli v1.2.5
 	github.com/git-pkgs/packageurl-go v0.3.1
+	github.com/klauspost/compress v1.18.0
 	github.com/oapi-codegen/runtime v1.4.0
+	go.opentelemetry.io/otel v1.35.0
+	go.opentelemetry.io/otel/metric v1.35.0
+	go.opentelemetry.io/otel/trace v1.35.0
 )
 
 require (
@@ -0,0 +1,139 @@
+package ecosystems
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// DependencyGraph is a dependency tree resolved by the caller, with
+// packages keyed by PURL. Build it with AddEdge or AddVersion and export
+// it with WriteDOT or WriteJSON. Both formats list nodes and edges in a
+// stable order, so exports of the same graph are identical.
+type DependencyGraph struct {
+	nodes map[string]bool
+	edges map[GraphEdge]bool
+}
+
+// GraphEdge is a dependency from one package to another.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Constraint is the version requirement, such as "^4.17.0".
+	Constraint string `json:"constraint,omitempty"`
+	// Kind is the dependency kind, such as "runtime" or "development".
+	Kind string `json:"kind,omitempty"`
+}
+
+// NewDependencyGraph returns an empty graph.
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		nodes: make(map[string]bool),
+		edges: make(map[GraphEdge]bool),
+	}
+}
+
+// AddNode adds a package without dependencies.
+func (g *DependencyGraph) AddNode(purl string) {
+	g.nodes[purl] = true
+}
+
+// AddEdge adds a dependency and both of its packages.
+func (g *DependencyGraph) AddEdge(e GraphEdge) {
+	g.nodes[e.From] = true
+	g.nodes[e.To] = true
+	g.edges[e] = true
+}
+
+// AddVersion adds a version and an edge to each of its dependencies. The
+// dependencies are unresolved, so their nodes are package PURLs without a
+// version. With kinds, such as DependencyRuntime, only dependencies of
+// those normalized kinds are added.
+func (g *DependencyGraph) AddVersion(v *packages.VersionWithDependencies, kinds ...string) error {
+	g.AddNode(v.Purl)
+	deps := v.Dependencies
+	if len(kinds) > 0 {
+		deps = FilterDependenciesByKind(v, kinds...)
+	}
+	for _, dep := range deps {
+		purl, err := PackageToPURL(packages.Package{Ecosystem: dep.Ecosystem, Name: dep.PackageName})
+		if err != nil {
+			return fmt.Errorf("dependency %s of %s: %w", dep.PackageName, v.Purl, err)
+		}
+		e := GraphEdge{From: v.Purl, To: purl.ToString()}
+		if dep.Requirements != nil {
+			e.Constraint = *dep.Requirements
+		}
+		if dep.Kind != nil {
+			e.Kind = *dep.Kind
+		}
+		g.AddEdge(e)
+	}
+	return nil
+}
+
+// Nodes returns the PURLs of all packages in the graph, sorted.
+func (g *DependencyGraph) Nodes() []string {
+	return slices.Sorted(maps.Keys(g.nodes))
+}
+
+// Edges returns all dependencies in the graph, sorted by From, To,
+// Constraint and Kind.
+func (g *DependencyGraph) Edges() []GraphEdge {
+	return slices.SortedFunc(maps.Keys(g.edges), func(a, b GraphEdge) int {
+		return cmp.Or(
+			cmp.Compare(a.From, b.From),
+			cmp.Compare(a.To, b.To),
+			cmp.Compare(a.Constraint, b.Constraint),
+			cmp.Compare(a.Kind, b.Kind),
+		)
+	})
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, with each edge
+// labelled by its version constraint.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph dependencies {")
+	for _, n := range g.Nodes() {
+		fmt.Fprintf(bw, "  %s;\n", strconv.Quote(n))
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(bw, "  %s -> %s", strconv.Quote(e.From), strconv.Quote(e.To))
+		if e.Constraint != "" {
+			fmt.Fprintf(bw, " [label=%s]", strconv.Quote(e.Constraint))
+		}
+		fmt.Fprintln(bw, ";")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// graphJSON is the JSON form of a DependencyGraph.
+type graphJSON struct {
+	Nodes []string    `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// WriteJSON writes the graph as a JSON object with a sorted "nodes" array
+// of PURLs and an "edges" array of objects with "from", "to" and optional
+// "constraint" and "kind" fields.
+func (g *DependencyGraph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	out := graphJSON{Nodes: g.Nodes(), Edges: g.Edges()}
+	if out.Nodes == nil {
+		out.Nodes = []string{}
+	}
+	if out.Edges == nil {
+		out.Edges = []GraphEdge{}
+	}
+	return enc.Encode(out)
+}
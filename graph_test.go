@@ -0,0 +1,128 @@
+package ecosystems
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func testGraph(t *testing.T) *DependencyGraph {
+	t.Helper()
+	runtime, dev := "runtime", "development"
+	caret, tilde := "^4.17.0", "~7.0"
+	g := NewDependencyGraph()
+	err := g.AddVersion(&packages.VersionWithDependencies{
+		Purl: "pkg:npm/app@1.0.0",
+		Dependencies: []packages.Dependency{
+			{Ecosystem: "npm", PackageName: "lodash", Requirements: &caret, Kind: &runtime},
+			{Ecosystem: "npm", PackageName: "@babel/core", Requirements: &tilde, Kind: &dev},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddVersion() error = %v", err)
+	}
+	return g
+}
+
+func TestDependencyGraph(t *testing.T) {
+	g := testGraph(t)
+
+	wantNodes := []string{"pkg:npm/%40babel/core", "pkg:npm/app@1.0.0", "pkg:npm/lodash"}
+	if got := g.Nodes(); !reflect.DeepEqual(got, wantNodes) {
+		t.Errorf("Nodes() = %v, want %v", got, wantNodes)
+	}
+
+	edges := g.Edges()
+	if len(edges) != 2 {
+		t.Fatalf("Edges() returned %d edges, want 2", len(edges))
+	}
+	want := GraphEdge{From: "pkg:npm/app@1.0.0", To: "pkg:npm/lodash", Constraint: "^4.17.0", Kind: "runtime"}
+	if edges[1] != want {
+		t.Errorf("Edges()[1] = %+v, want %+v", edges[1], want)
+	}
+}
+
+func TestDependencyGraphAddVersionKinds(t *testing.T) {
+	g := NewDependencyGraph()
+	dev, normal := "dev", "normal"
+	err := g.AddVersion(&packages.VersionWithDependencies{
+		Purl: "pkg:cargo/app@1.0.0",
+		Dependencies: []packages.Dependency{
+			{Ecosystem: "cargo", PackageName: "serde", Kind: &normal},
+			{Ecosystem: "cargo", PackageName: "criterion", Kind: &dev},
+		},
+	}, DependencyRuntime)
+	if err != nil {
+		t.Fatalf("AddVersion() error = %v", err)
+	}
+	want := []string{"pkg:cargo/app@1.0.0", "pkg:cargo/serde"}
+	if got := g.Nodes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraphUnknownEcosystem(t *testing.T) {
+	g := NewDependencyGraph()
+	err := g.AddVersion(&packages.VersionWithDependencies{
+		Purl:         "pkg:npm/app@1.0.0",
+		Dependencies: []packages.Dependency{{Ecosystem: "unknown", PackageName: "x"}},
+	})
+	if err == nil {
+		t.Error("AddVersion() with unknown ecosystem should error")
+	}
+}
+
+func TestDependencyGraphWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testGraph(t).WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+
+	want := `digraph dependencies {
+  "pkg:npm/%40babel/core";
+  "pkg:npm/app@1.0.0";
+  "pkg:npm/lodash";
+  "pkg:npm/app@1.0.0" -> "pkg:npm/%40babel/core" [label="~7.0"];
+  "pkg:npm/app@1.0.0" -> "pkg:npm/lodash" [label="^4.17.0"];
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDependencyGraphWriteJSON(t *testing.T) {
+	var first, second bytes.Buffer
+	if err := testGraph(t).WriteJSON(&first); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if err := testGraph(t).WriteJSON(&second); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if first.String() != second.String() {
+		t.Error("WriteJSON() output is not stable")
+	}
+
+	var decoded struct {
+		Nodes []string    `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(first.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() produced invalid JSON: %v", err)
+	}
+	if len(decoded.Nodes) != 3 || len(decoded.Edges) != 2 {
+		t.Errorf("WriteJSON() = %d nodes, %d edges, want 3 and 2", len(decoded.Nodes), len(decoded.Edges))
+	}
+
+	var empty bytes.Buffer
+	if err := NewDependencyGraph().WriteJSON(&empty); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if !strings.Contains(empty.String(), `"nodes": []`) {
+		t.Errorf("WriteJSON() of empty graph = %s, want empty arrays", empty.String())
+	}
+}
@@ -0,0 +1,84 @@
+package ecosystems
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// minGzipRequestBytes is the smallest request body WithRequestCompression
+// compresses. Smaller bodies gain little.
+const minGzipRequestBytes = 1024
+
+// WithRequestCompression gzips large request bodies, such as bulk lookups
+// of many PURLs, and sends them with Content-Encoding: gzip. If the server
+// rejects a compressed body with 415 Unsupported Media Type, the request is
+// sent again uncompressed and the client stops compressing.
+func WithRequestCompression() Option {
+	return func(c *clientConfig) {
+		c.gzipRequests = true
+	}
+}
+
+// gzipRequestTransport compresses request bodies.
+type gzipRequestTransport struct {
+	next        http.RoundTripper
+	stats       *clientStats
+	unsupported atomic.Bool
+}
+
+func (t *gzipRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.unsupported.Load() || req.GetBody == nil || req.ContentLength < minGzipRequestBytes ||
+		req.Header.Get("Content-Encoding") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	compressed, err := gzipBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(compressed)
+	if err != nil || resp.StatusCode != http.StatusUnsupportedMediaType {
+		return resp, err
+	}
+
+	resp.Body.Close()
+	t.unsupported.Store(true)
+	t.stats.retry()
+	retry := req.Clone(req.Context())
+	if retry.Body, err = req.GetBody(); err != nil {
+		return nil, fmt.Errorf("resending uncompressed request: %w", err)
+	}
+	return t.next.RoundTrip(retry)
+}
+
+// gzipBody returns a copy of req with its body gzipped.
+func gzipBody(req *http.Request) (*http.Request, error) {
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("compressing request: %w", err)
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, body); err != nil {
+		return nil, fmt.Errorf("compressing request: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compressing request: %w", err)
+	}
+
+	data := buf.Bytes()
+	out := req.Clone(req.Context())
+	out.Header.Set("Content-Encoding", "gzip")
+	out.ContentLength = int64(len(data))
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package ecosystems
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func manyPURLs(n int) []string {
+	purls := []string{"pkg:gem/rails"}
+	for i := 1; i < n; i++ {
+		purls = append(purls, fmt.Sprintf("pkg:npm/missing-package-%d", i))
+	}
+	return purls
+}
+
+func TestWithRequestCompression(t *testing.T) {
+	_, srv := newTestClient(t)
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.PackagesURL()), WithRequestCompression())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results, err := client.BulkLookup(context.Background(), manyPURLs(50))
+	if err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	if results["pkg:gem/rails"] == nil {
+		t.Error("BulkLookup() missing pkg:gem/rails")
+	}
+}
+
+func TestWithRequestCompressionEncoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		purls       int
+		unsupported bool
+		calls       int
+		want        []string
+	}{
+		{"small body", 1, false, 1, []string{""}},
+		{"large body", 50, false, 2, []string{"gzip", "gzip"}},
+		{"unsupported", 50, true, 2, []string{"gzip", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var encodings []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encoding := r.Header.Get("Content-Encoding")
+				mu.Lock()
+				encodings = append(encodings, encoding)
+				mu.Unlock()
+				if encoding == "gzip" && tt.unsupported {
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+				body := io.Reader(r.Body)
+				if encoding == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Errorf("gzip.NewReader() error = %v", err)
+						return
+					}
+					body = zr
+				}
+				var req struct{ Purls []string }
+				if err := json.NewDecoder(body).Decode(&req); err != nil || len(req.Purls) != tt.purls {
+					t.Errorf("request body purls = %d, %v, want %d", len(req.Purls), err, tt.purls)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer srv.Close()
+
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithRequestCompression())
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			for range tt.calls {
+				if _, err := client.BulkLookup(context.Background(), manyPURLs(tt.purls)); err != nil {
+					t.Fatalf("BulkLookup() error = %v", err)
+				}
+			}
+			if fmt.Sprint(encodings) != fmt.Sprint(tt.want) {
+				t.Errorf("Content-Encoding = %q, want %q", encodings, tt.want)
+			}
+			if retries := client.Stats().Retries; (retries == 1) != tt.unsupported {
+				t.Errorf("Stats().Retries = %d, unsupported %v", retries, tt.unsupported)
+			}
+		})
+	}
+}
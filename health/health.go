@@ -0,0 +1,208 @@
+// Package health scores how well maintained packages are, from the package
+// and repository data ecosyste.ms collects.
+//
+// A score combines several signals, each rated from 0 (worst) to 1 (best):
+// how recently a version was released, how recently the repository was
+// pushed to, whether it is archived, how quickly issues get a response and
+// how many maintainers the package has. Signals that are unknown for a
+// package are left out and the remaining weights rescaled, so a package is
+// not penalized for missing data.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// Component names used as keys in Score.Components.
+const (
+	ComponentReleaseAge    = "release_age"
+	ComponentCommitRecency = "commit_recency"
+	ComponentArchived      = "archived"
+	ComponentIssueResponse = "issue_response"
+	ComponentMaintainers   = "maintainers"
+)
+
+// Signals are the inputs to a health score. Zero values mean unknown.
+type Signals struct {
+	// LastRelease is when the latest version was published.
+	LastRelease time.Time
+	// LastCommit is when the repository was last pushed to.
+	LastCommit time.Time
+	// HasRepository reports whether repository data was found, which makes
+	// Archived meaningful.
+	HasRepository bool
+	Archived      bool
+	// IssueResponseTime is the typical time until an issue gets a response.
+	// The packages and repos APIs do not report it, so set it from another
+	// source such as issues.ecosyste.ms.
+	IssueResponseTime time.Duration
+	// Maintainers is the number of registry maintainers.
+	Maintainers int
+}
+
+// Weights sets how much each signal contributes to a score. Only the
+// ratios between weights matter.
+type Weights struct {
+	ReleaseAge    float64
+	CommitRecency float64
+	Archived      float64
+	IssueResponse float64
+	Maintainers   float64
+}
+
+// DefaultWeights favors release and commit activity.
+var DefaultWeights = Weights{
+	ReleaseAge:    0.3,
+	CommitRecency: 0.3,
+	Archived:      0.2,
+	IssueResponse: 0.1,
+	Maintainers:   0.1,
+}
+
+// Score is a package's health score.
+type Score struct {
+	PURL string
+	// Value ranges from 0 to 100, higher is healthier. It is 0 when no
+	// signal is known.
+	Value float64
+	// Components holds the 0 to 1 rating of each known signal, keyed by
+	// the Component constants.
+	Components map[string]float64
+	Signals    Signals
+}
+
+// Compute scores signals as of now.
+func Compute(s Signals, w Weights, now time.Time) Score {
+	score := Score{Signals: s, Components: make(map[string]float64)}
+	var total, weight float64
+	add := func(name string, w, rating float64) {
+		score.Components[name] = rating
+		total += w * rating
+		weight += w
+	}
+
+	if !s.LastRelease.IsZero() {
+		add(ComponentReleaseAge, w.ReleaseAge, decay(now.Sub(s.LastRelease), 90*day, 3*365*day))
+	}
+	if !s.LastCommit.IsZero() {
+		add(ComponentCommitRecency, w.CommitRecency, decay(now.Sub(s.LastCommit), 30*day, 2*365*day))
+	}
+	if s.HasRepository {
+		rating := 1.0
+		if s.Archived {
+			rating = 0
+		}
+		add(ComponentArchived, w.Archived, rating)
+	}
+	if s.IssueResponseTime > 0 {
+		add(ComponentIssueResponse, w.IssueResponse, decay(s.IssueResponseTime, 2*day, 60*day))
+	}
+	if s.Maintainers > 0 {
+		add(ComponentMaintainers, w.Maintainers, maintainersRating(s.Maintainers))
+	}
+
+	if weight > 0 {
+		score.Value = 100 * total / weight
+	}
+	return score
+}
+
+const day = 24 * time.Hour
+
+// decay rates d as 1 up to good, falling linearly to 0 at bad.
+func decay(d, good, bad time.Duration) float64 {
+	switch {
+	case d <= good:
+		return 1
+	case d >= bad:
+		return 0
+	}
+	return 1 - float64(d-good)/float64(bad-good)
+}
+
+// maintainersRating rewards a bus factor above one.
+func maintainersRating(n int) float64 {
+	switch n {
+	case 1:
+		return 0.4
+	case 2:
+		return 0.7
+	}
+	return 1
+}
+
+// SignalsFrom extracts signals from a package and its repository, either
+// of which may be nil.
+func SignalsFrom(pkg *packages.PackageWithRegistry, repo *repos.Repository) Signals {
+	var s Signals
+	if pkg != nil {
+		if pkg.LatestReleasePublishedAt != nil {
+			s.LastRelease = *pkg.LatestReleasePublishedAt
+		}
+		s.Maintainers = len(pkg.Maintainers)
+	}
+	if repo != nil {
+		s.HasRepository = true
+		if repo.PushedAt != nil {
+			s.LastCommit = *repo.PushedAt
+		}
+		if repo.Archived != nil {
+			s.Archived = *repo.Archived
+		}
+	}
+	return s
+}
+
+// Scorer looks up packages and their repositories and scores them.
+type Scorer struct {
+	Client  ecosystems.ClientInterface
+	Weights Weights
+	// Now returns the time scores are computed at. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewScorer returns a Scorer using DefaultWeights.
+func NewScorer(client ecosystems.ClientInterface) *Scorer {
+	return &Scorer{Client: client, Weights: DefaultWeights, Now: time.Now}
+}
+
+// Score scores each PURL the API recognizes. PURLs that are not found are
+// omitted from the result.
+func (s *Scorer) Score(ctx context.Context, purls []string, opts ...ecosystems.CallOption) (map[string]*Score, error) {
+	pkgs, err := s.Client.BulkLookup(ctx, purls, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var repoURLs []string
+	for _, pkg := range pkgs {
+		if pkg.RepositoryUrl != nil && *pkg.RepositoryUrl != "" {
+			repoURLs = append(repoURLs, *pkg.RepositoryUrl)
+		}
+	}
+	found, err := s.Client.BulkGetRepositories(ctx, repoURLs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	scores := make(map[string]*Score, len(pkgs))
+	for purl, pkg := range pkgs {
+		var repo *repos.Repository
+		if pkg.RepositoryUrl != nil {
+			repo = found.Repositories[ecosystems.NormalizeRepoURL(*pkg.RepositoryUrl)]
+		}
+		score := Compute(SignalsFrom(pkg, repo), s.Weights, now)
+		score.PURL = purl
+		scores[purl] = &score
+	}
+	return scores, nil
+}
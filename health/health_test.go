@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+)
+
+var now = time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals Signals
+		want    float64
+	}{
+		{"unknown", Signals{}, 0},
+		{"active", Signals{
+			LastRelease:   now.AddDate(0, -1, 0),
+			LastCommit:    now.AddDate(0, 0, -3),
+			HasRepository: true,
+			Maintainers:   5,
+		}, 100},
+		{"archived", Signals{
+			LastRelease:   now.AddDate(0, -1, 0),
+			LastCommit:    now.AddDate(0, 0, -3),
+			HasRepository: true,
+			Archived:      true,
+			Maintainers:   5,
+		}, 100 * 0.7 / 0.9},
+		{"abandoned", Signals{
+			LastRelease:   now.AddDate(-5, 0, 0),
+			LastCommit:    now.AddDate(-4, 0, 0),
+			HasRepository: true,
+			Archived:      true,
+		}, 0},
+		{"release only", Signals{LastRelease: now.AddDate(0, 0, -10)}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(tt.signals, DefaultWeights, now)
+			if math.Abs(got.Value-tt.want) > 0.001 {
+				t.Errorf("Compute().Value = %v, want %v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeWeights(t *testing.T) {
+	s := Signals{
+		LastRelease:   now.AddDate(-5, 0, 0),
+		HasRepository: true,
+	}
+	got := Compute(s, Weights{ReleaseAge: 1, Archived: 3}, now)
+	if got.Value != 75 {
+		t.Errorf("Compute().Value = %v, want 75", got.Value)
+	}
+	if got.Components[ComponentReleaseAge] != 0 || got.Components[ComponentArchived] != 1 {
+		t.Errorf("Compute().Components = %v", got.Components)
+	}
+	if _, ok := got.Components[ComponentCommitRecency]; ok {
+		t.Error("Compute().Components includes unknown commit recency")
+	}
+}
+
+func TestDecay(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want float64
+	}{
+		{0, 1},
+		{10 * day, 1},
+		{55 * day, 0.5},
+		{100 * day, 0},
+		{200 * day, 0},
+	}
+
+	for _, tt := range tests {
+		if got := decay(tt.d, 10*day, 100*day); got != tt.want {
+			t.Errorf("decay(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestScorer(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+	client, err := ecosystems.NewClient("test-agent/1.0",
+		ecosystems.WithPackagesServer(srv.PackagesURL()),
+		ecosystems.WithReposServer(srv.ReposURL()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	scorer := NewScorer(client)
+	scorer.Now = func() time.Time { return now }
+	scores, err := scorer.Score(context.Background(), []string{"pkg:gem/rails", "pkg:npm/missing"})
+	if err != nil {
+		t.Fatalf("Score() error = %v", err)
+	}
+	if len(scores) != 1 {
+		t.Fatalf("Score() returned %d scores, want 1", len(scores))
+	}
+
+	rails := scores["pkg:gem/rails"]
+	if rails == nil || !rails.Signals.HasRepository || rails.Signals.Maintainers != 1 {
+		t.Fatalf("Score()[rails] = %+v, want package and repository signals", rails)
+	}
+	if rails.Value <= 50 || rails.Value > 100 {
+		t.Errorf("Score()[rails].Value = %v, want a healthy score", rails.Value)
+	}
+}
@@ -0,0 +1,46 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// HostRepositoriesIter iterates over every repository ecosyste.ms knows on a
+// host such as "GitHub", fetching pages as the loop advances. Hosts can
+// hold millions of repositories, so stop early by breaking out of the loop.
+// Like every API request, pages rejected with 429 Too Many Requests are
+// retried after the Retry-After delay, or with exponential backoff; any
+// other failed request ends the iteration with its error.
+func (c *Client) HostRepositoriesIter(ctx context.Context, host string, opts ...CallOption) iter.Seq2[repos.Repository, error] {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "HostRepositoriesIter", "")
+	perPage := c.perPage(call, DefaultPageSize)
+
+	return paginate(ctx, func(page int) (*Page[repos.Repository], error) {
+		resp, err := c.reposAPI().GetHostRepositoriesWithResponse(ctx, host, &repos.GetHostRepositoriesParams{
+			Page:    &page,
+			PerPage: &perPage,
+		}, call.reposEditors()...)
+		if err != nil {
+			return nil, fmt.Errorf("list host repositories: %w", err)
+		}
+
+		if resp.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, newAPIError("list host repositories", resp.HTTPResponse, resp.Body)
+		}
+
+		if resp.JSON200 == nil {
+			return nil, nil
+		}
+
+		return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{Page: page, PerPage: perPage}), nil
+	})
+}
@@ -0,0 +1,96 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostRepositoriesIter(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	var names []string
+	for repo, err := range client.HostRepositoriesIter(context.Background(), "GitHub", CallPageSize(2)) {
+		if err != nil {
+			t.Fatalf("HostRepositoriesIter() error = %v", err)
+		}
+		names = append(names, *repo.FullName)
+	}
+	if len(names) != 3 {
+		t.Errorf("HostRepositoriesIter() = %v, want 3 GitHub repositories", names)
+	}
+
+	for _, err := range client.HostRepositoriesIter(context.Background(), "nowhere") {
+		t.Errorf("HostRepositoriesIter(nowhere) yielded error %v, want nothing", err)
+	}
+}
+
+func TestHostRepositoriesIterRateLimited(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		repos := []map[string]string{}
+		if page == 1 {
+			repos = append(repos, map[string]string{"full_name": "a/b"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(repos)
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithReposServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var count int
+	for _, err := range client.HostRepositoriesIter(context.Background(), "GitHub", CallPageSize(1)) {
+		if err != nil {
+			t.Fatalf("HostRepositoriesIter() error = %v", err)
+		}
+		count++
+	}
+	if count != 1 || calls != 4 {
+		t.Errorf("HostRepositoriesIter() yielded %d repositories in %d requests, want 1 in 4", count, calls)
+	}
+	if stats := client.Stats(); stats.Retries != 2 || stats.RateLimitWaits != 2 || stats.Requests[RequestKey{"HostRepositoriesIter", http.StatusTooManyRequests}] != 2 {
+		t.Errorf("Stats() = %+v, want 2 rate limited retries", stats)
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{"seconds", 3, "7", 7 * time.Second},
+		{"past date", 0, "Mon, 02 Jan 2006 15:04:05 GMT", 0},
+		{"first backoff", 0, "", time.Second},
+		{"doubled", 3, "", 8 * time.Second},
+		{"capped", 10, "", time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.retryAfter != "" {
+				h.Set("Retry-After", tt.retryAfter)
+			}
+			if got := rateLimitDelay(tt.attempt, h); got != tt.want {
+				t.Errorf("rateLimitDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,15 +4,37 @@ package ecosystems
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
 
-func TestIntegrationBulkLookup(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
+// newIntegrationClient creates a client that replays responses recorded in
+// testdata/cassettes, so a request without a cassette fails the test. When
+// no cassettes have been recorded yet it runs against the live API and
+// records what it sees. Set ECOSYSTEMS_RECORD=record to re-record them.
+func newIntegrationClient(t *testing.T) *Client {
+	t.Helper()
+	dir := filepath.Join("testdata", "cassettes")
+	mode := RecorderReplay
+	if os.Getenv("ECOSYSTEMS_RECORD") == "record" {
+		mode = RecorderRecord
+	} else if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		mode = RecorderReplayOrRecord
+	}
+	client, err := NewClient("ecosystems-go-test/1.0",
+		WithRecorder(dir, mode),
+	)
 	if err != nil {
 		t.Fatalf("NewClient() error = %v", err)
 	}
+	return client
+}
+
+func TestIntegrationBulkLookup(t *testing.T) {
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -47,10 +69,7 @@ func TestIntegrationBulkLookup(t *testing.T) {
 }
 
 func TestIntegrationLookup(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -70,10 +89,7 @@ func TestIntegrationLookup(t *testing.T) {
 }
 
 func TestIntegrationGetVersion(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -93,10 +109,7 @@ func TestIntegrationGetVersion(t *testing.T) {
 }
 
 func TestIntegrationGetAllVersions(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -117,10 +130,7 @@ func TestIntegrationGetAllVersions(t *testing.T) {
 }
 
 func TestIntegrationLookupPURL(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -145,10 +155,7 @@ func TestIntegrationLookupPURL(t *testing.T) {
 }
 
 func TestIntegrationGetVersionPURL(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -173,10 +180,7 @@ func TestIntegrationGetVersionPURL(t *testing.T) {
 }
 
 func TestIntegrationListRegistries(t *testing.T) {
-	client, err := NewClient("ecosystems-go-test/1.0")
-	if err != nil {
-		t.Fatalf("NewClient() error = %v", err)
-	}
+	client := newIntegrationClient(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -202,3 +206,14 @@ func TestIntegrationListRegistries(t *testing.T) {
 		t.Error("ListRegistries() missing rubygems.org")
 	}
 }
+
+func TestIntegrationVerifyRegistryMappings(t *testing.T) {
+	client := newIntegrationClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.VerifyRegistryMappings(ctx); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,83 @@
+package ecosystems
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// ClientInterface is the set of high-level operations provided by Client.
+// Code that depends on ClientInterface rather than *Client can be tested
+// with the mock package instead of making HTTP requests.
+type ClientInterface interface {
+	BulkLookup(ctx context.Context, purls []string, opts ...CallOption) (map[string]*packages.PackageWithRegistry, error)
+	BulkLookupDetailed(ctx context.Context, purls []string, opts ...CallOption) (*BulkLookupResult, error)
+	BulkLookupStream(ctx context.Context, purls []string, fn func(purl string, pkg *packages.PackageWithRegistry) error, opts ...CallOption) error
+	Lookup(ctx context.Context, purl string, opts ...CallOption) (*packages.PackageWithRegistry, error)
+	LookupByRegistryAndName(ctx context.Context, registry, name string, opts ...CallOption) (*packages.Package, error)
+	GetVersion(ctx context.Context, registry, name, version string, opts ...CallOption) (*packages.VersionWithDependencies, error)
+	BulkGetVersions(ctx context.Context, purls []string, opts ...CallOption) (map[string]*packages.VersionWithDependencies, error)
+	GetAllVersions(ctx context.Context, registry, name string, opts ...CallOption) ([]packages.Version, error)
+	GetVersionNumbers(ctx context.Context, registry, name string, opts ...CallOption) ([]string, error)
+	GetVersionsPage(ctx context.Context, registry, name string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Version], error)
+	GetRepository(ctx context.Context, url string, opts ...CallOption) (*repos.Repository, error)
+	GetRepositoryForPackage(ctx context.Context, purl string, opts ...CallOption) (*repos.Repository, error)
+	BulkGetRepositories(ctx context.Context, urls []string, opts ...CallOption) (*BulkRepositoriesResult, error)
+	GetRepositoryByHostAndName(ctx context.Context, host, fullName string, opts ...CallOption) (*repos.Repository, error)
+	SyncRepository(ctx context.Context, url string, opts ...CallOption) (bool, error)
+	SyncPackage(ctx context.Context, registry, name string, opts ...CallOption) (bool, error)
+	Ping(ctx context.Context, opts ...CallOption) ([]ServiceStatus, error)
+	ListRegistries(ctx context.Context, opts ...CallOption) ([]packages.Registry, error)
+	ListRegistriesByEcosystem(ctx context.Context, ecosystem string, opts ...CallOption) ([]packages.Registry, error)
+	GetRegistry(ctx context.Context, name string, opts ...CallOption) (*packages.Registry, error)
+	RefreshRegistries(ctx context.Context, opts ...CallOption) error
+	VerifyRegistryMappings(ctx context.Context, opts ...CallOption) error
+	ListCriticalPackages(ctx context.Context, registry string, opts ListOptions, callOpts ...CallOption) (*Page[packages.PackageWithRegistry], error)
+	ListRegistryPackages(ctx context.Context, registry string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Package], error)
+	ListTopPackages(ctx context.Context, registry, by string, limit int, callOpts ...CallOption) ([]packages.Package, error)
+	ListKeywords(ctx context.Context, opts ListOptions, callOpts ...CallOption) (*Page[packages.Keyword], error)
+	GetPackagesByKeyword(ctx context.Context, ecosystem, keyword string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Package], error)
+	GetRegistryPackageNames(ctx context.Context, registry string, opts ...CallOption) iter.Seq2[string, error]
+	GetRecentlyUpdatedPackages(ctx context.Context, registry string, since time.Time, opts ...CallOption) iter.Seq2[packages.Package, error]
+	ListTopics(ctx context.Context, opts ListOptions, callOpts ...CallOption) (*Page[repos.Topic], error)
+	GetRepositoriesByTopic(ctx context.Context, host, topic string, opts ListOptions, callOpts ...CallOption) ([]repos.Repository, error)
+	GetOwner(ctx context.Context, host, login string, opts ...CallOption) (*repos.Owner, error)
+	HostRepositoriesIter(ctx context.Context, host string, opts ...CallOption) iter.Seq2[repos.Repository, error]
+	GetDependentRepositories(ctx context.Context, registry, name string, opts ...CallOption) iter.Seq2[repos.Repository, error]
+	ListOwnerRepositories(ctx context.Context, host, login string, opts ...CallOption) ([]repos.Repository, error)
+	LookupPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*packages.Package, error)
+	LookupPURLSubpath(ctx context.Context, purl packageurl.PackageURL, opts SubpathOptions, callOpts ...CallOption) (*SubpathPackage, error)
+	GetVersionPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*packages.VersionWithDependencies, error)
+	GetAllVersionsPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) ([]packages.Version, error)
+	GetVersionNumbersPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) ([]string, error)
+	LookupRepositoryPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*repos.Repository, error)
+	GetVersionsMatching(ctx context.Context, purl packageurl.PackageURL, constraint string, opts ...CallOption) ([]packages.Version, error)
+	GetLatestVersion(ctx context.Context, purl packageurl.PackageURL, opts LatestVersionOptions, callOpts ...CallOption) (string, error)
+	WatchPackage(ctx context.Context, purl string, interval time.Duration, fn func(newVersion packages.Version), opts ...CallOption) error
+	DetectMaintainerChanges(ctx context.Context, registry, name string, opts ...CallOption) ([]MaintainerChange, error)
+	LicenseReport(ctx context.Context, purls []string, opts ...CallOption) (*LicenseReport, error)
+	VulnerabilityReport(ctx context.Context, purls []string, opts ...CallOption) (*VulnerabilityReport, error)
+	DiffLockfiles(ctx context.Context, oldPath, newPath string, opts ...CallOption) (*LockfileDiff, error)
+	GetFundingForPURL(ctx context.Context, purl string, opts ...CallOption) (*Funding, error)
+	GetCollectiveForProject(ctx context.Context, projectURL string, opts ...CallOption) (*Collective, error)
+	FindSimilarPackages(ctx context.Context, registry, name string, maxDistance int, opts ...CallOption) ([]SimilarPackage, error)
+	FindPackagesByRepository(ctx context.Context, repoURL string, opts ...CallOption) ([]packages.PackageWithRegistry, error)
+	FindPackageEverywhere(ctx context.Context, name string, opts ...CallOption) ([]PackageFamily, error)
+	GetRepositoryFiles(ctx context.Context, repoURL string, opts ...CallOption) (RepositoryFiles, error)
+	GetRepositoryFile(ctx context.Context, repoURL, path string, opts ...CallOption) (*RepositoryFile, error)
+	OutdatedReport(ctx context.Context, pinned []packageurl.PackageURL, opts ...CallOption) (*OutdatedReport, error)
+	GetPackageStats(ctx context.Context, purl string, opts ...CallOption) (*PackageStats, error)
+	NormalizePopularity(ctx context.Context, purls []string, opts ...CallOption) (map[string]*Popularity, error)
+	ReleaseCadence(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*ReleaseCadence, error)
+	Prefetch(ctx context.Context, purls []string, opts ...CallOption) <-chan PrefetchResult
+	LookupDelta(ctx context.Context, purls []string, previous *Snapshot, maxAge time.Duration, opts ...CallOption) (*Snapshot, error)
+	ParsePURL(s string) (packageurl.PackageURL, error)
+	FormatPURL(purl packageurl.PackageURL) string
+	ResolveRegistry(purl packageurl.PackageURL) (registry, reason string)
+}
+
+var _ ClientInterface = (*Client)(nil)
@@ -0,0 +1,102 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// ListKeywords returns a page of package keywords, most used first.
+// Keywords are shared across all registries.
+func (c *Client) ListKeywords(ctx context.Context, opts ListOptions, callOpts ...CallOption) (*Page[packages.Keyword], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "ListKeywords", "")
+	opts = c.listOptions(call, opts)
+	resp, err := c.packagesAPI().GetKeywordsWithResponse(ctx, &packages.GetKeywordsParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+	}, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("list keywords: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("list keywords", resp.HTTPResponse, resp.Body)
+	}
+
+	var items []packages.Keyword
+	if resp.JSON200 != nil {
+		items = *resp.JSON200
+	}
+	return newPage(items, resp.HTTPResponse, opts), nil
+}
+
+// GetPackagesByKeyword returns a page of the packages tagged with keyword,
+// or nil if no package uses it. The API serves keywords across all
+// registries and cannot filter them, so when ecosystem is set (for example
+// "cargo" or "npm") packages from other ecosystems are dropped and further
+// API pages are fetched until the page holds at least opts.PerPage matches
+// or the listing ends. Such a page may then span several API pages: its
+// NextPage is the API page to pass as opts.Page to continue, and its
+// TotalCount and TotalPages are zero because the API only reports totals
+// for the unfiltered listing.
+func (c *Client) GetPackagesByKeyword(ctx context.Context, ecosystem, keyword string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Package], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "GetPackagesByKeyword", ecosystem)
+	opts = c.listOptions(call, opts)
+	if ecosystem == "" {
+		return c.packagesByKeywordPage(ctx, call, keyword, opts)
+	}
+
+	var result *Page[packages.Package]
+	for {
+		page, err := c.packagesByKeywordPage(ctx, call, keyword, opts)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return result, nil
+		}
+		if result == nil {
+			result = &Page[packages.Package]{Items: []packages.Package{}, Page: page.Page, PerPage: opts.PerPage}
+		}
+		for _, pkg := range page.Items {
+			if pkg.Ecosystem == ecosystem {
+				result.Items = append(result.Items, pkg)
+			}
+		}
+		result.NextPage = page.NextPage
+		if page.NextPage == 0 || len(page.Items) == 0 || len(result.Items) >= opts.PerPage {
+			return result, nil
+		}
+		opts.Page = page.NextPage
+	}
+}
+
+// packagesByKeywordPage fetches one unfiltered API page of the packages
+// tagged with keyword, or nil if no package uses it.
+func (c *Client) packagesByKeywordPage(ctx context.Context, call *callConfig, keyword string, opts ListOptions) (*Page[packages.Package], error) {
+	resp, err := c.packagesAPI().GetKeywordWithResponse(ctx, keyword, &packages.GetKeywordParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+	}, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get packages by keyword: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get packages by keyword", resp.HTTPResponse, resp.Body)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+
+	return newPage(resp.JSON200.Packages, resp.HTTPResponse, opts), nil
+}
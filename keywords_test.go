@@ -0,0 +1,111 @@
+package ecosystems
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestListKeywords(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	page, err := client.ListKeywords(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("ListKeywords() error = %v", err)
+	}
+	keywords := page.Items
+	if len(keywords) == 0 {
+		t.Fatal("ListKeywords() returned no keywords")
+	}
+	if keywords[0].Name != "javascript" || keywords[0].PackagesCount != 2 {
+		t.Errorf("ListKeywords()[0] = %q (%d), want %q (2)", keywords[0].Name, keywords[0].PackagesCount, "javascript")
+	}
+
+	page, err = client.ListKeywords(context.Background(), ListOptions{Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatalf("ListKeywords() page 2 error = %v", err)
+	}
+	if len(page.Items) != 2 || page.Items[0].Name == keywords[0].Name {
+		t.Errorf("ListKeywords() page 2 = %v", page.Items)
+	}
+}
+
+func TestGetPackagesByKeyword(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		ecosystem string
+		keyword   string
+		want      int
+	}{
+		{"", "javascript", 2},
+		{"npm", "javascript", 2},
+		{"cargo", "javascript", 0},
+		{"", "web", 1},
+		{"", "missing", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ecosystem+"/"+tt.keyword, func(t *testing.T) {
+			page, err := client.GetPackagesByKeyword(context.Background(), tt.ecosystem, tt.keyword, ListOptions{})
+			if err != nil {
+				t.Fatalf("GetPackagesByKeyword() error = %v", err)
+			}
+			var got int
+			if page != nil {
+				got = len(page.Items)
+			}
+			if got != tt.want {
+				t.Errorf("GetPackagesByKeyword() returned %d packages, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPackagesByKeywordFillsFilteredPages(t *testing.T) {
+	client, srv := newTestClient(t)
+	// Sorted by name the javascript listing is @babel/core, execjs, lodash,
+	// uglifier, alternating npm and rubygems.
+	for _, name := range []string{"execjs", "uglifier"} {
+		srv.AddPackage("rubygems.org", packages.PackageWithRegistry{
+			Name:          name,
+			Ecosystem:     "rubygems",
+			KeywordsArray: []string{"javascript"},
+		})
+	}
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		opts      ListOptions
+		want      []string
+		nextPage  int
+	}{
+		{"unfiltered", "", ListOptions{PerPage: 2}, []string{"@babel/core", "execjs"}, 2},
+		{"skips to first match", "rubygems", ListOptions{PerPage: 1}, []string{"execjs"}, 3},
+		{"continues from next page", "rubygems", ListOptions{Page: 3, PerPage: 1}, []string{"uglifier"}, 0},
+		{"fills across pages", "npm", ListOptions{PerPage: 2}, []string{"@babel/core", "lodash"}, 0},
+		{"no matches", "cargo", ListOptions{PerPage: 2}, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := client.GetPackagesByKeyword(context.Background(), tt.ecosystem, "javascript", tt.opts)
+			if err != nil {
+				t.Fatalf("GetPackagesByKeyword() error = %v", err)
+			}
+			names := []string{}
+			for _, pkg := range page.Items {
+				names = append(names, pkg.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("GetPackagesByKeyword() = %v, want %v", names, tt.want)
+			}
+			if page.NextPage != tt.nextPage {
+				t.Errorf("NextPage = %d, want %d", page.NextPage, tt.nextPage)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package ecosystems
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// LicenseReport summarizes the licenses declared by a set of packages.
+type LicenseReport struct {
+	// Counts maps each SPDX license ID to the number of packages declaring it.
+	Counts map[string]int
+	// Licenses maps each found PURL to its SPDX license IDs.
+	Licenses map[string][]string
+	// Unknown lists found PURLs with no recognized license, sorted.
+	Unknown []string
+	// Copyleft lists PURLs with at least one copyleft license, sorted.
+	Copyleft []string
+	// Missing lists PURLs the API did not recognize, in request order.
+	Missing []string
+}
+
+// LicenseReport looks up packages by PURL and summarizes their declared
+// licenses, using the SPDX IDs ecosyste.ms normalizes from package
+// metadata. A package declaring several licenses is counted once under
+// each.
+func (c *Client) LicenseReport(ctx context.Context, purls []string, opts ...CallOption) (*LicenseReport, error) {
+	result, err := c.BulkLookupDetailed(ctx, purls, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LicenseReport{
+		Counts:   make(map[string]int),
+		Licenses: make(map[string][]string, len(result.Packages)),
+		Missing:  result.Missing,
+	}
+	for purl, pkg := range result.Packages {
+		var ids []string
+		for _, id := range pkg.NormalizedLicenses {
+			if id != "" && !unknownLicenses[strings.ToUpper(id)] && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		report.Licenses[purl] = ids
+		if len(ids) == 0 {
+			report.Unknown = append(report.Unknown, purl)
+			continue
+		}
+		for _, id := range ids {
+			report.Counts[id]++
+		}
+		if slices.ContainsFunc(ids, IsCopyleft) {
+			report.Copyleft = append(report.Copyleft, purl)
+		}
+	}
+	slices.Sort(report.Unknown)
+	slices.Sort(report.Copyleft)
+	return report, nil
+}
+
+// unknownLicenses are placeholder values that do not name a license.
+var unknownLicenses = map[string]bool{
+	"NOASSERTION": true,
+	"NONE":        true,
+	"OTHER":       true,
+	"UNKNOWN":     true,
+}
+
+// copyleftPrefixes are the SPDX ID prefixes of copyleft license families,
+// both strong (GPL, AGPL) and weak (LGPL, MPL, EPL).
+var copyleftPrefixes = []string{
+	"AGPL-", "CC-BY-SA-", "CDDL-", "CPL-", "EPL-", "EUPL-", "GPL-",
+	"LGPL-", "MPL-", "OSL-", "SSPL-",
+}
+
+// IsCopyleft reports whether an SPDX license ID belongs to a copyleft
+// license family, such as GPL-3.0-only or MPL-2.0.
+func IsCopyleft(id string) bool {
+	id = strings.ToUpper(id)
+	for _, prefix := range copyleftPrefixes {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestLicenseReport(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddPackage("npmjs.org", packages.PackageWithRegistry{
+		Name: "readline-gpl", Purl: "pkg:npm/readline-gpl", NormalizedLicenses: []string{"GPL-3.0-only", "MIT"},
+	})
+	srv.AddPackage("npmjs.org", packages.PackageWithRegistry{
+		Name: "mystery", Purl: "pkg:npm/mystery", NormalizedLicenses: []string{"NOASSERTION"},
+	})
+
+	report, err := client.LicenseReport(context.Background(), []string{
+		"pkg:gem/rails", "pkg:npm/lodash", "pkg:npm/readline-gpl", "pkg:npm/mystery", "pkg:npm/nope",
+	})
+	if err != nil {
+		t.Fatalf("LicenseReport() error = %v", err)
+	}
+
+	wantCounts := map[string]int{"MIT": 3, "GPL-3.0-only": 1}
+	if !reflect.DeepEqual(report.Counts, wantCounts) {
+		t.Errorf("Counts = %v, want %v", report.Counts, wantCounts)
+	}
+	if want := []string{"pkg:npm/mystery"}; !reflect.DeepEqual(report.Unknown, want) {
+		t.Errorf("Unknown = %v, want %v", report.Unknown, want)
+	}
+	if want := []string{"pkg:npm/readline-gpl"}; !reflect.DeepEqual(report.Copyleft, want) {
+		t.Errorf("Copyleft = %v, want %v", report.Copyleft, want)
+	}
+	if want := []string{"pkg:npm/nope"}; !reflect.DeepEqual(report.Missing, want) {
+		t.Errorf("Missing = %v, want %v", report.Missing, want)
+	}
+}
+
+func TestIsCopyleft(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"GPL-3.0-only", true},
+		{"AGPL-3.0-or-later", true},
+		{"LGPL-2.1", true},
+		{"MPL-2.0", true},
+		{"mpl-2.0", true},
+		{"MIT", false},
+		{"Apache-2.0", false},
+		{"BSD-3-Clause", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCopyleft(tt.id); got != tt.want {
+			t.Errorf("IsCopyleft(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
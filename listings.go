@@ -0,0 +1,118 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// Sort fields accepted by package listings.
+const (
+	SortByDownloads         = "downloads"
+	SortByDependentPackages = "dependent_packages_count"
+	SortByDependentRepos    = "dependent_repos_count"
+)
+
+// ListOptions selects one page of a listing endpoint. Zero values use the
+// API defaults, except for PerPage.
+type ListOptions struct {
+	Page int
+	// PerPage is the page size. Zero uses CallPageSize, WithDefaultPageSize
+	// or DefaultPageSize, in that order; sizes above MaxPageSize are capped.
+	PerPage int
+	// Sort is the field to sort by, such as SortByDownloads.
+	Sort string
+	// Order is "asc" or "desc".
+	Order string
+}
+
+// intParam returns a pointer to n, or nil when n is zero.
+func intParam(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// stringParam returns a pointer to s, or nil when s is empty.
+func stringParam(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ListCriticalPackages returns a page of packages ecosyste.ms marks as
+// critical, in the given registry or across all registries when registry
+// is empty.
+func (c *Client) ListCriticalPackages(ctx context.Context, registry string, opts ListOptions, callOpts ...CallOption) (*Page[packages.PackageWithRegistry], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "ListCriticalPackages", registry)
+	opts = c.listOptions(call, opts)
+	resp, err := c.packagesAPI().GetCriticalPackagesWithResponse(ctx, &packages.GetCriticalPackagesParams{
+		Registry: stringParam(registry),
+		Page:     intParam(opts.Page),
+		PerPage:  intParam(opts.PerPage),
+		Sort:     stringParam(opts.Sort),
+		Order:    stringParam(opts.Order),
+	}, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("list critical packages: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("list critical packages", resp.HTTPResponse, resp.Body)
+	}
+
+	var items []packages.PackageWithRegistry
+	if resp.JSON200 != nil {
+		items = *resp.JSON200
+	}
+	return newPage(items, resp.HTTPResponse, opts), nil
+}
+
+// ListRegistryPackages returns a page of the packages in a registry. Set
+// opts.Sort to SortByDownloads, SortByDependentPackages or
+// SortByDependentRepos with Order "desc" to list the most used packages.
+// It returns nil if the registry is not known.
+func (c *Client) ListRegistryPackages(ctx context.Context, registry string, opts ListOptions, callOpts ...CallOption) (*Page[packages.Package], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "ListRegistryPackages", registry)
+	opts = c.listOptions(call, opts)
+	resp, err := c.packagesAPI().GetRegistryPackagesWithResponse(ctx, registry, &packages.GetRegistryPackagesParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+		Sort:    stringParam(opts.Sort),
+		Order:   stringParam(opts.Order),
+	}, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("list registry packages: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("list registry packages", resp.HTTPResponse, resp.Body)
+	}
+
+	var items []packages.Package
+	if resp.JSON200 != nil {
+		items = *resp.JSON200
+	}
+	return newPage(items, resp.HTTPResponse, opts), nil
+}
+
+// ListTopPackages returns the limit most used packages in a registry,
+// ranked by the given sort field such as SortByDownloads. The limit is one
+// page, so it is capped at MaxPageSize; zero means the default page size.
+func (c *Client) ListTopPackages(ctx context.Context, registry, by string, limit int, callOpts ...CallOption) ([]packages.Package, error) {
+	page, err := c.ListRegistryPackages(ctx, registry, ListOptions{PerPage: limit, Sort: by, Order: "desc"}, callOpts...)
+	if err != nil || page == nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
@@ -0,0 +1,133 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListCriticalPackages(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		registry string
+		want     []string
+	}{
+		{"", []string{"lodash", "rails"}},
+		{"npmjs.org", []string{"lodash"}},
+		{"pypi.org", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			page, err := client.ListCriticalPackages(context.Background(), tt.registry, ListOptions{})
+			if err != nil {
+				t.Fatalf("ListCriticalPackages() error = %v", err)
+			}
+			var got []string
+			for _, p := range page.Items {
+				got = append(got, p.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListCriticalPackages() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ListCriticalPackages()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestListRegistryPackagesSorted(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	page, err := client.ListRegistryPackages(context.Background(), "npmjs.org", ListOptions{Sort: SortByDownloads, Order: "asc"})
+	if err != nil {
+		t.Fatalf("ListRegistryPackages() error = %v", err)
+	}
+	pkgs := page.Items
+	if len(pkgs) != 2 {
+		t.Fatalf("ListRegistryPackages() returned %d packages, want 2", len(pkgs))
+	}
+	if pkgs[0].Name != "@babel/core" || pkgs[1].Name != "lodash" {
+		t.Errorf("ListRegistryPackages() = [%q %q], want [\"@babel/core\" \"lodash\"]", pkgs[0].Name, pkgs[1].Name)
+	}
+}
+
+func TestListTopPackages(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	pkgs, err := client.ListTopPackages(context.Background(), "npmjs.org", SortByDownloads, 1)
+	if err != nil {
+		t.Fatalf("ListTopPackages() error = %v", err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Name != "lodash" {
+		t.Errorf("ListTopPackages() = %v, want [lodash]", pkgs)
+	}
+}
+
+func TestListingPageSize(t *testing.T) {
+	var perPage string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("per_page")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/keywords/") {
+			_, _ = w.Write([]byte(`{"packages": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		clientOpts []Option
+		call       func(c *Client) error
+		want       string
+	}{
+		{"default", nil, func(c *Client) error {
+			_, err := c.ListRegistryPackages(ctx, "npmjs.org", ListOptions{})
+			return err
+		}, "100"},
+		{"client default", []Option{WithDefaultPageSize(25)}, func(c *Client) error {
+			_, err := c.ListKeywords(ctx, ListOptions{})
+			return err
+		}, "25"},
+		{"call page size", []Option{WithDefaultPageSize(25)}, func(c *Client) error {
+			_, err := c.ListCriticalPackages(ctx, "", ListOptions{}, CallPageSize(10))
+			return err
+		}, "10"},
+		{"explicit wins", nil, func(c *Client) error {
+			_, err := c.GetVersionsPage(ctx, "npmjs.org", "lodash", ListOptions{PerPage: 5}, CallPageSize(10))
+			return err
+		}, "5"},
+		{"capped", nil, func(c *Client) error {
+			_, err := c.GetPackagesByKeyword(ctx, "", "cli", ListOptions{PerPage: 5000})
+			return err
+		}, "1000"},
+		{"top packages capped", nil, func(c *Client) error {
+			_, err := c.ListTopPackages(ctx, "npmjs.org", SortByDownloads, 5000)
+			return err
+		}, "1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-agent/1.0", append(tt.clientOpts, WithPackagesServer(srv.URL))...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if err := tt.call(client); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if perPage != tt.want {
+				t.Errorf("per_page = %q, want %q", perPage, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package ecosystems
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/versions"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// LockfileDiff lists the dependency changes between two lockfiles, each
+// sorted by PURL.
+type LockfileDiff struct {
+	Added      []DependencyChange
+	Removed    []DependencyChange
+	Upgraded   []DependencyChange
+	Downgraded []DependencyChange
+}
+
+// DependencyChange is one package whose pinned version was added, removed
+// or changed.
+type DependencyChange struct {
+	// PURL identifies the package, without a version.
+	PURL string
+	// From is "" for added dependencies and To is "" for removed ones.
+	From string
+	To   string
+	// PublishedAt is when To was released, or zero when unknown.
+	PublishedAt time.Time
+	// Advisories affect To. ResolvedAdvisories affected From but not To.
+	Advisories         []Vulnerability
+	ResolvedAdvisories []Vulnerability
+	// MaintainerChanges lists the releases after From, up to and including
+	// To, that changed the package's publishers or maintainers. It is only
+	// set for upgrades.
+	MaintainerChanges []MaintainerChange
+}
+
+// DiffLockfiles compares the dependencies pinned by two lockfiles, read
+// with ReadLockfile, for reviewing dependency updates. Each change is
+// enriched with the release date of the new version, the advisories it
+// adds or resolves and, for upgrades, maintainer changes in between.
+// A package pinned at several versions is reported as upgraded or
+// downgraded only when exactly one version was replaced by another;
+// otherwise its versions are listed as added and removed.
+func (c *Client) DiffLockfiles(ctx context.Context, oldPath, newPath string, opts ...CallOption) (*LockfileDiff, error) {
+	before, err := ReadLockfile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ReadLockfile(newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := c.diffDependencies(before, after)
+	if err := c.enrichChanges(ctx, diff, opts...); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// diffDependencies compares the versions pinned for each package.
+func (c *Client) diffDependencies(before, after []packageurl.PackageURL) *LockfileDiff {
+	type pinned struct {
+		purlType string
+		versions map[string]int // version -> 1 before, 2 after, 3 both
+	}
+	packages := make(map[string]*pinned)
+	collect := func(purls []packageurl.PackageURL, side int) {
+		for _, p := range purls {
+			version := p.Version
+			p.Version = ""
+			key := c.FormatPURL(p)
+			if packages[key] == nil {
+				packages[key] = &pinned{purlType: p.Type, versions: make(map[string]int)}
+			}
+			packages[key].versions[version] |= side
+		}
+	}
+	collect(before, 1)
+	collect(after, 2)
+
+	diff := &LockfileDiff{}
+	for key, pkg := range packages {
+		var removed, added []string
+		for version, side := range pkg.versions {
+			switch side {
+			case 1:
+				removed = append(removed, version)
+			case 2:
+				added = append(added, version)
+			}
+		}
+		if len(removed) == 1 && len(added) == 1 {
+			change := DependencyChange{PURL: key, From: removed[0], To: added[0]}
+			if versions.Compare(pkg.purlType, change.From, change.To) < 0 {
+				diff.Upgraded = append(diff.Upgraded, change)
+			} else {
+				diff.Downgraded = append(diff.Downgraded, change)
+			}
+			continue
+		}
+		for _, v := range added {
+			diff.Added = append(diff.Added, DependencyChange{PURL: key, To: v})
+		}
+		for _, v := range removed {
+			diff.Removed = append(diff.Removed, DependencyChange{PURL: key, From: v})
+		}
+	}
+
+	for _, changes := range []*[]DependencyChange{&diff.Added, &diff.Removed, &diff.Upgraded, &diff.Downgraded} {
+		slices.SortFunc(*changes, func(a, b DependencyChange) int {
+			return cmp.Or(cmp.Compare(a.PURL, b.PURL), cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+		})
+	}
+	return diff
+}
+
+// enrichChanges adds release dates, advisories and maintainer changes.
+func (c *Client) enrichChanges(ctx context.Context, diff *LockfileDiff, opts ...CallOption) error {
+	var all []*DependencyChange
+	for _, changes := range [][]DependencyChange{diff.Added, diff.Removed, diff.Upgraded, diff.Downgraded} {
+		for i := range changes {
+			all = append(all, &changes[i])
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+
+	var fromPURLs, toPURLs []string
+	for _, ch := range all {
+		if ch.From != "" {
+			fromPURLs = append(fromPURLs, c.versionedPURL(ch.PURL, ch.From))
+		}
+		if ch.To != "" {
+			toPURLs = append(toPURLs, c.versionedPURL(ch.PURL, ch.To))
+		}
+	}
+
+	released, err := c.BulkGetVersions(ctx, toPURLs, opts...)
+	if err != nil {
+		return err
+	}
+	report, err := c.VulnerabilityReport(ctx, append(fromPURLs, toPURLs...), opts...)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]Vulnerability, len(report.Vulnerabilities))
+	for _, v := range report.Vulnerabilities {
+		byID[v.ID] = v
+	}
+
+	for _, ch := range all {
+		var fromIDs []string
+		if ch.From != "" {
+			fromIDs = report.Affected[c.versionedPURL(ch.PURL, ch.From)]
+		}
+		if ch.To == "" {
+			continue
+		}
+		to := c.versionedPURL(ch.PURL, ch.To)
+		if v := released[to]; v != nil {
+			ch.PublishedAt, _ = parseTimestamp(v.PublishedAt)
+		}
+		toIDs := report.Affected[to]
+		for _, id := range toIDs {
+			ch.Advisories = append(ch.Advisories, byID[id])
+		}
+		for _, id := range fromIDs {
+			if !slices.Contains(toIDs, id) {
+				ch.ResolvedAdvisories = append(ch.ResolvedAdvisories, byID[id])
+			}
+		}
+	}
+
+	upgraded := make([]*DependencyChange, len(diff.Upgraded))
+	for i := range diff.Upgraded {
+		upgraded[i] = &diff.Upgraded[i]
+	}
+	return forEach(ctx, upgraded, maxRepositoryWorkers, func(ctx context.Context, ch *DependencyChange) error {
+		p, err := c.ParsePURL(ch.PURL)
+		if err != nil {
+			return err
+		}
+		registry := c.registryFor(p)
+		if registry == "" {
+			return nil
+		}
+		changes, err := c.DetectMaintainerChanges(ctx, registry, PURLToName(p), opts...)
+		if err != nil {
+			return err
+		}
+		for _, mc := range changes {
+			if versions.Compare(p.Type, mc.Version, ch.From) > 0 && versions.Compare(p.Type, mc.Version, ch.To) <= 0 {
+				ch.MaintainerChanges = append(ch.MaintainerChanges, mc)
+			}
+		}
+		return nil
+	})
+}
+
+// versionedPURL adds a version to a package PURL formatted by FormatPURL.
+func (c *Client) versionedPURL(purl, version string) string {
+	p, err := c.ParsePURL(purl)
+	if err != nil {
+		return purl
+	}
+	p.Version = version
+	return c.FormatPURL(p)
+}
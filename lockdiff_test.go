@@ -0,0 +1,90 @@
+package ecosystems
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeLockfile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffLockfiles(t *testing.T) {
+	client, _ := newTestClient(t)
+	dir := t.TempDir()
+	oldPath := writeLockfile(t, filepath.Join(dir, "old"), "package-lock.json", `{"packages": {
+		"node_modules/lodash": {"version": "4.17.20"},
+		"node_modules/left-pad": {"version": "1.3.0"},
+		"node_modules/semver": {"version": "7.6.0"}
+	}}`)
+	newPath := writeLockfile(t, filepath.Join(dir, "new"), "package-lock.json", `{"packages": {
+		"node_modules/lodash": {"version": "4.17.21"},
+		"node_modules/@babel/core": {"version": "7.24.0"},
+		"node_modules/semver": {"version": "7.5.4"}
+	}}`)
+
+	diff, err := client.DiffLockfiles(context.Background(), oldPath, newPath)
+	if err != nil {
+		t.Fatalf("DiffLockfiles() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		changes []DependencyChange
+		want    []string
+	}{
+		{"added", diff.Added, []string{"pkg:npm/%40babel/core  -> 7.24.0"}},
+		{"removed", diff.Removed, []string{"pkg:npm/left-pad 1.3.0 -> "}},
+		{"upgraded", diff.Upgraded, []string{"pkg:npm/lodash 4.17.20 -> 4.17.21"}},
+		{"downgraded", diff.Downgraded, []string{"pkg:npm/semver 7.6.0 -> 7.5.4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ch := range tt.changes {
+				got = append(got, ch.PURL+" "+ch.From+" -> "+ch.To)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	lodash := diff.Upgraded[0]
+	if want := time.Date(2021, 2, 20, 15, 42, 16, 0, time.UTC); !lodash.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", lodash.PublishedAt, want)
+	}
+	if len(lodash.Advisories) != 0 {
+		t.Errorf("Advisories = %v, want none", lodash.Advisories)
+	}
+	if len(lodash.ResolvedAdvisories) != 1 || lodash.ResolvedAdvisories[0].Title != "Command Injection in lodash" {
+		t.Errorf("ResolvedAdvisories = %v, want the command injection advisory", lodash.ResolvedAdvisories)
+	}
+}
+
+func TestDiffLockfilesMultipleVersions(t *testing.T) {
+	client := &Client{purlParser: PackageURLParser{}}
+	before, _ := ParseLockfile("package-lock.json", []byte(`{"packages": {
+		"node_modules/ms": {"version": "2.0.0"},
+		"node_modules/debug/node_modules/ms": {"version": "2.1.2"}
+	}}`))
+	after, _ := ParseLockfile("package-lock.json", []byte(`{"packages": {
+		"node_modules/ms": {"version": "2.1.3"}
+	}}`))
+
+	diff := client.diffDependencies(before, after)
+	if len(diff.Upgraded) != 0 || len(diff.Added) != 1 || len(diff.Removed) != 2 {
+		t.Errorf("diffDependencies() = %+v, want 1 added and 2 removed", diff)
+	}
+}
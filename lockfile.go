@@ -0,0 +1,196 @@
+package ecosystems
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// ReadLockfile reads the pinned dependencies of a lockfile as versioned
+// PURLs. The format is chosen by file name: package-lock.json or
+// npm-shrinkwrap.json, Gemfile.lock, Cargo.lock or go.sum.
+func ReadLockfile(path string) ([]packageurl.PackageURL, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	purls, err := ParseLockfile(filepath.Base(path), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return purls, nil
+}
+
+// ParseLockfile parses lockfile data as ReadLockfile does, choosing the
+// format by name. Each package version is returned once.
+func ParseLockfile(name string, data []byte) ([]packageurl.PackageURL, error) {
+	var purls []packageurl.PackageURL
+	seen := make(map[string]bool)
+	add := func(p packageurl.PackageURL) {
+		if p.Name == "" || p.Version == "" {
+			return
+		}
+		if key := p.ToString(); !seen[key] {
+			seen[key] = true
+			purls = append(purls, p)
+		}
+	}
+
+	var err error
+	switch name {
+	case "package-lock.json", "npm-shrinkwrap.json":
+		err = parseNPMLockfile(data, add)
+	case "Gemfile.lock":
+		parseGemfileLock(data, add)
+	case "Cargo.lock":
+		parseCargoLock(data, add)
+	case "go.sum":
+		parseGoSum(data, add)
+	default:
+		return nil, fmt.Errorf("unsupported lockfile %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return purls, nil
+}
+
+// npmPURL returns the PURL of an npm package such as @babel/core.
+func npmPURL(name, version string) packageurl.PackageURL {
+	namespace, name := NameToPURLParts(packageurl.TypeNPM, name)
+	return packageurl.PackageURL{Type: packageurl.TypeNPM, Namespace: namespace, Name: name, Version: version}
+}
+
+// parseNPMLockfile reads the packages map of lockfile versions 2 and 3, or
+// the nested dependencies of version 1.
+func parseNPMLockfile(data []byte, add func(packageurl.PackageURL)) error {
+	type v1Dependency struct {
+		Version      string          `json:"version"`
+		Dependencies json.RawMessage `json:"dependencies"`
+	}
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return fmt.Errorf("parsing package-lock.json: %w", err)
+	}
+
+	if lock.Packages != nil {
+		for _, path := range slices.Sorted(maps.Keys(lock.Packages)) {
+			pkg := lock.Packages[path]
+			i := strings.LastIndex(path, "node_modules/")
+			if i < 0 || pkg.Link {
+				continue
+			}
+			add(npmPURL(path[i+len("node_modules/"):], pkg.Version))
+		}
+		return nil
+	}
+
+	var walk func(raw json.RawMessage) error
+	walk = func(raw json.RawMessage) error {
+		if len(raw) == 0 {
+			return nil
+		}
+		var deps map[string]v1Dependency
+		if err := json.Unmarshal(raw, &deps); err != nil {
+			return fmt.Errorf("parsing package-lock.json: %w", err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			add(npmPURL(name, deps[name].Version))
+			if err := walk(deps[name].Dependencies); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(lock.Dependencies)
+}
+
+// parseGemfileLock reads the gems listed under specs in the GEM section.
+// Platform suffixes such as -x86_64-linux are dropped from versions.
+func parseGemfileLock(data []byte, add func(packageurl.PackageURL)) {
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = line
+			continue
+		}
+		// Gems are indented four spaces; their dependencies six.
+		if section != "GEM" || !strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "     ") {
+			continue
+		}
+		name, version, ok := strings.Cut(strings.TrimSpace(line), " (")
+		if !ok {
+			continue
+		}
+		version = strings.TrimSuffix(version, ")")
+		version, _, _ = strings.Cut(version, "-")
+		add(packageurl.PackageURL{Type: packageurl.TypeGem, Name: name, Version: version})
+	}
+}
+
+// parseCargoLock reads [[package]] entries with a source, skipping the
+// workspace's own crates.
+func parseCargoLock(data []byte, add func(packageurl.PackageURL)) {
+	var name, version, source string
+	flush := func() {
+		if source != "" {
+			add(packageurl.PackageURL{Type: packageurl.TypeCargo, Name: name, Version: version})
+		}
+		name, version, source = "", "", ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = value
+		case "version":
+			version = value
+		case "source":
+			source = value
+		}
+	}
+	flush()
+}
+
+// parseGoSum reads module versions from go.sum, skipping the go.mod-only
+// checksums of modules that are not built.
+func parseGoSum(data []byte, add func(packageurl.PackageURL)) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		namespace, name := "", fields[0]
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+		add(packageurl.PackageURL{Type: packageurl.TypeGolang, Namespace: namespace, Name: name, Version: fields[1]})
+	}
+}
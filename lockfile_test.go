@@ -0,0 +1,119 @@
+package ecosystems
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseLockfile(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []string
+	}{
+		{
+			name: "package-lock v3",
+			file: "package-lock.json",
+			data: `{"lockfileVersion": 3, "packages": {
+				"": {"name": "app", "version": "1.0.0"},
+				"node_modules/lodash": {"version": "4.17.21"},
+				"node_modules/@babel/core": {"version": "7.24.0"},
+				"node_modules/@babel/core/node_modules/semver": {"version": "6.3.1"},
+				"node_modules/local": {"resolved": "../local", "link": true}
+			}}`,
+			want: []string{"pkg:npm/%40babel/core@7.24.0", "pkg:npm/semver@6.3.1", "pkg:npm/lodash@4.17.21"},
+		},
+		{
+			name: "package-lock v1",
+			file: "package-lock.json",
+			data: `{"lockfileVersion": 1, "dependencies": {
+				"lodash": {"version": "4.17.20"},
+				"debug": {"version": "2.6.9", "dependencies": {"ms": {"version": "2.0.0"}}}
+			}}`,
+			want: []string{"pkg:npm/debug@2.6.9", "pkg:npm/ms@2.0.0", "pkg:npm/lodash@4.17.20"},
+		},
+		{
+			name: "Gemfile.lock",
+			file: "Gemfile.lock",
+			data: `GIT
+  remote: https://github.com/example/private.git
+  specs:
+    private (0.1.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.16.2-x86_64-linux)
+      racc (~> 1.4)
+    racc (1.7.3)
+    rails (7.1.3)
+
+PLATFORMS
+  x86_64-linux
+`,
+			want: []string{"pkg:gem/nokogiri@1.16.2", "pkg:gem/racc@1.7.3", "pkg:gem/rails@7.1.3"},
+		},
+		{
+			name: "Cargo.lock",
+			file: "Cargo.lock",
+			data: `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde"]
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			want: []string{"pkg:cargo/serde@1.0.197"},
+		},
+		{
+			name: "go.sum",
+			file: "go.sum",
+			data: `github.com/google/uuid v1.6.0 h1:NIvaJDMOsjHA8n1jAhLSgzrAzy1Hgr+hNrb57e+94F0=
+github.com/google/uuid v1.6.0/go.mod h1:TIyPZe4MgqvfeYDBFedMoGGpEw/LqOeaOT+nhxU+yHo=
+golang.org/x/mod v0.17.0/go.mod h1:hTbmBsO62+eylJbnUtE2MGJUyE7QWk4xUqPFrRgJ+7c=
+`,
+			want: []string{"pkg:golang/github.com/google/uuid@v1.6.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purls, err := ParseLockfile(tt.file, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseLockfile() error = %v", err)
+			}
+			var got []string
+			for _, p := range purls {
+				got = append(got, p.ToString())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseLockfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLockfileErrors(t *testing.T) {
+	dir := t.TempDir()
+	unsupported := filepath.Join(dir, "yarn.lock")
+	invalid := filepath.Join(dir, "package-lock.json")
+	for path, data := range map[string]string{unsupported: "", invalid: "{"} {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, path := range []string{unsupported, invalid, filepath.Join(dir, "missing", "go.sum")} {
+		if _, err := ReadLockfile(path); err == nil {
+			t.Errorf("ReadLockfile(%s) error = nil, want error", filepath.Base(path))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ecosystems
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs each API request at debug level and failed requests at
+// warn level. Entries include the retry count, which is zero for the first
+// attempt and counts up as rate limited requests are retried. The
+// Authorization header is always redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *clientConfig) {
+		c.logger = logger
+	}
+}
+
+// redactedHeaders are never written to logs or debug output.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// loggingTransport logs requests made through it.
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("duration", elapsed),
+		slog.Int("retry", attemptFrom(req.Context())),
+	}
+	if op, ok := operationFrom(req.Context()); ok {
+		attrs = append(attrs, slog.String("endpoint", op.name))
+	}
+
+	ctx := req.Context()
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		t.logger.LogAttrs(ctx, slog.LevelWarn, "ecosyste.ms request failed", attrs...)
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound {
+		t.logger.LogAttrs(ctx, slog.LevelWarn, "ecosyste.ms request failed", attrs...)
+		return resp, err
+	}
+
+	if t.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header)))
+		t.logger.LogAttrs(ctx, slog.LevelDebug, "ecosyste.ms request", attrs...)
+	}
+	return resp, err
+}
+
+type attemptKey struct{}
+
+// withAttempt records in ctx which attempt at a request is being sent,
+// counting from zero, so that middleware below the retry loop can see it.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFrom returns the attempt recorded by withAttempt, or zero.
+func attemptFrom(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+// redactHeaders returns a copy of h with sensitive values replaced.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for name := range out {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			out[name] = []string{"REDACTED"}
+		}
+	}
+	return out
+}
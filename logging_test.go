@@ -0,0 +1,102 @@
+package ecosystems
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+)
+
+func TestWithLogger(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithAPIKey("secret-key"),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.LookupByRegistryAndName(context.Background(), "rubygems.org", "rails"); err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"level=DEBUG", "status=404", "endpoint=LookupByRegistryAndName", "REDACTED"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret-key") {
+		t.Errorf("log output contains API key:\n%s", out)
+	}
+}
+
+func TestWithLoggerRetryCount(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.URL),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log entries, want 2:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{"level=WARN.*retry=0.*status=429", "level=DEBUG.*retry=1.*status=200"} {
+		if !regexp.MustCompile(want).MatchString(lines[i]) {
+			t.Errorf("log entry %d = %q, want match for %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestWithLoggerWarnsOnFailure(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://127.0.0.1:1"),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.ListRegistries(context.Background()); err == nil {
+		t.Fatal("ListRegistries() against closed port should error")
+	}
+	if !strings.Contains(buf.String(), "level=WARN") {
+		t.Errorf("log output missing warning:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,119 @@
+package ecosystems
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// MaintainerChange records a release whose publishers or maintainers differ
+// from those of the release before it.
+type MaintainerChange struct {
+	Version         string
+	PreviousVersion string
+	// PublishedAt is zero when the API has no publish date for Version.
+	PublishedAt time.Time
+	// Added and Removed list identities, sorted. An identity is a login or
+	// name, lowercased, or an email address when neither is given.
+	Added   []string
+	Removed []string
+}
+
+// maintainerMetadataKeys are the version metadata fields that name the
+// people who published or maintain a release, across registries.
+var maintainerMetadataKeys = []string{"_npmUser", "maintainers", "published_by", "publisher", "uploaded_by"}
+
+// DetectMaintainerChanges walks a package's versions in publish order and
+// reports each release that added or removed a publisher or maintainer.
+// Maintainer lists come from per-version registry metadata, so versions
+// without it are skipped and registries that do not record it yield no
+// changes. It returns nil if the package is not found.
+func (c *Client) DetectMaintainerChanges(ctx context.Context, registry, name string, opts ...CallOption) ([]MaintainerChange, error) {
+	versions, err := c.GetAllVersions(ctx, registry, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return maintainerChanges(versions), nil
+}
+
+// maintainerChanges compares the maintainers of consecutive versions.
+func maintainerChanges(versions []packages.Version) []MaintainerChange {
+	type release struct {
+		number      string
+		publishedAt time.Time
+		maintainers []string
+	}
+	var releases []release
+	for _, v := range versions {
+		if v.Metadata == nil {
+			continue
+		}
+		maintainers := versionMaintainers(*v.Metadata)
+		if len(maintainers) == 0 {
+			continue
+		}
+		published, _ := parseTimestamp(v.PublishedAt)
+		releases = append(releases, release{v.Number, published, maintainers})
+	}
+	slices.SortStableFunc(releases, func(a, b release) int { return a.publishedAt.Compare(b.publishedAt) })
+
+	var changes []MaintainerChange
+	for i := 1; i < len(releases); i++ {
+		prev, cur := releases[i-1], releases[i]
+		change := MaintainerChange{Version: cur.number, PreviousVersion: prev.number, PublishedAt: cur.publishedAt}
+		for _, m := range cur.maintainers {
+			if !slices.Contains(prev.maintainers, m) {
+				change.Added = append(change.Added, m)
+			}
+		}
+		for _, m := range prev.maintainers {
+			if !slices.Contains(cur.maintainers, m) {
+				change.Removed = append(change.Removed, m)
+			}
+		}
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// versionMaintainers collects the sorted, unique identities named in version
+// metadata. Values may be strings, lists, or objects with a login, name or
+// email.
+func versionMaintainers(metadata map[string]interface{}) []string {
+	var ids []string
+	var add func(v interface{})
+	add = func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			if id := maintainerIdentity(v); id != "" && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		case []interface{}:
+			for _, item := range v {
+				add(item)
+			}
+		case map[string]interface{}:
+			add(cmp.Or(stringField(v, "login"), stringField(v, "name"), stringField(v, "email")))
+		}
+	}
+	for _, key := range maintainerMetadataKeys {
+		add(metadata[key])
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// maintainerIdentity normalizes a maintainer string such as
+// "Jane Doe <jane@example.com>" to "jane doe".
+func maintainerIdentity(s string) string {
+	if i := strings.Index(s, "<"); i > 0 {
+		s = s[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(s))
+}
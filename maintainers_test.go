@@ -0,0 +1,80 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestDetectMaintainerChanges(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddPackage("npmjs.org", packages.PackageWithRegistry{Name: "event-stream", Purl: "pkg:npm/event-stream"})
+	add := func(number, published string, metadata map[string]interface{}) {
+		srv.AddVersion("npmjs.org", "event-stream", packages.VersionWithDependencies{
+			Number: number, PublishedAt: &published, Metadata: &metadata,
+		})
+	}
+	// Out of publish order, as an API page might return them.
+	add("3.3.6", "2018-09-09T00:00:00Z", map[string]interface{}{
+		"_npmUser":    map[string]interface{}{"name": "right9ctrl", "email": "r@example.com"},
+		"maintainers": []interface{}{map[string]interface{}{"name": "right9ctrl"}},
+	})
+	add("3.3.4", "2015-09-01T00:00:00Z", map[string]interface{}{
+		"_npmUser":    map[string]interface{}{"name": "dominictarr"},
+		"maintainers": []interface{}{"dominictarr <dominic@example.com>"},
+	})
+	add("3.3.5", "2018-09-05T00:00:00Z", map[string]interface{}{
+		"_npmUser":    map[string]interface{}{"name": "right9ctrl"},
+		"maintainers": []interface{}{"dominictarr", "right9ctrl"},
+	})
+	add("3.3.5-docs", "2018-09-06T00:00:00Z", map[string]interface{}{})
+
+	changes, err := client.DetectMaintainerChanges(context.Background(), "npmjs.org", "event-stream")
+	if err != nil {
+		t.Fatalf("DetectMaintainerChanges() error = %v", err)
+	}
+	want := []MaintainerChange{
+		{Version: "3.3.5", PreviousVersion: "3.3.4", Added: []string{"right9ctrl"}},
+		{Version: "3.3.6", PreviousVersion: "3.3.5", Removed: []string{"dominictarr"}},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("DetectMaintainerChanges() = %+v, want %d changes", changes, len(want))
+	}
+	for i, w := range want {
+		got := changes[i]
+		if got.Version != w.Version || got.PreviousVersion != w.PreviousVersion ||
+			!reflect.DeepEqual(got.Added, w.Added) || !reflect.DeepEqual(got.Removed, w.Removed) {
+			t.Errorf("changes[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	if changes[0].PublishedAt.IsZero() {
+		t.Error("changes[0].PublishedAt is zero")
+	}
+}
+
+func TestVersionMaintainers(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		want     []string
+	}{
+		{"empty", map[string]interface{}{}, nil},
+		{"publisher string", map[string]interface{}{"published_by": "Alice"}, []string{"alice"}},
+		{"email only", map[string]interface{}{"uploaded_by": map[string]interface{}{"email": "bob@example.com"}}, []string{"bob@example.com"}},
+		{"login over name", map[string]interface{}{"publisher": map[string]interface{}{"login": "carol", "name": "Carol C"}}, []string{"carol"}},
+		{"deduplicated", map[string]interface{}{
+			"_npmUser":    map[string]interface{}{"name": "dave"},
+			"maintainers": []interface{}{"Dave <d@example.com>", "erin"},
+		}, []string{"dave", "erin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionMaintainers(tt.metadata); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("versionMaintainers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,561 @@
+// Package mock provides a programmable implementation of
+// ecosystems.ClientInterface for testing code that uses the ecosyste.ms client
+// without making HTTP requests.
+//
+// Set the Func field for each method the code under test calls. Calling a
+// method whose Func field is nil returns an error wrapping ErrNotImplemented,
+// except for the local PURL helpers, which fall back to the default parser.
+// Per-call options are accepted and ignored.
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// ErrNotImplemented is returned by methods whose Func field is not set.
+var ErrNotImplemented = errors.New("mock: method not implemented")
+
+// Client is a mock ecosystems.ClientInterface with programmable responses.
+type Client struct {
+	BulkLookupFunc                 func(ctx context.Context, purls []string) (map[string]*packages.PackageWithRegistry, error)
+	BulkLookupDetailedFunc         func(ctx context.Context, purls []string) (*ecosystems.BulkLookupResult, error)
+	BulkLookupStreamFunc           func(ctx context.Context, purls []string, fn func(purl string, pkg *packages.PackageWithRegistry) error) error
+	LookupFunc                     func(ctx context.Context, purl string) (*packages.PackageWithRegistry, error)
+	LookupByRegistryAndNameFunc    func(ctx context.Context, registry, name string) (*packages.Package, error)
+	GetVersionFunc                 func(ctx context.Context, registry, name, version string) (*packages.VersionWithDependencies, error)
+	BulkGetVersionsFunc            func(ctx context.Context, purls []string) (map[string]*packages.VersionWithDependencies, error)
+	GetVersionNumbersFunc          func(ctx context.Context, registry, name string) ([]string, error)
+	GetAllVersionsFunc             func(ctx context.Context, registry, name string) ([]packages.Version, error)
+	GetVersionsPageFunc            func(ctx context.Context, registry, name string, opts ecosystems.ListOptions) (*ecosystems.Page[packages.Version], error)
+	GetRepositoryFunc              func(ctx context.Context, url string) (*repos.Repository, error)
+	GetRepositoryForPackageFunc    func(ctx context.Context, purl string) (*repos.Repository, error)
+	BulkGetRepositoriesFunc        func(ctx context.Context, urls []string) (*ecosystems.BulkRepositoriesResult, error)
+	GetRepositoryByHostAndNameFunc func(ctx context.Context, host, fullName string) (*repos.Repository, error)
+	SyncRepositoryFunc             func(ctx context.Context, url string) (bool, error)
+	SyncPackageFunc                func(ctx context.Context, registry, name string) (bool, error)
+	PingFunc                       func(ctx context.Context) ([]ecosystems.ServiceStatus, error)
+	ListRegistriesFunc             func(ctx context.Context) ([]packages.Registry, error)
+	ListRegistriesByEcosystemFunc  func(ctx context.Context, ecosystem string) ([]packages.Registry, error)
+	GetRegistryFunc                func(ctx context.Context, name string) (*packages.Registry, error)
+	RefreshRegistriesFunc          func(ctx context.Context) error
+	VerifyRegistryMappingsFunc     func(ctx context.Context) error
+	ListCriticalPackagesFunc       func(ctx context.Context, registry string, opts ecosystems.ListOptions) (*ecosystems.Page[packages.PackageWithRegistry], error)
+	ListRegistryPackagesFunc       func(ctx context.Context, registry string, opts ecosystems.ListOptions) (*ecosystems.Page[packages.Package], error)
+	ListTopPackagesFunc            func(ctx context.Context, registry, by string, limit int) ([]packages.Package, error)
+	ListKeywordsFunc               func(ctx context.Context, opts ecosystems.ListOptions) (*ecosystems.Page[packages.Keyword], error)
+	GetPackagesByKeywordFunc       func(ctx context.Context, ecosystem, keyword string, opts ecosystems.ListOptions) (*ecosystems.Page[packages.Package], error)
+	GetRegistryPackageNamesFunc    func(ctx context.Context, registry string) iter.Seq2[string, error]
+	GetRecentlyUpdatedPackagesFunc func(ctx context.Context, registry string, since time.Time) iter.Seq2[packages.Package, error]
+	ListTopicsFunc                 func(ctx context.Context, opts ecosystems.ListOptions) (*ecosystems.Page[repos.Topic], error)
+	GetRepositoriesByTopicFunc     func(ctx context.Context, host, topic string, opts ecosystems.ListOptions) ([]repos.Repository, error)
+	GetOwnerFunc                   func(ctx context.Context, host, login string) (*repos.Owner, error)
+	HostRepositoriesIterFunc       func(ctx context.Context, host string) iter.Seq2[repos.Repository, error]
+	GetDependentRepositoriesFunc   func(ctx context.Context, registry, name string) iter.Seq2[repos.Repository, error]
+	ListOwnerRepositoriesFunc      func(ctx context.Context, host, login string) ([]repos.Repository, error)
+	LookupPURLFunc                 func(ctx context.Context, purl packageurl.PackageURL) (*packages.Package, error)
+	LookupPURLSubpathFunc          func(ctx context.Context, purl packageurl.PackageURL, opts ecosystems.SubpathOptions) (*ecosystems.SubpathPackage, error)
+	GetVersionPURLFunc             func(ctx context.Context, purl packageurl.PackageURL) (*packages.VersionWithDependencies, error)
+	GetVersionNumbersPURLFunc      func(ctx context.Context, purl packageurl.PackageURL) ([]string, error)
+	GetAllVersionsPURLFunc         func(ctx context.Context, purl packageurl.PackageURL) ([]packages.Version, error)
+	LookupRepositoryPURLFunc       func(ctx context.Context, purl packageurl.PackageURL) (*repos.Repository, error)
+	GetVersionsMatchingFunc        func(ctx context.Context, purl packageurl.PackageURL, constraint string) ([]packages.Version, error)
+	GetLatestVersionFunc           func(ctx context.Context, purl packageurl.PackageURL, opts ecosystems.LatestVersionOptions) (string, error)
+	WatchPackageFunc               func(ctx context.Context, purl string, interval time.Duration, fn func(newVersion packages.Version)) error
+	DetectMaintainerChangesFunc    func(ctx context.Context, registry, name string) ([]ecosystems.MaintainerChange, error)
+	LicenseReportFunc              func(ctx context.Context, purls []string) (*ecosystems.LicenseReport, error)
+	VulnerabilityReportFunc        func(ctx context.Context, purls []string) (*ecosystems.VulnerabilityReport, error)
+	DiffLockfilesFunc              func(ctx context.Context, oldPath, newPath string) (*ecosystems.LockfileDiff, error)
+	GetFundingForPURLFunc          func(ctx context.Context, purl string) (*ecosystems.Funding, error)
+	GetCollectiveForProjectFunc    func(ctx context.Context, projectURL string) (*ecosystems.Collective, error)
+	FindSimilarPackagesFunc        func(ctx context.Context, registry, name string, maxDistance int) ([]ecosystems.SimilarPackage, error)
+	FindPackagesByRepositoryFunc   func(ctx context.Context, repoURL string) ([]packages.PackageWithRegistry, error)
+	FindPackageEverywhereFunc      func(ctx context.Context, name string) ([]ecosystems.PackageFamily, error)
+	GetRepositoryFilesFunc         func(ctx context.Context, repoURL string) (ecosystems.RepositoryFiles, error)
+	GetRepositoryFileFunc          func(ctx context.Context, repoURL, path string) (*ecosystems.RepositoryFile, error)
+	OutdatedReportFunc             func(ctx context.Context, pinned []packageurl.PackageURL) (*ecosystems.OutdatedReport, error)
+	GetPackageStatsFunc            func(ctx context.Context, purl string) (*ecosystems.PackageStats, error)
+	NormalizePopularityFunc        func(ctx context.Context, purls []string) (map[string]*ecosystems.Popularity, error)
+	ReleaseCadenceFunc             func(ctx context.Context, purl packageurl.PackageURL) (*ecosystems.ReleaseCadence, error)
+	PrefetchFunc                   func(ctx context.Context, purls []string) <-chan ecosystems.PrefetchResult
+	LookupDeltaFunc                func(ctx context.Context, purls []string, previous *ecosystems.Snapshot, maxAge time.Duration) (*ecosystems.Snapshot, error)
+	ParsePURLFunc                  func(s string) (packageurl.PackageURL, error)
+	FormatPURLFunc                 func(purl packageurl.PackageURL) string
+	ResolveRegistryFunc            func(purl packageurl.PackageURL) (registry, reason string)
+}
+
+var _ ecosystems.ClientInterface = (*Client)(nil)
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotImplemented)
+}
+
+// failedSeq returns an iterator that yields err and stops.
+func failedSeq[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+func (m *Client) BulkLookup(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (map[string]*packages.PackageWithRegistry, error) {
+	if m.BulkLookupFunc == nil {
+		return nil, notImplemented("BulkLookup")
+	}
+	return m.BulkLookupFunc(ctx, purls)
+}
+
+func (m *Client) BulkLookupDetailed(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (*ecosystems.BulkLookupResult, error) {
+	if m.BulkLookupDetailedFunc == nil {
+		return nil, notImplemented("BulkLookupDetailed")
+	}
+	return m.BulkLookupDetailedFunc(ctx, purls)
+}
+
+func (m *Client) BulkLookupStream(ctx context.Context, purls []string, fn func(purl string, pkg *packages.PackageWithRegistry) error, _ ...ecosystems.CallOption) error {
+	if m.BulkLookupStreamFunc == nil {
+		return notImplemented("BulkLookupStream")
+	}
+	return m.BulkLookupStreamFunc(ctx, purls, fn)
+}
+
+func (m *Client) Lookup(ctx context.Context, purl string, _ ...ecosystems.CallOption) (*packages.PackageWithRegistry, error) {
+	if m.LookupFunc == nil {
+		return nil, notImplemented("Lookup")
+	}
+	return m.LookupFunc(ctx, purl)
+}
+
+func (m *Client) LookupByRegistryAndName(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) (*packages.Package, error) {
+	if m.LookupByRegistryAndNameFunc == nil {
+		return nil, notImplemented("LookupByRegistryAndName")
+	}
+	return m.LookupByRegistryAndNameFunc(ctx, registry, name)
+}
+
+func (m *Client) GetVersion(ctx context.Context, registry, name, version string, _ ...ecosystems.CallOption) (*packages.VersionWithDependencies, error) {
+	if m.GetVersionFunc == nil {
+		return nil, notImplemented("GetVersion")
+	}
+	return m.GetVersionFunc(ctx, registry, name, version)
+}
+
+func (m *Client) BulkGetVersions(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (map[string]*packages.VersionWithDependencies, error) {
+	if m.BulkGetVersionsFunc == nil {
+		return nil, notImplemented("BulkGetVersions")
+	}
+	return m.BulkGetVersionsFunc(ctx, purls)
+}
+
+func (m *Client) GetVersionNumbers(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) ([]string, error) {
+	if m.GetVersionNumbersFunc == nil {
+		return nil, notImplemented("GetVersionNumbers")
+	}
+	return m.GetVersionNumbersFunc(ctx, registry, name)
+}
+
+func (m *Client) GetAllVersions(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) ([]packages.Version, error) {
+	if m.GetAllVersionsFunc == nil {
+		return nil, notImplemented("GetAllVersions")
+	}
+	return m.GetAllVersionsFunc(ctx, registry, name)
+}
+
+func (m *Client) GetVersionsPage(ctx context.Context, registry, name string, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[packages.Version], error) {
+	if m.GetVersionsPageFunc == nil {
+		return nil, notImplemented("GetVersionsPage")
+	}
+	return m.GetVersionsPageFunc(ctx, registry, name, opts)
+}
+
+func (m *Client) GetRepository(ctx context.Context, url string, _ ...ecosystems.CallOption) (*repos.Repository, error) {
+	if m.GetRepositoryFunc == nil {
+		return nil, notImplemented("GetRepository")
+	}
+	return m.GetRepositoryFunc(ctx, url)
+}
+
+func (m *Client) GetRepositoryForPackage(ctx context.Context, purl string, _ ...ecosystems.CallOption) (*repos.Repository, error) {
+	if m.GetRepositoryForPackageFunc == nil {
+		return nil, notImplemented("GetRepositoryForPackage")
+	}
+	return m.GetRepositoryForPackageFunc(ctx, purl)
+}
+
+func (m *Client) BulkGetRepositories(ctx context.Context, urls []string, _ ...ecosystems.CallOption) (*ecosystems.BulkRepositoriesResult, error) {
+	if m.BulkGetRepositoriesFunc == nil {
+		return nil, notImplemented("BulkGetRepositories")
+	}
+	return m.BulkGetRepositoriesFunc(ctx, urls)
+}
+
+func (m *Client) GetRepositoryByHostAndName(ctx context.Context, host, fullName string, _ ...ecosystems.CallOption) (*repos.Repository, error) {
+	if m.GetRepositoryByHostAndNameFunc == nil {
+		return nil, notImplemented("GetRepositoryByHostAndName")
+	}
+	return m.GetRepositoryByHostAndNameFunc(ctx, host, fullName)
+}
+
+func (m *Client) SyncRepository(ctx context.Context, url string, _ ...ecosystems.CallOption) (bool, error) {
+	if m.SyncRepositoryFunc == nil {
+		return false, notImplemented("SyncRepository")
+	}
+	return m.SyncRepositoryFunc(ctx, url)
+}
+
+func (m *Client) SyncPackage(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) (bool, error) {
+	if m.SyncPackageFunc == nil {
+		return false, notImplemented("SyncPackage")
+	}
+	return m.SyncPackageFunc(ctx, registry, name)
+}
+
+func (m *Client) Ping(ctx context.Context, _ ...ecosystems.CallOption) ([]ecosystems.ServiceStatus, error) {
+	if m.PingFunc == nil {
+		return nil, notImplemented("Ping")
+	}
+	return m.PingFunc(ctx)
+}
+
+func (m *Client) ListRegistries(ctx context.Context, _ ...ecosystems.CallOption) ([]packages.Registry, error) {
+	if m.ListRegistriesFunc == nil {
+		return nil, notImplemented("ListRegistries")
+	}
+	return m.ListRegistriesFunc(ctx)
+}
+
+func (m *Client) ListRegistriesByEcosystem(ctx context.Context, ecosystem string, _ ...ecosystems.CallOption) ([]packages.Registry, error) {
+	if m.ListRegistriesByEcosystemFunc == nil {
+		return nil, notImplemented("ListRegistriesByEcosystem")
+	}
+	return m.ListRegistriesByEcosystemFunc(ctx, ecosystem)
+}
+
+func (m *Client) GetRegistry(ctx context.Context, name string, _ ...ecosystems.CallOption) (*packages.Registry, error) {
+	if m.GetRegistryFunc == nil {
+		return nil, notImplemented("GetRegistry")
+	}
+	return m.GetRegistryFunc(ctx, name)
+}
+
+func (m *Client) RefreshRegistries(ctx context.Context, _ ...ecosystems.CallOption) error {
+	if m.RefreshRegistriesFunc == nil {
+		return notImplemented("RefreshRegistries")
+	}
+	return m.RefreshRegistriesFunc(ctx)
+}
+
+func (m *Client) VerifyRegistryMappings(ctx context.Context, _ ...ecosystems.CallOption) error {
+	if m.VerifyRegistryMappingsFunc == nil {
+		return notImplemented("VerifyRegistryMappings")
+	}
+	return m.VerifyRegistryMappingsFunc(ctx)
+}
+
+func (m *Client) ListCriticalPackages(ctx context.Context, registry string, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[packages.PackageWithRegistry], error) {
+	if m.ListCriticalPackagesFunc == nil {
+		return nil, notImplemented("ListCriticalPackages")
+	}
+	return m.ListCriticalPackagesFunc(ctx, registry, opts)
+}
+
+func (m *Client) ListRegistryPackages(ctx context.Context, registry string, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[packages.Package], error) {
+	if m.ListRegistryPackagesFunc == nil {
+		return nil, notImplemented("ListRegistryPackages")
+	}
+	return m.ListRegistryPackagesFunc(ctx, registry, opts)
+}
+
+func (m *Client) ListTopPackages(ctx context.Context, registry, by string, limit int, _ ...ecosystems.CallOption) ([]packages.Package, error) {
+	if m.ListTopPackagesFunc == nil {
+		return nil, notImplemented("ListTopPackages")
+	}
+	return m.ListTopPackagesFunc(ctx, registry, by, limit)
+}
+
+func (m *Client) ListKeywords(ctx context.Context, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[packages.Keyword], error) {
+	if m.ListKeywordsFunc == nil {
+		return nil, notImplemented("ListKeywords")
+	}
+	return m.ListKeywordsFunc(ctx, opts)
+}
+
+func (m *Client) GetPackagesByKeyword(ctx context.Context, ecosystem, keyword string, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[packages.Package], error) {
+	if m.GetPackagesByKeywordFunc == nil {
+		return nil, notImplemented("GetPackagesByKeyword")
+	}
+	return m.GetPackagesByKeywordFunc(ctx, ecosystem, keyword, opts)
+}
+
+func (m *Client) GetRegistryPackageNames(ctx context.Context, registry string, _ ...ecosystems.CallOption) iter.Seq2[string, error] {
+	if m.GetRegistryPackageNamesFunc == nil {
+		return failedSeq[string](notImplemented("GetRegistryPackageNames"))
+	}
+	return m.GetRegistryPackageNamesFunc(ctx, registry)
+}
+
+func (m *Client) GetRecentlyUpdatedPackages(ctx context.Context, registry string, since time.Time, _ ...ecosystems.CallOption) iter.Seq2[packages.Package, error] {
+	if m.GetRecentlyUpdatedPackagesFunc == nil {
+		return failedSeq[packages.Package](notImplemented("GetRecentlyUpdatedPackages"))
+	}
+	return m.GetRecentlyUpdatedPackagesFunc(ctx, registry, since)
+}
+
+func (m *Client) ListTopics(ctx context.Context, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) (*ecosystems.Page[repos.Topic], error) {
+	if m.ListTopicsFunc == nil {
+		return nil, notImplemented("ListTopics")
+	}
+	return m.ListTopicsFunc(ctx, opts)
+}
+
+func (m *Client) GetRepositoriesByTopic(ctx context.Context, host, topic string, opts ecosystems.ListOptions, _ ...ecosystems.CallOption) ([]repos.Repository, error) {
+	if m.GetRepositoriesByTopicFunc == nil {
+		return nil, notImplemented("GetRepositoriesByTopic")
+	}
+	return m.GetRepositoriesByTopicFunc(ctx, host, topic, opts)
+}
+
+func (m *Client) GetOwner(ctx context.Context, host, login string, _ ...ecosystems.CallOption) (*repos.Owner, error) {
+	if m.GetOwnerFunc == nil {
+		return nil, notImplemented("GetOwner")
+	}
+	return m.GetOwnerFunc(ctx, host, login)
+}
+
+func (m *Client) HostRepositoriesIter(ctx context.Context, host string, _ ...ecosystems.CallOption) iter.Seq2[repos.Repository, error] {
+	if m.HostRepositoriesIterFunc == nil {
+		return failedSeq[repos.Repository](notImplemented("HostRepositoriesIter"))
+	}
+	return m.HostRepositoriesIterFunc(ctx, host)
+}
+
+func (m *Client) GetDependentRepositories(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) iter.Seq2[repos.Repository, error] {
+	if m.GetDependentRepositoriesFunc == nil {
+		return failedSeq[repos.Repository](notImplemented("GetDependentRepositories"))
+	}
+	return m.GetDependentRepositoriesFunc(ctx, registry, name)
+}
+
+func (m *Client) ListOwnerRepositories(ctx context.Context, host, login string, _ ...ecosystems.CallOption) ([]repos.Repository, error) {
+	if m.ListOwnerRepositoriesFunc == nil {
+		return nil, notImplemented("ListOwnerRepositories")
+	}
+	return m.ListOwnerRepositoriesFunc(ctx, host, login)
+}
+
+func (m *Client) LookupPURL(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) (*packages.Package, error) {
+	if m.LookupPURLFunc == nil {
+		return nil, notImplemented("LookupPURL")
+	}
+	return m.LookupPURLFunc(ctx, purl)
+}
+
+func (m *Client) LookupPURLSubpath(ctx context.Context, purl packageurl.PackageURL, opts ecosystems.SubpathOptions, _ ...ecosystems.CallOption) (*ecosystems.SubpathPackage, error) {
+	if m.LookupPURLSubpathFunc == nil {
+		return nil, notImplemented("LookupPURLSubpath")
+	}
+	return m.LookupPURLSubpathFunc(ctx, purl, opts)
+}
+
+func (m *Client) GetVersionPURL(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) (*packages.VersionWithDependencies, error) {
+	if m.GetVersionPURLFunc == nil {
+		return nil, notImplemented("GetVersionPURL")
+	}
+	return m.GetVersionPURLFunc(ctx, purl)
+}
+
+func (m *Client) GetVersionNumbersPURL(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) ([]string, error) {
+	if m.GetVersionNumbersPURLFunc == nil {
+		return nil, notImplemented("GetVersionNumbersPURL")
+	}
+	return m.GetVersionNumbersPURLFunc(ctx, purl)
+}
+
+func (m *Client) GetAllVersionsPURL(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) ([]packages.Version, error) {
+	if m.GetAllVersionsPURLFunc == nil {
+		return nil, notImplemented("GetAllVersionsPURL")
+	}
+	return m.GetAllVersionsPURLFunc(ctx, purl)
+}
+
+func (m *Client) LookupRepositoryPURL(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) (*repos.Repository, error) {
+	if m.LookupRepositoryPURLFunc == nil {
+		return nil, notImplemented("LookupRepositoryPURL")
+	}
+	return m.LookupRepositoryPURLFunc(ctx, purl)
+}
+
+func (m *Client) GetVersionsMatching(ctx context.Context, purl packageurl.PackageURL, constraint string, _ ...ecosystems.CallOption) ([]packages.Version, error) {
+	if m.GetVersionsMatchingFunc == nil {
+		return nil, notImplemented("GetVersionsMatching")
+	}
+	return m.GetVersionsMatchingFunc(ctx, purl, constraint)
+}
+
+func (m *Client) GetLatestVersion(ctx context.Context, purl packageurl.PackageURL, opts ecosystems.LatestVersionOptions, _ ...ecosystems.CallOption) (string, error) {
+	if m.GetLatestVersionFunc == nil {
+		return "", notImplemented("GetLatestVersion")
+	}
+	return m.GetLatestVersionFunc(ctx, purl, opts)
+}
+
+func (m *Client) WatchPackage(ctx context.Context, purl string, interval time.Duration, fn func(newVersion packages.Version), _ ...ecosystems.CallOption) error {
+	if m.WatchPackageFunc == nil {
+		return notImplemented("WatchPackage")
+	}
+	return m.WatchPackageFunc(ctx, purl, interval, fn)
+}
+
+func (m *Client) DetectMaintainerChanges(ctx context.Context, registry, name string, _ ...ecosystems.CallOption) ([]ecosystems.MaintainerChange, error) {
+	if m.DetectMaintainerChangesFunc == nil {
+		return nil, notImplemented("DetectMaintainerChanges")
+	}
+	return m.DetectMaintainerChangesFunc(ctx, registry, name)
+}
+
+func (m *Client) LicenseReport(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (*ecosystems.LicenseReport, error) {
+	if m.LicenseReportFunc == nil {
+		return nil, notImplemented("LicenseReport")
+	}
+	return m.LicenseReportFunc(ctx, purls)
+}
+
+func (m *Client) VulnerabilityReport(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (*ecosystems.VulnerabilityReport, error) {
+	if m.VulnerabilityReportFunc == nil {
+		return nil, notImplemented("VulnerabilityReport")
+	}
+	return m.VulnerabilityReportFunc(ctx, purls)
+}
+
+func (m *Client) DiffLockfiles(ctx context.Context, oldPath, newPath string, _ ...ecosystems.CallOption) (*ecosystems.LockfileDiff, error) {
+	if m.DiffLockfilesFunc == nil {
+		return nil, notImplemented("DiffLockfiles")
+	}
+	return m.DiffLockfilesFunc(ctx, oldPath, newPath)
+}
+
+func (m *Client) GetFundingForPURL(ctx context.Context, purl string, _ ...ecosystems.CallOption) (*ecosystems.Funding, error) {
+	if m.GetFundingForPURLFunc == nil {
+		return nil, notImplemented("GetFundingForPURL")
+	}
+	return m.GetFundingForPURLFunc(ctx, purl)
+}
+
+func (m *Client) GetCollectiveForProject(ctx context.Context, projectURL string, _ ...ecosystems.CallOption) (*ecosystems.Collective, error) {
+	if m.GetCollectiveForProjectFunc == nil {
+		return nil, notImplemented("GetCollectiveForProject")
+	}
+	return m.GetCollectiveForProjectFunc(ctx, projectURL)
+}
+
+func (m *Client) FindSimilarPackages(ctx context.Context, registry, name string, maxDistance int, _ ...ecosystems.CallOption) ([]ecosystems.SimilarPackage, error) {
+	if m.FindSimilarPackagesFunc == nil {
+		return nil, notImplemented("FindSimilarPackages")
+	}
+	return m.FindSimilarPackagesFunc(ctx, registry, name, maxDistance)
+}
+
+func (m *Client) FindPackagesByRepository(ctx context.Context, repoURL string, _ ...ecosystems.CallOption) ([]packages.PackageWithRegistry, error) {
+	if m.FindPackagesByRepositoryFunc == nil {
+		return nil, notImplemented("FindPackagesByRepository")
+	}
+	return m.FindPackagesByRepositoryFunc(ctx, repoURL)
+}
+
+func (m *Client) FindPackageEverywhere(ctx context.Context, name string, _ ...ecosystems.CallOption) ([]ecosystems.PackageFamily, error) {
+	if m.FindPackageEverywhereFunc == nil {
+		return nil, notImplemented("FindPackageEverywhere")
+	}
+	return m.FindPackageEverywhereFunc(ctx, name)
+}
+
+func (m *Client) GetRepositoryFiles(ctx context.Context, repoURL string, _ ...ecosystems.CallOption) (ecosystems.RepositoryFiles, error) {
+	if m.GetRepositoryFilesFunc == nil {
+		return nil, notImplemented("GetRepositoryFiles")
+	}
+	return m.GetRepositoryFilesFunc(ctx, repoURL)
+}
+
+func (m *Client) GetRepositoryFile(ctx context.Context, repoURL, path string, _ ...ecosystems.CallOption) (*ecosystems.RepositoryFile, error) {
+	if m.GetRepositoryFileFunc == nil {
+		return nil, notImplemented("GetRepositoryFile")
+	}
+	return m.GetRepositoryFileFunc(ctx, repoURL, path)
+}
+
+func (m *Client) OutdatedReport(ctx context.Context, pinned []packageurl.PackageURL, _ ...ecosystems.CallOption) (*ecosystems.OutdatedReport, error) {
+	if m.OutdatedReportFunc == nil {
+		return nil, notImplemented("OutdatedReport")
+	}
+	return m.OutdatedReportFunc(ctx, pinned)
+}
+
+func (m *Client) GetPackageStats(ctx context.Context, purl string, _ ...ecosystems.CallOption) (*ecosystems.PackageStats, error) {
+	if m.GetPackageStatsFunc == nil {
+		return nil, notImplemented("GetPackageStats")
+	}
+	return m.GetPackageStatsFunc(ctx, purl)
+}
+
+func (m *Client) NormalizePopularity(ctx context.Context, purls []string, _ ...ecosystems.CallOption) (map[string]*ecosystems.Popularity, error) {
+	if m.NormalizePopularityFunc == nil {
+		return nil, notImplemented("NormalizePopularity")
+	}
+	return m.NormalizePopularityFunc(ctx, purls)
+}
+
+func (m *Client) ReleaseCadence(ctx context.Context, purl packageurl.PackageURL, _ ...ecosystems.CallOption) (*ecosystems.ReleaseCadence, error) {
+	if m.ReleaseCadenceFunc == nil {
+		return nil, notImplemented("ReleaseCadence")
+	}
+	return m.ReleaseCadenceFunc(ctx, purl)
+}
+
+func (m *Client) Prefetch(ctx context.Context, purls []string, _ ...ecosystems.CallOption) <-chan ecosystems.PrefetchResult {
+	if m.PrefetchFunc == nil {
+		ch := make(chan ecosystems.PrefetchResult, 1)
+		ch <- ecosystems.PrefetchResult{Err: notImplemented("Prefetch")}
+		close(ch)
+		return ch
+	}
+	return m.PrefetchFunc(ctx, purls)
+}
+
+func (m *Client) LookupDelta(ctx context.Context, purls []string, previous *ecosystems.Snapshot, maxAge time.Duration, _ ...ecosystems.CallOption) (*ecosystems.Snapshot, error) {
+	if m.LookupDeltaFunc == nil {
+		return nil, notImplemented("LookupDelta")
+	}
+	return m.LookupDeltaFunc(ctx, purls, previous, maxAge)
+}
+
+// ParsePURL calls ParsePURLFunc, or ecosystems.ParsePURL if it is not set.
+func (m *Client) ParsePURL(s string) (packageurl.PackageURL, error) {
+	if m.ParsePURLFunc == nil {
+		return ecosystems.ParsePURL(s)
+	}
+	return m.ParsePURLFunc(s)
+}
+
+// FormatPURL calls FormatPURLFunc, or the default parser's Format if it is not set.
+func (m *Client) FormatPURL(purl packageurl.PackageURL) string {
+	if m.FormatPURLFunc == nil {
+		return ecosystems.PackageURLParser{}.Format(purl)
+	}
+	return m.FormatPURLFunc(purl)
+}
+
+// ResolveRegistry calls ResolveRegistryFunc, or ecosystems.ResolveRegistry if it is not set.
+func (m *Client) ResolveRegistry(purl packageurl.PackageURL) (registry, reason string) {
+	if m.ResolveRegistryFunc == nil {
+		return ecosystems.ResolveRegistry(purl)
+	}
+	return m.ResolveRegistryFunc(purl)
+}
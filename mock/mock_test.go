@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestClientProgrammedResponse(t *testing.T) {
+	var client ecosystems.ClientInterface = &Client{
+		LookupFunc: func(ctx context.Context, purl string) (*packages.PackageWithRegistry, error) {
+			return &packages.PackageWithRegistry{Purl: purl, Name: "rails"}, nil
+		},
+	}
+
+	pkg, err := client.Lookup(context.Background(), "pkg:gem/rails")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if pkg.Name != "rails" {
+		t.Errorf("Name = %q, want %q", pkg.Name, "rails")
+	}
+}
+
+func TestClientNotImplemented(t *testing.T) {
+	client := &Client{}
+	_, err := client.BulkLookup(context.Background(), []string{"pkg:gem/rails"})
+	if !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("BulkLookup() error = %v, want ErrNotImplemented", err)
+	}
+}
+
+func TestClientNotImplementedIterator(t *testing.T) {
+	client := &Client{}
+	var errs []error
+	for _, err := range client.GetRegistryPackageNames(context.Background(), "npmjs.org") {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrNotImplemented) {
+		t.Errorf("GetRegistryPackageNames() errors = %v, want one ErrNotImplemented", errs)
+	}
+}
@@ -0,0 +1,184 @@
+package ecosystems
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// OfflineMode controls what an offline client does with requests its
+// snapshot cannot answer.
+type OfflineMode int
+
+const (
+	// OfflineOnly fails requests the snapshot cannot answer with ErrOffline.
+	OfflineOnly OfflineMode = iota
+	// OfflineFallback sends requests the snapshot cannot answer to the API.
+	OfflineFallback
+)
+
+// ErrOffline is returned in OfflineOnly mode for requests the offline
+// snapshot cannot answer.
+var ErrOffline = errors.New("not available offline")
+
+// WithOfflineStore serves package lookups from a snapshot saved with
+// Snapshot.Save, so code using the client runs without network access.
+// Bulk lookups and lookups by registry and name are answered from the
+// snapshot; PURLs it lacks are reported as not found. Other requests, such
+// as versions and repositories, fail with ErrOffline unless mode is
+// OfflineFallback, in which case they and any PURLs missing from the
+// snapshot are fetched from the API.
+func WithOfflineStore(store *Snapshot, mode OfflineMode) Option {
+	return func(c *clientConfig) {
+		c.offlineStore = store
+		c.offlineMode = mode
+	}
+}
+
+// offlineTransport answers package lookups from a snapshot.
+type offlineTransport struct {
+	store *Snapshot
+	mode  OfflineMode
+	next  http.RoundTripper
+	stats *clientStats
+}
+
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := req.URL.EscapedPath()
+	switch {
+	case req.Method == http.MethodPost && strings.HasSuffix(path, "/packages/bulk_lookup"):
+		return t.bulkLookup(req)
+	case req.Method == http.MethodGet:
+		if registry, name, ok := packagePath(path); ok {
+			if pkg := t.find(registry, name); pkg != nil {
+				t.stats.cache(true)
+				return jsonResponse(req, http.StatusOK, pkg)
+			}
+			if t.mode == OfflineOnly {
+				t.stats.cache(false)
+				return jsonResponse(req, http.StatusNotFound, map[string]string{"error": "not found"})
+			}
+		}
+	}
+	t.stats.cache(false)
+	if t.mode == OfflineFallback {
+		return t.next.RoundTrip(req)
+	}
+	return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL, ErrOffline)
+}
+
+// bulkLookup answers a bulk lookup from the snapshot, fetching the PURLs it
+// lacks from the API in OfflineFallback mode.
+func (t *offlineTransport) bulkLookup(req *http.Request) (*http.Response, error) {
+	var body packages.BulkLookupPackagesJSONBody
+	if req.Body != nil {
+		err := json.NewDecoder(req.Body).Decode(&body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("offline: decoding bulk lookup: %w", err)
+		}
+	}
+
+	found := []packages.PackageWithRegistry{}
+	var missing []string
+	if body.Purls != nil {
+		for _, purl := range *body.Purls {
+			if entry := t.store.Packages[purl]; entry != nil && entry.Package != nil {
+				pkg := *entry.Package
+				pkg.Purl = purl
+				found = append(found, pkg)
+			} else {
+				missing = append(missing, purl)
+			}
+		}
+	}
+
+	t.stats.cache(len(missing) == 0)
+	if t.mode == OfflineFallback && len(missing) > 0 {
+		fetched, err := t.fetch(req, missing)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, fetched...)
+	}
+	return jsonResponse(req, http.StatusOK, found)
+}
+
+// fetch sends a bulk lookup for purls to the API.
+func (t *offlineTransport) fetch(req *http.Request, purls []string) ([]packages.PackageWithRegistry, error) {
+	data, err := json.Marshal(packages.BulkLookupPackagesJSONBody{Purls: &purls})
+	if err != nil {
+		return nil, fmt.Errorf("offline: encoding bulk lookup: %w", err)
+	}
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.ContentLength = int64(len(data))
+
+	resp, err := t.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, readAPIError("bulk lookup", resp)
+	}
+	var pkgs []packages.PackageWithRegistry
+	if err := json.NewDecoder(resp.Body).Decode(&pkgs); err != nil {
+		return nil, fmt.Errorf("offline: decoding bulk lookup: %w", err)
+	}
+	return pkgs, nil
+}
+
+// find returns the snapshot's package with the given registry and name.
+func (t *offlineTransport) find(registry, name string) *packages.PackageWithRegistry {
+	for _, entry := range t.store.Packages {
+		if entry.Package != nil && entry.Package.Registry.Name == registry && entry.Package.Name == name {
+			return entry.Package
+		}
+	}
+	return nil
+}
+
+// packagePath matches an escaped path ending in
+// /registries/{registry}/packages/{name}.
+func packagePath(path string) (registry, name string, ok bool) {
+	parts := strings.Split(path, "/")
+	n := len(parts)
+	if n < 4 || parts[n-4] != "registries" || parts[n-2] != "packages" {
+		return "", "", false
+	}
+	registry, err := url.PathUnescape(parts[n-3])
+	if err != nil {
+		return "", "", false
+	}
+	name, err = url.PathUnescape(parts[n-1])
+	if err != nil {
+		return "", "", false
+	}
+	return registry, name, true
+}
+
+func jsonResponse(req *http.Request, status int, v interface{}) (*http.Response, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("offline: encoding response: %w", err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
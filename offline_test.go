@@ -0,0 +1,86 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func offlineSnapshot() *Snapshot {
+	return NewSnapshot(map[string]*packages.PackageWithRegistry{
+		"pkg:npm/%40scope/offline": {
+			Name: "@scope/offline", Purl: "pkg:npm/%40scope/offline",
+			Registry: packages.Registry{Name: "npmjs.org"},
+		},
+	})
+}
+
+func TestOfflineOnly(t *testing.T) {
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer("http://127.0.0.1:1"),
+		WithOfflineStore(offlineSnapshot(), OfflineOnly),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := client.BulkLookupDetailed(ctx, []string{"pkg:npm/%40scope/offline", "pkg:gem/rails"})
+	if err != nil {
+		t.Fatalf("BulkLookupDetailed() error = %v", err)
+	}
+	if result.Packages["pkg:npm/%40scope/offline"] == nil {
+		t.Error("BulkLookupDetailed() missing snapshot package")
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "pkg:gem/rails" {
+		t.Errorf("Missing = %v, want [pkg:gem/rails]", result.Missing)
+	}
+
+	pkg, err := client.LookupByRegistryAndName(ctx, "npmjs.org", "@scope/offline")
+	if err != nil || pkg == nil || pkg.Name != "@scope/offline" {
+		t.Errorf("LookupByRegistryAndName() = %v, %v, want snapshot package", pkg, err)
+	}
+	pkg, err = client.LookupByRegistryAndName(ctx, "npmjs.org", "absent")
+	if err != nil || pkg != nil {
+		t.Errorf("LookupByRegistryAndName() = %v, %v, want nil, nil", pkg, err)
+	}
+
+	if _, err := client.GetAllVersions(ctx, "npmjs.org", "@scope/offline"); !errors.Is(err, ErrOffline) {
+		t.Errorf("GetAllVersions() error = %v, want ErrOffline", err)
+	}
+}
+
+func TestOfflineFallback(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithOfflineStore(offlineSnapshot(), OfflineFallback),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	results, err := client.BulkLookup(ctx, []string{"pkg:npm/%40scope/offline", "pkg:gem/rails"})
+	if err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	if results["pkg:npm/%40scope/offline"] == nil || results["pkg:gem/rails"] == nil {
+		t.Errorf("BulkLookup() = %v, want snapshot and API packages", results)
+	}
+
+	versions, err := client.GetAllVersions(ctx, "rubygems.org", "rails")
+	if err != nil || len(versions) != 3 {
+		t.Errorf("GetAllVersions() = %d versions, %v, want 3 from the API", len(versions), err)
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+}
@@ -0,0 +1,53 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Collective is the Open Collective account that funds a project, as known
+// to opencollective.ecosyste.ms. No OpenAPI spec of that service is
+// vendored, so its lookup endpoint and these fields are unverified.
+type Collective struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// URL is the collective's page on opencollective.com.
+	URL string `json:"html_url"`
+	// Balance is the collective's current balance in Currency.
+	Balance  float64 `json:"balance"`
+	Currency string  `json:"currency"`
+	// ContributeURL is where to contribute to the collective, derived from
+	// Slug.
+	ContributeURL string `json:"-"`
+}
+
+// GetCollectiveForProject returns the Open Collective account funding the
+// project at projectURL, usually its repository URL, from
+// opencollective.ecosyste.ms. It returns nil if the project is not known
+// or has no collective.
+func (c *Client) GetCollectiveForProject(ctx context.Context, projectURL string, opts ...CallOption) (*Collective, error) {
+	var project struct {
+		Collective *Collective `json:"collective"`
+	}
+	query := url.Values{"url": {projectURL}}
+	err := c.services.lookup(ServiceOpenCollective).GetJSON(ctx, "projects/lookup", query, &project, opts...)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	collective := project.Collective
+	if collective == nil || collective.Slug == "" {
+		return nil, nil
+	}
+	if collective.URL == "" {
+		collective.URL = fmt.Sprintf(fundingPlatforms["open_collective"], collective.Slug)
+	}
+	collective.ContributeURL = fmt.Sprintf(fundingPlatforms["open_collective"], collective.Slug) + "/donate"
+	return collective, nil
+}
@@ -0,0 +1,55 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCollectiveForProject(t *testing.T) {
+	opencollective := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/lookup" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("url") {
+		case "https://github.com/webpack/webpack":
+			_, _ = w.Write([]byte(`{"url": "https://github.com/webpack/webpack", "collective": {"slug": "webpack", "name": "webpack", "balance": 1234.5, "currency": "USD"}}`))
+		case "https://github.com/example/unfunded":
+			_, _ = w.Write([]byte(`{"url": "https://github.com/example/unfunded", "collective": null}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer opencollective.Close()
+
+	client, err := NewClient("test-agent/1.0", WithService(ServiceOpenCollective, ServiceConfig{BaseURL: opencollective.URL}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	collective, err := client.GetCollectiveForProject(context.Background(), "https://github.com/webpack/webpack")
+	if err != nil {
+		t.Fatalf("GetCollectiveForProject() error = %v", err)
+	}
+	want := Collective{
+		Slug:          "webpack",
+		Name:          "webpack",
+		URL:           "https://opencollective.com/webpack",
+		Balance:       1234.5,
+		Currency:      "USD",
+		ContributeURL: "https://opencollective.com/webpack/donate",
+	}
+	if collective == nil || *collective != want {
+		t.Errorf("GetCollectiveForProject() = %+v, want %+v", collective, want)
+	}
+
+	for _, projectURL := range []string{"https://github.com/example/unfunded", "https://github.com/example/unknown"} {
+		collective, err := client.GetCollectiveForProject(context.Background(), projectURL)
+		if err != nil || collective != nil {
+			t.Errorf("GetCollectiveForProject(%q) = %+v, %v, want nil, nil", projectURL, collective, err)
+		}
+	}
+}
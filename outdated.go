@@ -0,0 +1,183 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/versions"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// Update levels reported in OutdatedDependency.Behind.
+const (
+	BehindMajor = "major"
+	BehindMinor = "minor"
+	BehindPatch = "patch"
+	// BehindOther means the latest version is newer but its major, minor
+	// and patch numbers match or cannot be read, as with date-based or
+	// prerelease versions.
+	BehindOther = "other"
+)
+
+// OutdatedReport compares pinned versions with the latest releases.
+type OutdatedReport struct {
+	// Dependencies holds a result for each pinned PURL found, in request order.
+	Dependencies []OutdatedDependency
+	// Missing lists PURLs the API did not recognize, in request order.
+	Missing []string
+}
+
+// Outdated returns the dependencies that are behind their latest release.
+func (r *OutdatedReport) Outdated() []OutdatedDependency {
+	var outdated []OutdatedDependency
+	for _, d := range r.Dependencies {
+		if d.Behind != "" {
+			outdated = append(outdated, d)
+		}
+	}
+	return outdated
+}
+
+// OutdatedDependency compares one pinned version with the latest release.
+type OutdatedDependency struct {
+	PURL    string
+	Current string
+	Latest  string
+	// Behind is the most significant part of the version that is behind:
+	// BehindMajor, BehindMinor, BehindPatch or BehindOther. It is "" when
+	// the current version is the latest or newer.
+	Behind string
+	// MajorsBehind, MinorsBehind and PatchesBehind are the differences in
+	// the version numbers at and below the Behind level. For 1.2.3 against
+	// 3.0.1 MajorsBehind is 2 and the others are 0.
+	MajorsBehind  int
+	MinorsBehind  int
+	PatchesBehind int
+	// CurrentPublishedAt and LatestPublishedAt are zero when unknown.
+	CurrentPublishedAt time.Time
+	LatestPublishedAt  time.Time
+	// ReleaseAge is how long after the current version the latest one was
+	// published, or zero when either date is unknown.
+	ReleaseAge time.Duration
+}
+
+// OutdatedReport compares each pinned PURL's version with the latest
+// release of its package. Every PURL must have a version. The publish
+// dates of outdated versions are fetched with one request each, a few at a
+// time.
+func (c *Client) OutdatedReport(ctx context.Context, pinned []packageurl.PackageURL, opts ...CallOption) (*OutdatedReport, error) {
+	var lookup []string
+	seen := make(map[string]bool)
+	for _, p := range pinned {
+		if p.Version == "" {
+			return nil, fmt.Errorf("%s has no version", c.FormatPURL(p))
+		}
+		pkgPURL := p
+		pkgPURL.Version = ""
+		s := c.FormatPURL(pkgPURL)
+		if !seen[s] {
+			seen[s] = true
+			lookup = append(lookup, s)
+		}
+	}
+
+	result, err := c.BulkLookupDetailed(ctx, lookup, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	type staleDep struct {
+		index          int
+		registry, name string
+	}
+	report := &OutdatedReport{}
+	var stale []staleDep
+	for _, p := range pinned {
+		pkgPURL := p
+		pkgPURL.Version = ""
+		pkg := result.Packages[c.FormatPURL(pkgPURL)]
+		if pkg == nil {
+			report.Missing = append(report.Missing, c.FormatPURL(p))
+			continue
+		}
+
+		dep := OutdatedDependency{PURL: c.FormatPURL(p), Current: p.Version}
+		if pkg.LatestReleaseNumber != nil {
+			dep.Latest = *pkg.LatestReleaseNumber
+		}
+		if pkg.LatestReleasePublishedAt != nil {
+			dep.LatestPublishedAt = *pkg.LatestReleasePublishedAt
+		}
+		if dep.Latest != "" && versions.Compare(p.Type, dep.Current, dep.Latest) < 0 {
+			dep.Behind, dep.MajorsBehind, dep.MinorsBehind, dep.PatchesBehind = behind(dep.Current, dep.Latest)
+			stale = append(stale, staleDep{len(report.Dependencies), pkg.Registry.Name, pkg.Name})
+		}
+		report.Dependencies = append(report.Dependencies, dep)
+	}
+
+	var mu sync.Mutex
+	err = forEach(ctx, stale, maxRepositoryWorkers, func(ctx context.Context, sd staleDep) error {
+		v, err := c.GetVersion(ctx, sd.registry, sd.name, report.Dependencies[sd.index].Current, opts...)
+		if err != nil || v == nil {
+			return err
+		}
+		published, ok := parseTimestamp(v.PublishedAt)
+		if !ok {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		d := &report.Dependencies[sd.index]
+		d.CurrentPublishedAt = published
+		if !d.LatestPublishedAt.IsZero() {
+			d.ReleaseAge = d.LatestPublishedAt.Sub(d.CurrentPublishedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// behind compares the major, minor and patch numbers of two versions,
+// given that latest is newer than current.
+func behind(current, latest string) (level string, majors, minors, patches int) {
+	cur, ok1 := releaseNumbers(current)
+	lat, ok2 := releaseNumbers(latest)
+	if !ok1 || !ok2 {
+		return BehindOther, 0, 0, 0
+	}
+	switch {
+	case lat[0] > cur[0]:
+		return BehindMajor, lat[0] - cur[0], 0, 0
+	case lat[0] == cur[0] && lat[1] > cur[1]:
+		return BehindMinor, 0, lat[1] - cur[1], 0
+	case lat[0] == cur[0] && lat[1] == cur[1] && lat[2] > cur[2]:
+		return BehindPatch, 0, 0, lat[2] - cur[2]
+	}
+	return BehindOther, 0, 0, 0
+}
+
+// releaseNumbers reads the major, minor and patch numbers of a version such
+// as "v1.2.3-beta", treating missing parts as zero.
+func releaseNumbers(v string) ([3]int, bool) {
+	var nums [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	for i := 0; i < len(nums) && i < len(parts); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nums, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
@@ -0,0 +1,87 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+func TestOutdatedReport(t *testing.T) {
+	client, _ := newTestClient(t)
+	var pinned []packageurl.PackageURL
+	for _, s := range []string{"pkg:gem/rails@7.0.0", "pkg:npm/lodash@4.17.20", "pkg:gem/rails@7.1.3", "pkg:npm/nope@1.0.0"} {
+		p, err := packageurl.FromString(s)
+		if err != nil {
+			t.Fatalf("FromString(%q) error = %v", s, err)
+		}
+		pinned = append(pinned, p)
+	}
+
+	report, err := client.OutdatedReport(context.Background(), pinned)
+	if err != nil {
+		t.Fatalf("OutdatedReport() error = %v", err)
+	}
+	if len(report.Dependencies) != 3 {
+		t.Fatalf("OutdatedReport() returned %d dependencies, want 3", len(report.Dependencies))
+	}
+	if want := []string{"pkg:npm/nope@1.0.0"}; !reflect.DeepEqual(report.Missing, want) {
+		t.Errorf("Missing = %v, want %v", report.Missing, want)
+	}
+
+	rails := report.Dependencies[0]
+	if rails.Latest != "7.1.3" || rails.Behind != BehindMinor || rails.MinorsBehind != 1 {
+		t.Errorf("Dependencies[0] = %+v, want one minor behind 7.1.3", rails)
+	}
+	current := time.Date(2021, 12, 15, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2024, 1, 16, 22, 0, 0, 0, time.UTC)
+	if !rails.CurrentPublishedAt.Equal(current) || rails.ReleaseAge != latest.Sub(current) {
+		t.Errorf("Dependencies[0] dates = %v, %v, want %v, %v", rails.CurrentPublishedAt, rails.ReleaseAge, current, latest.Sub(current))
+	}
+
+	if lodash := report.Dependencies[1]; lodash.Behind != BehindPatch || lodash.PatchesBehind != 1 {
+		t.Errorf("Dependencies[1] = %+v, want one patch behind", lodash)
+	}
+	if upToDate := report.Dependencies[2]; upToDate.Behind != "" || upToDate.ReleaseAge != 0 {
+		t.Errorf("Dependencies[2] = %+v, want up to date", upToDate)
+	}
+	if got := len(report.Outdated()); got != 2 {
+		t.Errorf("Outdated() returned %d dependencies, want 2", got)
+	}
+}
+
+func TestOutdatedReportNoVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+	p, _ := packageurl.FromString("pkg:gem/rails")
+	if _, err := client.OutdatedReport(context.Background(), []packageurl.PackageURL{p}); err == nil {
+		t.Error("OutdatedReport() error = nil, want error for missing version")
+	}
+}
+
+func TestBehind(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		level           string
+		majors          int
+		minors          int
+		patches         int
+	}{
+		{"1.2.3", "3.0.1", BehindMajor, 2, 0, 0},
+		{"1.2.3", "1.5.0", BehindMinor, 0, 3, 0},
+		{"v1.2.3", "v1.2.7", BehindPatch, 0, 0, 4},
+		{"1.2", "1.2.1", BehindPatch, 0, 0, 1},
+		{"1.2.3-beta", "1.2.3", BehindOther, 0, 0, 0},
+		{"2024.01", "2024.02", BehindMinor, 0, 1, 0},
+		{"abc", "def", BehindOther, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		level, majors, minors, patches := behind(tt.current, tt.latest)
+		if level != tt.level || majors != tt.majors || minors != tt.minors || patches != tt.patches {
+			t.Errorf("behind(%q, %q) = %q, %d, %d, %d, want %q, %d, %d, %d", tt.current, tt.latest,
+				level, majors, minors, patches, tt.level, tt.majors, tt.minors, tt.patches)
+		}
+	}
+}
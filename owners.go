@@ -0,0 +1,80 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// GetOwner looks up a user or organization on a host such as "GitHub".
+// It returns nil if the owner is not known.
+func (c *Client) GetOwner(ctx context.Context, host, login string, opts ...CallOption) (*repos.Owner, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetOwner", "")
+	if err := checkPathSegments(host, login); err != nil {
+		return nil, fmt.Errorf("get owner: %w", err)
+	}
+	resp, err := c.reposAPI().GetHostOwnerWithResponse(ctx, host, login, call.reposEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get owner: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get owner", resp.HTTPResponse, resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// ListOwnerRepositories returns every repository owned by a user or
+// organization on a host, fetching all pages. It returns nil if the owner
+// is not known. If the overall timeout runs out, the repositories fetched
+// so far are returned along with an error wrapping ErrDeadlineBudgetExceeded.
+func (c *Client) ListOwnerRepositories(ctx context.Context, host, login string, opts ...CallOption) ([]repos.Repository, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "ListOwnerRepositories", "")
+	ctx, cancel := c.withBudget(ctx, call)
+	defer cancel()
+	perPage := c.perPage(call, DefaultPageSize)
+
+	var all []repos.Repository
+	for repo, err := range paginate(ctx, func(page int) (*Page[repos.Repository], error) {
+		resp, err := c.reposAPI().GetHostOwnerRepositoriesWithResponse(ctx, host, login, &repos.GetHostOwnerRepositoriesParams{
+			Page:    &page,
+			PerPage: &perPage,
+		}, call.reposEditors()...)
+		if err != nil {
+			return nil, fmt.Errorf("list owner repositories: %w", err)
+		}
+
+		if resp.StatusCode() == http.StatusNotFound {
+			return nil, nil
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return nil, newAPIError("list owner repositories", resp.HTTPResponse, resp.Body)
+		}
+
+		if resp.JSON200 == nil {
+			return nil, nil
+		}
+
+		return newPage(*resp.JSON200, resp.HTTPResponse, ListOptions{Page: page, PerPage: perPage}), nil
+	}) {
+		if err != nil {
+			if err = budgetErr(ctx, err); keepPartial(ctx, call, err) {
+				return all, err
+			}
+			return nil, err
+		}
+		all = append(all, repo)
+	}
+
+	return all, nil
+}
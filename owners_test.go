@@ -0,0 +1,49 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetOwner(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	owner, err := client.GetOwner(context.Background(), "GitHub", "rails")
+	if err != nil {
+		t.Fatalf("GetOwner() error = %v", err)
+	}
+	if owner == nil || *owner.Name != "Ruby on Rails" {
+		t.Errorf("GetOwner() = %v, want Ruby on Rails", owner)
+	}
+
+	owner, err = client.GetOwner(context.Background(), "GitHub", "missing")
+	if err != nil {
+		t.Fatalf("GetOwner() missing error = %v", err)
+	}
+	if owner != nil {
+		t.Errorf("GetOwner() missing = %v, want nil", owner)
+	}
+}
+
+func TestListOwnerRepositories(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	got, err := client.ListOwnerRepositories(context.Background(), "GitHub", "lodash", CallPageSize(1))
+	if err != nil {
+		t.Fatalf("ListOwnerRepositories() error = %v", err)
+	}
+	if len(got) != 1 || *got[0].FullName != "lodash/lodash" {
+		t.Errorf("ListOwnerRepositories() = %v, want [lodash/lodash]", got)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+
+	got, err = client.ListOwnerRepositories(context.Background(), "GitHub", "missing")
+	if err != nil {
+		t.Fatalf("ListOwnerRepositories() missing error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("ListOwnerRepositories() missing = %v, want nil", got)
+	}
+}
@@ -0,0 +1,135 @@
+package ecosystems
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Page is one page of a paginated listing together with the pagination
+// details the API reports in its response headers.
+type Page[T any] struct {
+	Items []T
+	// Page is the number of this page, starting at 1.
+	Page int
+	// PerPage is the page size the API applied.
+	PerPage int
+	// TotalCount and TotalPages cover the whole listing. They are zero when
+	// the API did not report them.
+	TotalCount int
+	TotalPages int
+	// NextPage is the number of the following page, or zero on the last page.
+	NextPage int
+}
+
+// newPage builds a Page from items and the headers of the response that
+// carried them. opts supplies the requested page and size for any values
+// missing from the headers.
+func newPage[T any](items []T, resp *http.Response, opts ListOptions) *Page[T] {
+	p := &Page[T]{Items: items, Page: opts.Page, PerPage: opts.PerPage}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+
+	var h http.Header
+	if resp != nil {
+		h = resp.Header
+	}
+	if n, ok := headerInt(h, "Current-Page"); ok {
+		p.Page = n
+	}
+	if n, ok := headerInt(h, "Page-Items"); ok {
+		p.PerPage = n
+	}
+	if n, ok := headerInt(h, "Total-Count"); ok {
+		p.TotalCount = n
+	}
+	if n, ok := headerInt(h, "Total-Pages"); ok {
+		p.TotalPages = n
+	}
+
+	switch next, ok := nextLinkPage(h.Get("Link")); {
+	case ok:
+		p.NextPage = next
+	case p.TotalPages > 0:
+		if p.Page < p.TotalPages {
+			p.NextPage = p.Page + 1
+		}
+	case p.PerPage > 0 && len(items) >= p.PerPage:
+		// No totals reported: a full page may be followed by another.
+		p.NextPage = p.Page + 1
+	}
+	return p
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// nextLinkPage returns the page query parameter of the rel="next" entry in
+// an RFC 8288 Link header.
+func nextLinkPage(link string) (int, bool) {
+	for _, entry := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(entry), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return 0, false
+		}
+		n, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// paginate yields the items of each page returned by fetch, starting at
+// page 1 and following each page's NextPage, until a page has no next
+// page, fetch returns a nil page or fails, or the caller stops iterating.
+// Following the pagination headers rather than comparing page lengths with
+// perPage keeps iterating when the server caps per_page below the size
+// requested. An error is yielded once, with a zero item.
+func paginate[T any](ctx context.Context, fetch func(page int) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 1; ; {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			p, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if p == nil {
+				return
+			}
+			for _, item := range p.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if p.NextPage <= page {
+				return
+			}
+			page = p.NextPage
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		opts     ListOptions
+		items    int
+		wantPage Page[int]
+	}{
+		{
+			name: "headers",
+			header: http.Header{
+				"Current-Page": {"2"},
+				"Page-Items":   {"10"},
+				"Total-Count":  {"35"},
+				"Total-Pages":  {"4"},
+				"Link":         {`<https://packages.ecosyste.ms/api/v1/registries?page=1>; rel="prev", <https://packages.ecosyste.ms/api/v1/registries?page=3&per_page=10>; rel="next"`},
+			},
+			opts:     ListOptions{Page: 2, PerPage: 10},
+			items:    10,
+			wantPage: Page[int]{Page: 2, PerPage: 10, TotalCount: 35, TotalPages: 4, NextPage: 3},
+		},
+		{
+			name:     "last page from totals",
+			header:   http.Header{"Total-Pages": {"4"}, "Total-Count": {"35"}},
+			opts:     ListOptions{Page: 4, PerPage: 10},
+			items:    5,
+			wantPage: Page[int]{Page: 4, PerPage: 10, TotalCount: 35, TotalPages: 4},
+		},
+		{
+			name:     "no headers, full page",
+			header:   http.Header{},
+			opts:     ListOptions{PerPage: 10},
+			items:    10,
+			wantPage: Page[int]{Page: 1, PerPage: 10, NextPage: 2},
+		},
+		{
+			name:     "no headers, short page",
+			header:   http.Header{},
+			opts:     ListOptions{Page: 3, PerPage: 10},
+			items:    4,
+			wantPage: Page[int]{Page: 3, PerPage: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPage(make([]int, tt.items), &http.Response{Header: tt.header}, tt.opts)
+			got.Items = nil
+			if !reflect.DeepEqual(*got, tt.wantPage) {
+				t.Errorf("newPage() = %+v, want %+v", *got, tt.wantPage)
+			}
+		})
+	}
+}
+
+func TestGetVersionsPage(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	page, err := client.GetVersionsPage(context.Background(), "rubygems.org", "rails", ListOptions{PerPage: 2})
+	if err != nil {
+		t.Fatalf("GetVersionsPage() error = %v", err)
+	}
+	if len(page.Items) != 2 || page.TotalCount != 3 || page.TotalPages != 2 || page.NextPage != 2 {
+		t.Errorf("GetVersionsPage() = %d items, %+v", len(page.Items), *page)
+	}
+
+	page, err = client.GetVersionsPage(context.Background(), "rubygems.org", "rails", ListOptions{Page: page.NextPage, PerPage: 2})
+	if err != nil {
+		t.Fatalf("GetVersionsPage() page 2 error = %v", err)
+	}
+	if len(page.Items) != 1 || page.Page != 2 || page.NextPage != 0 {
+		t.Errorf("GetVersionsPage() page 2 = %d items, %+v", len(page.Items), *page)
+	}
+
+	page, err = client.GetVersionsPage(context.Background(), "rubygems.org", "missing", ListOptions{})
+	if err != nil {
+		t.Fatalf("GetVersionsPage() missing error = %v", err)
+	}
+	if page != nil {
+		t.Errorf("GetVersionsPage() missing = %+v, want nil", page)
+	}
+}
+
+func TestPaginateServerCappedPageSize(t *testing.T) {
+	// The server applies per_page of at most 2, whatever the client asks.
+	names := []string{"a", "b", "c", "d", "e"}
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start, end := min((page-1)*2, len(names)), min(page*2, len(names))
+		w.Header().Set("Current-Page", strconv.Itoa(page))
+		w.Header().Set("Total-Pages", "3")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(names[start:end])
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var got []string
+	for name, err := range client.GetRegistryPackageNames(context.Background(), "npmjs.org") {
+		if err != nil {
+			t.Fatalf("GetRegistryPackageNames() error = %v", err)
+		}
+		got = append(got, name)
+	}
+	if !reflect.DeepEqual(got, names) || requests != 3 {
+		t.Errorf("GetRegistryPackageNames() = %v in %d requests, want %v in 3", got, requests, names)
+	}
+}
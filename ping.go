@@ -0,0 +1,90 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ServiceStatus is the outcome of pinging one ecosyste.ms service.
+type ServiceStatus struct {
+	// Service is "packages" or "repos".
+	Service string
+	// URL is the service's base URL.
+	URL string
+	// StatusCode is the HTTP status returned, or 0 if no response arrived.
+	StatusCode int
+	// Latency is how long the request took.
+	Latency time.Duration
+	// Err is why the service is unavailable, or nil if it responded with
+	// a 2xx status.
+	Err error
+}
+
+// OK reports whether the service responded successfully.
+func (s ServiceStatus) OK() bool {
+	return s.Err == nil
+}
+
+// Ping makes one lightweight request to each enabled service and
+// reports its status and latency, for use in readiness probes. The
+// services are pinged concurrently. The returned error joins the errors
+// of the services that failed.
+func (c *Client) Ping(ctx context.Context, opts ...CallOption) ([]ServiceStatus, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "Ping", "")
+
+	type target struct {
+		service *Service
+		path    string
+	}
+	targets := []target{
+		{c.services.lookup(ServicePackages), "registries"},
+		{c.services.lookup(ServiceRepos), "hosts"},
+	}
+	targets = slices.DeleteFunc(targets, func(t target) bool {
+		return t.service.Disabled()
+	})
+
+	statuses := make([]ServiceStatus, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = pingService(ctx, call, t.service, t.path)
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, s := range statuses {
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Service, s.Err))
+		}
+	}
+	return statuses, errors.Join(errs...)
+}
+
+func pingService(ctx context.Context, call *callConfig, service *Service, path string) ServiceStatus {
+	status := ServiceStatus{Service: service.Name(), URL: service.URL()}
+	start := time.Now()
+	resp, err := service.raw.get(ctx, call, path)
+	if err != nil {
+		status.Latency = time.Since(start)
+		status.Err = err
+		return status
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		status.Err = readAPIError("ping", resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	status.Latency = time.Since(start)
+	status.StatusCode = resp.StatusCode
+	return status
+}
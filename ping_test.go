@@ -0,0 +1,56 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	statuses, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Ping() returned %d statuses, want 2", len(statuses))
+	}
+	for i, want := range []string{"packages", "repos"} {
+		s := statuses[i]
+		if s.Service != want || !s.OK() || s.StatusCode != http.StatusOK || s.Latency <= 0 {
+			t.Errorf("Ping()[%d] = %+v, want healthy %s", i, s, want)
+		}
+	}
+	if got := srv.Requests(); len(got) != 2 {
+		t.Errorf("Ping() requests = %v, want 2", got)
+	}
+}
+
+func TestPingUnhealthy(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	_, srv := newTestClient(t)
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithReposServer(down.URL),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	statuses, err := client.Ping(context.Background())
+	if err == nil {
+		t.Fatal("Ping() error = nil, want repos failure")
+	}
+	if !statuses[0].OK() {
+		t.Errorf("packages status = %+v, want healthy", statuses[0])
+	}
+	if statuses[1].OK() || statuses[1].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("repos status = %+v, want 503", statuses[1])
+	}
+}
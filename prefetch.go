@@ -0,0 +1,63 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxPrefetchWorkers bounds the bulk lookups a prefetch runs at once.
+const maxPrefetchWorkers = 4
+
+// PrefetchResult is the outcome of a prefetch.
+type PrefetchResult struct {
+	// Snapshot holds the packages fetched.
+	Snapshot *Snapshot
+	// Err joins the errors of batches that failed. Prefetching is
+	// best-effort, so failed batches are left out of Snapshot.
+	Err error
+}
+
+// Prefetch starts looking up purls in the background, a few batches at a
+// time, and returns a channel that receives the result once they are done.
+// Callers that only want to warm data may ignore the channel. The client has
+// no cache of its own: responses pass through its HTTP client, so a caching
+// transport given with WithHTTPClient or a Recorder is warmed, and the
+// snapshot can be passed to LookupDelta so later lookups only fetch what is
+// missing. Cancel ctx to stop the prefetch early.
+func (c *Client) Prefetch(ctx context.Context, purls []string, opts ...CallOption) <-chan PrefetchResult {
+	var batches [][]string
+	for i := 0; i < len(purls); i += c.bulkBatchSize {
+		batches = append(batches, purls[i:min(i+c.bulkBatchSize, len(purls))])
+	}
+
+	ch := make(chan PrefetchResult, 1)
+	go func() {
+		defer close(ch)
+		snapshot := &Snapshot{TakenAt: time.Now(), Packages: make(map[string]*SnapshotEntry, len(purls))}
+		var (
+			mu   sync.Mutex
+			errs []error
+		)
+		err := forEach(ctx, batches, maxPrefetchWorkers, func(ctx context.Context, batch []string) error {
+			results, err := c.BulkLookup(ctx, batch, opts...)
+			fetchedAt := time.Now()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return nil
+			}
+			for purl, pkg := range results {
+				snapshot.Packages[purl] = &SnapshotEntry{FetchedAt: fetchedAt, Package: pkg}
+			}
+			return nil
+		})
+		if err != nil && len(errs) == 0 {
+			errs = append(errs, err)
+		}
+		ch <- PrefetchResult{Snapshot: snapshot, Err: errors.Join(errs...)}
+	}()
+	return ch
+}
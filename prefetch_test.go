@@ -0,0 +1,42 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPrefetch(t *testing.T) {
+	client, srv := newTestClient(t)
+	purls := []string{"pkg:gem/rails", "pkg:npm/lodash"}
+	for i := range MaxBulkLookupSize {
+		purls = append(purls, fmt.Sprintf("pkg:npm/missing-%d", i))
+	}
+
+	result := <-client.Prefetch(context.Background(), purls)
+	if result.Err != nil {
+		t.Fatalf("Prefetch() error = %v", result.Err)
+	}
+	snapshot := result.Snapshot
+	if len(snapshot.Packages) != 2 || snapshot.Packages["pkg:gem/rails"] == nil || snapshot.Packages["pkg:npm/lodash"] == nil {
+		t.Errorf("Prefetch() snapshot has %d packages, want rails and lodash", len(snapshot.Packages))
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("Prefetch() made %d requests, want 2 batches", got)
+	}
+}
+
+func TestPrefetchCanceled(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := <-client.Prefetch(ctx, []string{"pkg:gem/rails"})
+	if !errors.Is(result.Err, context.Canceled) {
+		t.Errorf("Prefetch() error = %v, want %v", result.Err, context.Canceled)
+	}
+	if result.Snapshot == nil || len(result.Snapshot.Packages) != 0 {
+		t.Errorf("Prefetch() snapshot = %v, want empty", result.Snapshot)
+	}
+}
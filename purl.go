@@ -3,15 +3,20 @@ package ecosystems
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
+	"unicode"
 
 	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/repos"
 	packageurl "github.com/git-pkgs/packageurl-go"
 )
 
-// PURLToRegistry converts a PURL type to the ecosyste.ms registry name.
+// PURLToRegistry converts a PURL to the ecosyste.ms registry name.
+// See ResolveRegistry for how the registry is chosen.
 func PURLToRegistry(purl packageurl.PackageURL) string {
-	return purlTypeToRegistry[purl.Type]
+	registry, _ := ResolveRegistry(purl)
+	return registry
 }
 
 // PURLToName converts a PURL to the ecosyste.ms package name format.
@@ -26,97 +31,421 @@ func PURLToName(purl packageurl.PackageURL) string {
 	case packageurl.TypeMaven:
 		// Maven uses colon separator for group:artifact
 		return fmt.Sprintf("%s:%s", purl.Namespace, purl.Name)
-	case packageurl.TypeApk:
-		// APK packages ignore namespace
+	case packageurl.TypeApk, packageurl.TypeDebian, packageurl.TypeRPM, packageurl.TypeAlpm:
+		// Distro packages use the namespace for the distribution, which
+		// selects the registry rather than forming part of the name
 		return name
+	case packageurl.TypeGolang:
+		// proxy.golang.org escapes uppercase letters in module paths
+		return escapeModulePath(fmt.Sprintf("%s/%s", purl.Namespace, purl.Name))
+	case packageurl.TypeSwift:
+		// swiftpackageindex.com names packages by the repository URL in the
+		// Swift Package Index, such as https://github.com/apple/swift-nio.git
+		return swiftPackageName(purl.Namespace, purl.Name)
+	case packageurl.TypeCocoapods:
+		// Subspecs written as pkg:cocoapods/GoogleUtilities/NSData+zlib are
+		// published as part of their root pod
+		root, _, _ := strings.Cut(purl.Namespace, "/")
+		return root
+	case packageurl.TypeCarthage:
+		// Carthage names GitHub dependencies owner/repo, as in a Cartfile
+		return fmt.Sprintf("%s/%s", strings.TrimPrefix(purl.Namespace, "github.com/"), purl.Name)
 	default:
 		// Most ecosystems use slash separator
 		return fmt.Sprintf("%s/%s", purl.Namespace, purl.Name)
 	}
 }
 
+// PackageToPURL converts a package returned by the API to a PURL without a
+// version. The package's own purl field is used when present; otherwise the
+// PURL is built from its ecosystem and name.
+func PackageToPURL(pkg packages.Package) (packageurl.PackageURL, error) {
+	if pkg.Purl != "" {
+		return ParsePURL(pkg.Purl)
+	}
+	purlType := EcosystemToPURLType(pkg.Ecosystem)
+	if purlType == "" {
+		return packageurl.PackageURL{}, fmt.Errorf("unsupported ecosystem: %q", pkg.Ecosystem)
+	}
+	if pkg.Name == "" {
+		return packageurl.PackageURL{}, fmt.Errorf("package has no name")
+	}
+	namespace, name := NameToPURLParts(purlType, pkg.Name)
+	return packageurl.PackageURL{Type: purlType, Namespace: namespace, Name: name}, nil
+}
+
+// VersionToPURL converts a package version returned by the API to a PURL.
+// The version's own purl field is used when present; otherwise the PURL is
+// built from pkg and the version number.
+func VersionToPURL(pkg packages.Package, version packages.Version) (packageurl.PackageURL, error) {
+	if version.Purl != "" {
+		return ParsePURL(version.Purl)
+	}
+	purl, err := PackageToPURL(pkg)
+	if err != nil {
+		return purl, err
+	}
+	purl.Version = version.Number
+	return purl, nil
+}
+
+// NameToPURLParts splits an ecosyste.ms package name into PURL namespace and
+// name for the given PURL type. It is the inverse of PURLToName.
+func NameToPURLParts(purlType, name string) (namespace, purlName string) {
+	switch purlType {
+	case packageurl.TypeMaven:
+		// Maven uses colon separator for group:artifact
+		if i := strings.LastIndex(name, ":"); i >= 0 {
+			return name[:i], name[i+1:]
+		}
+	case packageurl.TypeGolang:
+		name = unescapeModulePath(name)
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			return name[:i], name[i+1:]
+		}
+	case packageurl.TypeSwift:
+		name = strings.TrimSuffix(name, ".git")
+		if _, rest, ok := strings.Cut(name, "://"); ok {
+			name = rest
+		}
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			return name[:i], name[i+1:]
+		}
+	case packageurl.TypeNPM, packageurl.TypeComposer, packageurl.TypeCarthage,
+		packageurl.TypeDocker, packageurl.TypeGithub, packageurl.TypeBitbucket, packageurl.TypeHuggingface:
+		// Slash-separated namespaces: npm scopes, Go module paths, vendors
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			return name[:i], name[i+1:]
+		}
+	}
+	return "", name
+}
+
 // LookupPURL looks up a package by its PURL using the registry/name endpoint.
 // This is useful when you need the full Package type rather than PackageWithRegistry.
-func (c *Client) LookupPURL(ctx context.Context, purl packageurl.PackageURL) (*packages.Package, error) {
-	registry := PURLToRegistry(purl)
-	if registry == "" {
-		return nil, fmt.Errorf("unsupported PURL type: %s", purl.Type)
+// A subpath is ignored; use LookupPURLSubpath to take it into account.
+func (c *Client) LookupPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*packages.Package, error) {
+	registries, err := c.registriesFor(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
 	}
 	name := PURLToName(purl)
-	return c.LookupByRegistryAndName(ctx, registry, name)
+	for _, registry := range registries {
+		pkg, err := c.LookupByRegistryAndName(ctx, registry, name, opts...)
+		if err != nil || pkg != nil {
+			return pkg, err
+		}
+	}
+	return nil, nil
 }
 
 // GetVersionPURL gets a specific version using a PURL.
-func (c *Client) GetVersionPURL(ctx context.Context, purl packageurl.PackageURL) (*packages.VersionWithDependencies, error) {
+func (c *Client) GetVersionPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*packages.VersionWithDependencies, error) {
 	if purl.Version == "" {
 		return nil, fmt.Errorf("PURL has no version")
 	}
-	registry := PURLToRegistry(purl)
-	if registry == "" {
-		return nil, fmt.Errorf("unsupported PURL type: %s", purl.Type)
+	registries, err := c.registriesFor(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
 	}
 	name := PURLToName(purl)
-	return c.GetVersion(ctx, registry, name, purl.Version)
+	for _, registry := range registries {
+		v, err := c.GetVersion(ctx, registry, name, purl.Version, opts...)
+		if err != nil || v != nil {
+			return v, err
+		}
+	}
+	return nil, nil
 }
 
 // GetAllVersionsPURL gets all versions for a package using a PURL.
-func (c *Client) GetAllVersionsPURL(ctx context.Context, purl packageurl.PackageURL) ([]packages.Version, error) {
-	registry := PURLToRegistry(purl)
-	if registry == "" {
-		return nil, fmt.Errorf("unsupported PURL type: %s", purl.Type)
+func (c *Client) GetAllVersionsPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) ([]packages.Version, error) {
+	registries, err := c.registriesFor(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
+	}
+	name := PURLToName(purl)
+	for _, registry := range registries {
+		versions, err := c.GetAllVersions(ctx, registry, name, opts...)
+		if err != nil || versions != nil {
+			return versions, err
+		}
+	}
+	return nil, nil
+}
+
+// GetVersionNumbersPURL gets the version numbers of a package using a PURL.
+func (c *Client) GetVersionNumbersPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) ([]string, error) {
+	registries, err := c.registriesFor(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
 	}
 	name := PURLToName(purl)
-	return c.GetAllVersions(ctx, registry, name)
+	for _, registry := range registries {
+		numbers, err := c.GetVersionNumbers(ctx, registry, name, opts...)
+		if err != nil || numbers != nil {
+			return numbers, err
+		}
+	}
+	return nil, nil
+}
+
+// escapeModulePath escapes a Go module path the way the module proxy
+// protocol does, replacing each uppercase letter with an exclamation mark
+// followed by its lowercase form: github.com/Azure/go-autorest becomes
+// github.com/!azure/go-autorest.
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if 'A' <= r && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unescapeModulePath reverses escapeModulePath. Paths that are not escaped
+// are returned unchanged.
+func unescapeModulePath(path string) string {
+	if !strings.Contains(path, "!") {
+		return path
+	}
+	var b strings.Builder
+	bang := false
+	for _, r := range path {
+		switch {
+		case bang && 'a' <= r && r <= 'z':
+			r -= 'a' - 'A'
+		case bang:
+			b.WriteByte('!')
+		case r == '!':
+			bang = true
+			continue
+		}
+		bang = false
+		b.WriteRune(r)
+	}
+	if bang {
+		b.WriteByte('!')
+	}
+	return b.String()
 }
 
+// swiftPackageName returns the Swift Package Index URL of a Swift package
+// from its PURL namespace, the repository host and owner, and name. A
+// namespace that already includes a scheme is kept as is.
+func swiftPackageName(namespace, name string) string {
+	if !strings.Contains(namespace, "://") {
+		namespace = "https://" + namespace
+	}
+	return fmt.Sprintf("%s/%s.git", namespace, strings.TrimSuffix(name, ".git"))
+}
+
+// purlTypeToRepositoryHost maps repository hosting PURL types to their hosts.
+var purlTypeToRepositoryHost = map[string]string{
+	packageurl.TypeGithub:    "github.com",
+	packageurl.TypeGitlab:    "gitlab.com",
+	packageurl.TypeBitbucket: "bitbucket.org",
+}
+
+// LookupRepositoryPURL looks up the repository for a pkg:github, pkg:gitlab
+// or pkg:bitbucket PURL using the repos API.
+func (c *Client) LookupRepositoryPURL(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (*repos.Repository, error) {
+	host := purlTypeToRepositoryHost[purl.Type]
+	if host == "" {
+		return nil, fmt.Errorf("unsupported repository PURL type: %s", purl.Type)
+	}
+	if purl.Namespace == "" {
+		return nil, fmt.Errorf("repository PURL has no owner")
+	}
+	return c.GetRepository(ctx, fmt.Sprintf("https://%s/%s/%s", host, purl.Namespace, purl.Name), opts...)
+}
+
+// MaxPURLLength is the longest PURL string ParsePURL accepts.
+const MaxPURLLength = 4096
+
 // ParsePURL parses a PURL string.
 func ParsePURL(s string) (packageurl.PackageURL, error) {
+	return PackageURLParser{}.Parse(s)
+}
+
+// PURLParser parses and serializes Package URLs.
+// Implement it to plug a different PURL library or spec-version-specific
+// behavior into a Client via WithPURLParser.
+type PURLParser interface {
+	Parse(s string) (packageurl.PackageURL, error)
+	Format(purl packageurl.PackageURL) string
+}
+
+// PackageURLParser is the default PURLParser, backed by packageurl-go.
+type PackageURLParser struct{}
+
+// Parse parses a PURL string, accepting bare PURLs without the pkg: scheme.
+// Inputs longer than MaxPURLLength or containing control characters are
+// rejected, since PURLs frequently come from untrusted SBOM content.
+func (PackageURLParser) Parse(s string) (packageurl.PackageURL, error) {
+	if len(s) > MaxPURLLength {
+		return packageurl.PackageURL{}, fmt.Errorf("PURL exceeds %d bytes", MaxPURLLength)
+	}
+	if i := strings.IndexFunc(s, unicode.IsControl); i >= 0 {
+		return packageurl.PackageURL{}, fmt.Errorf("PURL contains control character at byte %d", i)
+	}
 	// Handle bare PURLs without the pkg: scheme
 	if !strings.HasPrefix(s, "pkg:") {
 		s = "pkg:" + s
 	}
-	return packageurl.FromString(s)
+	purl, err := packageurl.FromString(s)
+	if err != nil {
+		return purl, err
+	}
+	// packageurl-go percent-encodes the subpath when formatting but leaves
+	// it encoded when parsing
+	if purl.Subpath, err = url.PathUnescape(purl.Subpath); err != nil {
+		return packageurl.PackageURL{}, fmt.Errorf("invalid PURL subpath: %w", err)
+	}
+	// Percent-encoding can smuggle control characters into decoded components
+	parts := []string{purl.Namespace, purl.Name, purl.Version, purl.Subpath}
+	for _, q := range purl.Qualifiers {
+		parts = append(parts, q.Value)
+	}
+	for _, part := range parts {
+		if strings.IndexFunc(part, unicode.IsControl) >= 0 {
+			return packageurl.PackageURL{}, fmt.Errorf("PURL contains encoded control character")
+		}
+	}
+	return purl, nil
+}
+
+// Format serializes a PURL to its canonical string form.
+func (PackageURLParser) Format(purl packageurl.PackageURL) string {
+	return purl.ToString()
+}
+
+// ParsePURL parses a PURL string using the client's configured PURLParser.
+func (c *Client) ParsePURL(s string) (packageurl.PackageURL, error) {
+	return c.purlParser.Parse(s)
+}
+
+// FormatPURL serializes a PURL using the client's configured PURLParser.
+func (c *Client) FormatPURL(purl packageurl.PackageURL) string {
+	return c.purlParser.Format(purl)
 }
 
 // purlTypeToRegistry maps PURL types to ecosyste.ms registry names.
 var purlTypeToRegistry = map[string]string{
-	packageurl.TypeAlpm:       "archlinux.org",
-	packageurl.TypeApk:        "alpine-edge",
-	packageurl.TypeBitbucket:  "",
-	packageurl.TypeBitnami:    "",
-	packageurl.TypeBower:      "bower.io",
-	packageurl.TypeCargo:      "crates.io",
-	packageurl.TypeCarthage:   "carthage",
-	packageurl.TypeChef:       "supermarket.chef.io",
-	packageurl.TypeChocolatey: "chocolatey.org",
-	packageurl.TypeClojars:    "clojars.org",
-	packageurl.TypeCocoapods:  "cocoapods.org",
-	packageurl.TypeComposer:   "packagist.org",
-	packageurl.TypeConan:      "conan.io",
-	packageurl.TypeConda:      "anaconda.org",
-	packageurl.TypeCpan:       "metacpan.org",
-	packageurl.TypeCran:       "cran.r-project.org",
-	packageurl.TypeDocker:     "hub.docker.com",
-	packageurl.TypeElm:        "package.elm-lang.org",
-	packageurl.TypeGem:        "rubygems.org",
-	packageurl.TypeGeneric:    "",
-	packageurl.TypeGithub:     "",
-	packageurl.TypeGolang:     "proxy.golang.org",
-	packageurl.TypeHackage:    "hackage.haskell.org",
-	packageurl.TypeHex:        "hex.pm",
+	packageurl.TypeAlpm:        "archlinux.org",
+	packageurl.TypeApk:         "alpine-edge",
+	packageurl.TypeBitbucket:   "",
+	packageurl.TypeBitnami:     "",
+	packageurl.TypeBower:       "bower.io",
+	packageurl.TypeCargo:       "crates.io",
+	packageurl.TypeCarthage:    "carthage",
+	packageurl.TypeChef:        "supermarket.chef.io",
+	packageurl.TypeChocolatey:  "chocolatey.org",
+	packageurl.TypeClojars:     "clojars.org",
+	packageurl.TypeCocoapods:   "cocoapods.org",
+	packageurl.TypeComposer:    "packagist.org",
+	packageurl.TypeConan:       "conan.io",
+	packageurl.TypeConda:       "anaconda.org",
+	packageurl.TypeCpan:        "metacpan.org",
+	packageurl.TypeCran:        "cran.r-project.org",
+	packageurl.TypeDocker:      "hub.docker.com",
+	packageurl.TypeElm:         "package.elm-lang.org",
+	packageurl.TypeGem:         "rubygems.org",
+	packageurl.TypeGeneric:     "",
+	packageurl.TypeGithub:      "",
+	packageurl.TypeGolang:      "proxy.golang.org",
+	packageurl.TypeHackage:     "hackage.haskell.org",
+	packageurl.TypeHex:         "hex.pm",
 	packageurl.TypeHuggingface: "",
-	packageurl.TypeMaven:      "repo1.maven.org",
-	packageurl.TypeNPM:        "npmjs.org",
-	packageurl.TypeNuget:      "nuget.org",
-	packageurl.TypeOCI:        "",
-	packageurl.TypePub:        "pub.dev",
-	packageurl.TypePyPi:       "pypi.org",
-	packageurl.TypeRPM:        "",
-	packageurl.TypeSwift:      "swiftpackageindex.com",
-	"brew":                    "formulae.brew.sh",
-	"deb":                     "debian",
-	"julia":                   "juliahub.com",
-	"puppet":                  "forge.puppet.com",
+	packageurl.TypeMaven:       "repo1.maven.org",
+	packageurl.TypeNPM:         "npmjs.org",
+	packageurl.TypeNuget:       "nuget.org",
+	packageurl.TypeOCI:         "",
+	packageurl.TypePub:         "pub.dev",
+	packageurl.TypePyPi:        "pypi.org",
+	packageurl.TypeRPM:         "",
+	packageurl.TypeSwift:       "swiftpackageindex.com",
+	"brew":                     "formulae.brew.sh",
+	"deb":                      "debian",
+	"dub":                      "code.dlang.org",
+	"haxelib":                  "lib.haxe.org",
+	"julia":                    "juliahub.com",
+	"luarocks":                 "luarocks.org",
+	"nimble":                   "nimble.directory",
+	"opam":                     "opam.ocaml.org",
+	"puppet":                   "forge.puppet.com",
+	"racket":                   "pkgs.racket-lang.org",
+	"spack":                    "spack.io",
+	"vcpkg":                    "vcpkg.io",
+}
+
+// RegistryToPURLType returns the PURL type for an ecosyste.ms registry name,
+// such as "gem" for "rubygems.org". It returns "" for unknown registries.
+func RegistryToPURLType(registry string) string {
+	return registryToPURLType[registry]
+}
+
+// EcosystemToPURLType returns the PURL type for an ecosyste.ms ecosystem
+// name, such as "gem" for "rubygems". It returns "" for unknown ecosystems.
+func EcosystemToPURLType(ecosystem string) string {
+	return ecosystemToPURLType[ecosystem]
+}
+
+// registryToPURLType is the inverse of purlTypeToRegistry.
+var registryToPURLType = func() map[string]string {
+	m := make(map[string]string, len(purlTypeToRegistry))
+	for t, registry := range purlTypeToRegistry {
+		if registry != "" {
+			m[registry] = t
+		}
+	}
+	return m
+}()
+
+// ecosystemToPURLType maps ecosyste.ms ecosystem names to PURL types.
+var ecosystemToPURLType = map[string]string{
+	"actions":    packageurl.TypeGithub,
+	"alpine":     packageurl.TypeApk,
+	"bower":      packageurl.TypeBower,
+	"cargo":      packageurl.TypeCargo,
+	"carthage":   packageurl.TypeCarthage,
+	"chef":       packageurl.TypeChef,
+	"chocolatey": packageurl.TypeChocolatey,
+	"clojars":    packageurl.TypeClojars,
+	"cocoapods":  packageurl.TypeCocoapods,
+	"conan":      packageurl.TypeConan,
+	"conda":      packageurl.TypeConda,
+	"cpan":       packageurl.TypeCpan,
+	"cran":       packageurl.TypeCran,
+	"debian":     "deb",
+	"docker":     packageurl.TypeDocker,
+	"dub":        "dub",
+	"elm":        packageurl.TypeElm,
+	"go":         packageurl.TypeGolang,
+	"hackage":    packageurl.TypeHackage,
+	"haxelib":    "haxelib",
+	"hex":        packageurl.TypeHex,
+	"homebrew":   "brew",
+	"julia":      "julia",
+	"luarocks":   "luarocks",
+	"maven":      packageurl.TypeMaven,
+	"nimble":     "nimble",
+	"npm":        packageurl.TypeNPM,
+	"nuget":      packageurl.TypeNuget,
+	"opam":       "opam",
+	"packagist":  packageurl.TypeComposer,
+	"pacman":     packageurl.TypeAlpm,
+	"pub":        packageurl.TypePub,
+	"puppet":     "puppet",
+	"pypi":       packageurl.TypePyPi,
+	"racket":     "racket",
+	"rubygems":   packageurl.TypeGem,
+	"spack":      "spack",
+	"swiftpm":    packageurl.TypeSwift,
+	"vcpkg":      "vcpkg",
 }
 
 // SupportedPURLTypes returns all PURL types that have registry mappings.
@@ -1,8 +1,11 @@
 package ecosystems
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/ecosyste-ms/ecosystems-go/packages"
 	packageurl "github.com/git-pkgs/packageurl-go"
 )
 
@@ -38,11 +41,65 @@ func TestPURLToRegistry(t *testing.T) {
 	}
 }
 
-func TestPURLToName(t *testing.T) {
+func TestRegistryToPURLType(t *testing.T) {
+	tests := []struct {
+		registry string
+		expected string
+	}{
+		{"npmjs.org", "npm"},
+		{"rubygems.org", "gem"},
+		{"repo1.maven.org", "maven"},
+		{"proxy.golang.org", "golang"},
+		{"formulae.brew.sh", "brew"},
+		{"debian", "deb"},
+		{"unknown.example", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			if got := RegistryToPURLType(tt.registry); got != tt.expected {
+				t.Errorf("RegistryToPURLType(%q) = %q, want %q", tt.registry, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistryToPURLTypeRoundTrip(t *testing.T) {
+	for _, purlType := range SupportedPURLTypes() {
+		registry := PURLToRegistry(packageurl.PackageURL{Type: purlType})
+		if got := RegistryToPURLType(registry); got != purlType {
+			t.Errorf("RegistryToPURLType(%q) = %q, want %q", registry, got, purlType)
+		}
+	}
+}
+
+func TestEcosystemToPURLType(t *testing.T) {
 	tests := []struct {
-		name      string
-		purl      packageurl.PackageURL
+		ecosystem string
 		expected  string
+	}{
+		{"rubygems", "gem"},
+		{"npm", "npm"},
+		{"packagist", "composer"},
+		{"go", "golang"},
+		{"homebrew", "brew"},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ecosystem, func(t *testing.T) {
+			if got := EcosystemToPURLType(tt.ecosystem); got != tt.expected {
+				t.Errorf("EcosystemToPURLType(%q) = %q, want %q", tt.ecosystem, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPURLToName(t *testing.T) {
+	tests := []struct {
+		name     string
+		purl     packageurl.PackageURL
+		expected string
 	}{
 		{
 			name:     "simple npm package",
@@ -74,11 +131,56 @@ func TestPURLToName(t *testing.T) {
 			purl:     packageurl.PackageURL{Type: packageurl.TypeGolang, Namespace: "github.com/go-git", Name: "go-git"},
 			expected: "github.com/go-git/go-git",
 		},
+		{
+			name:     "deb ignores distro namespace",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeDebian, Namespace: "ubuntu", Name: "curl"},
+			expected: "curl",
+		},
+		{
+			name:     "rpm ignores distro namespace",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeRPM, Namespace: "fedora", Name: "curl"},
+			expected: "curl",
+		},
 		{
 			name:     "apk ignores namespace",
 			purl:     packageurl.PackageURL{Type: packageurl.TypeApk, Namespace: "alpine", Name: "curl"},
 			expected: "curl",
 		},
+		{
+			name:     "go module with uppercase letters",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeGolang, Namespace: "github.com/Azure", Name: "azure-sdk-for-go"},
+			expected: "github.com/!azure/azure-sdk-for-go",
+		},
+		{
+			name:     "swift package index URL",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeSwift, Namespace: "github.com/apple", Name: "swift-nio"},
+			expected: "https://github.com/apple/swift-nio.git",
+		},
+		{
+			name:     "swift namespace with scheme",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeSwift, Namespace: "https://gitlab.com/group", Name: "lib.git"},
+			expected: "https://gitlab.com/group/lib.git",
+		},
+		{
+			name:     "cocoapods pod",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeCocoapods, Name: "AFNetworking"},
+			expected: "AFNetworking",
+		},
+		{
+			name:     "cocoapods subspec uses root pod",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeCocoapods, Namespace: "GoogleUtilities", Name: "NSData+zlib"},
+			expected: "GoogleUtilities",
+		},
+		{
+			name:     "carthage github dependency",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeCarthage, Namespace: "ReactiveX", Name: "RxSwift"},
+			expected: "ReactiveX/RxSwift",
+		},
+		{
+			name:     "carthage drops github host",
+			purl:     packageurl.PackageURL{Type: packageurl.TypeCarthage, Namespace: "github.com/ReactiveX", Name: "RxSwift"},
+			expected: "ReactiveX/RxSwift",
+		},
 	}
 
 	for _, tt := range tests {
@@ -91,6 +193,141 @@ func TestPURLToName(t *testing.T) {
 	}
 }
 
+func TestPackageToPURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      packages.Package
+		expected string
+	}{
+		{
+			name:     "uses purl field",
+			pkg:      packages.Package{Ecosystem: "npm", Name: "ignored", Purl: "pkg:npm/lodash"},
+			expected: "pkg:npm/lodash",
+		},
+		{
+			name:     "gem",
+			pkg:      packages.Package{Ecosystem: "rubygems", Name: "rails"},
+			expected: "pkg:gem/rails",
+		},
+		{
+			name:     "scoped npm",
+			pkg:      packages.Package{Ecosystem: "npm", Name: "@babel/core"},
+			expected: "pkg:npm/%40babel/core",
+		},
+		{
+			name:     "maven",
+			pkg:      packages.Package{Ecosystem: "maven", Name: "org.apache.commons:commons-lang3"},
+			expected: "pkg:maven/org.apache.commons/commons-lang3",
+		},
+		{
+			name:     "go module",
+			pkg:      packages.Package{Ecosystem: "go", Name: "github.com/go-git/go-git"},
+			expected: "pkg:golang/github.com/go-git/go-git",
+		},
+		{
+			name:     "packagist",
+			pkg:      packages.Package{Ecosystem: "packagist", Name: "symfony/console"},
+			expected: "pkg:composer/symfony/console",
+		},
+		{
+			name:     "escaped go module",
+			pkg:      packages.Package{Ecosystem: "go", Name: "github.com/!azure/azure-sdk-for-go"},
+			expected: "pkg:golang/github.com/Azure/azure-sdk-for-go",
+		},
+		{
+			name:     "swift",
+			pkg:      packages.Package{Ecosystem: "swiftpm", Name: "https://github.com/apple/swift-nio.git"},
+			expected: "pkg:swift/github.com/apple/swift-nio",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purl, err := PackageToPURL(tt.pkg)
+			if err != nil {
+				t.Fatalf("PackageToPURL() error = %v", err)
+			}
+			if got := purl.ToString(); got != tt.expected {
+				t.Errorf("PackageToPURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPackageToPURLRoundTrip(t *testing.T) {
+	for _, pkg := range []packages.Package{
+		{Ecosystem: "npm", Name: "@babel/core"},
+		{Ecosystem: "maven", Name: "org.apache.commons:commons-lang3"},
+		{Ecosystem: "go", Name: "github.com/go-git/go-git"},
+		{Ecosystem: "pypi", Name: "requests"},
+		{Ecosystem: "swiftpm", Name: "https://github.com/apple/swift-nio.git"},
+		{Ecosystem: "cocoapods", Name: "AFNetworking"},
+		{Ecosystem: "go", Name: "github.com/!burnt!sushi/toml"},
+	} {
+		purl, err := PackageToPURL(pkg)
+		if err != nil {
+			t.Fatalf("PackageToPURL(%s, %s) error = %v", pkg.Ecosystem, pkg.Name, err)
+		}
+		if got := PURLToName(purl); got != pkg.Name {
+			t.Errorf("PURLToName(PackageToPURL(%s, %q)) = %q", pkg.Ecosystem, pkg.Name, got)
+		}
+	}
+}
+
+func TestEscapeModulePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		escaped string
+	}{
+		{"github.com/go-git/go-git", "github.com/go-git/go-git"},
+		{"github.com/Azure/azure-sdk-for-go", "github.com/!azure/azure-sdk-for-go"},
+		{"github.com/BurntSushi/toml", "github.com/!burnt!sushi/toml"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := escapeModulePath(tt.path); got != tt.escaped {
+				t.Errorf("escapeModulePath() = %q, want %q", got, tt.escaped)
+			}
+			if got := unescapeModulePath(tt.escaped); got != tt.path {
+				t.Errorf("unescapeModulePath() = %q, want %q", got, tt.path)
+			}
+		})
+	}
+
+	if got := unescapeModulePath("example.com/a!1!"); got != "example.com/a!1!" {
+		t.Errorf("unescapeModulePath() = %q, want invalid escapes kept", got)
+	}
+}
+
+func TestPackageToPURLUnsupportedEcosystem(t *testing.T) {
+	if _, err := PackageToPURL(packages.Package{Ecosystem: "unknown", Name: "x"}); err == nil {
+		t.Error("PackageToPURL() with unknown ecosystem should error")
+	}
+}
+
+func TestVersionToPURL(t *testing.T) {
+	pkg := packages.Package{Ecosystem: "maven", Name: "org.apache.commons:commons-lang3"}
+
+	purl, err := VersionToPURL(pkg, packages.Version{Number: "3.14.0"})
+	if err != nil {
+		t.Fatalf("VersionToPURL() error = %v", err)
+	}
+	want := "pkg:maven/org.apache.commons/commons-lang3@3.14.0"
+	if got := purl.ToString(); got != want {
+		t.Errorf("VersionToPURL() = %q, want %q", got, want)
+	}
+
+	purl, err = VersionToPURL(pkg, packages.Version{Number: "ignored", Purl: "pkg:gem/rails@7.1.0"})
+	if err != nil {
+		t.Fatalf("VersionToPURL() error = %v", err)
+	}
+	if got := purl.ToString(); got != "pkg:gem/rails@7.1.0" {
+		t.Errorf("VersionToPURL() = %q, want %q", got, "pkg:gem/rails@7.1.0")
+	}
+}
+
 func TestParsePURL(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -167,3 +404,74 @@ func TestSupportedPURLTypes(t *testing.T) {
 		}
 	}
 }
+
+type upperNameParser struct {
+	PackageURLParser
+}
+
+func (p upperNameParser) Parse(s string) (packageurl.PackageURL, error) {
+	purl, err := p.PackageURLParser.Parse(s)
+	purl.Name = strings.ToUpper(purl.Name)
+	return purl, err
+}
+
+func TestClientPURLParser(t *testing.T) {
+	client, err := NewClient("test-agent/1.0", WithPURLParser(upperNameParser{}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	purl, err := client.ParsePURL("pkg:gem/rails@7.0.0")
+	if err != nil {
+		t.Fatalf("ParsePURL() error = %v", err)
+	}
+	if purl.Name != "RAILS" {
+		t.Errorf("Name = %q, want %q", purl.Name, "RAILS")
+	}
+	if got := client.FormatPURL(purl); got != "pkg:gem/RAILS@7.0.0" {
+		t.Errorf("FormatPURL() = %q, want %q", got, "pkg:gem/RAILS@7.0.0")
+	}
+}
+
+func TestParsePURLRejectsPathologicalInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"control character", "pkg:npm/left\x00pad"},
+		{"newline", "pkg:npm/left-pad\n"},
+		{"encoded control character", "pkg:npm/left%00pad"},
+		{"encoded control character in qualifier", "pkg:npm/left-pad?x=%0A"},
+		{"encoded control character in subpath", "pkg:golang/example.com/mod#a%00b"},
+		{"oversized", "pkg:npm/" + strings.Repeat("a", MaxPURLLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePURL(tt.input); err == nil {
+				t.Errorf("ParsePURL(%q) should error", tt.name)
+			}
+		})
+	}
+}
+
+func TestLookupRepositoryPURL(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	purl, err := ParsePURL("pkg:github/rails/rails")
+	if err != nil {
+		t.Fatalf("ParsePURL() error = %v", err)
+	}
+	repo, err := client.LookupRepositoryPURL(ctx, purl)
+	if err != nil {
+		t.Fatalf("LookupRepositoryPURL() error = %v", err)
+	}
+	if repo == nil || repo.FullName == nil || *repo.FullName != "rails/rails" {
+		t.Errorf("LookupRepositoryPURL() = %+v, want rails/rails", repo)
+	}
+
+	if _, err := client.LookupRepositoryPURL(ctx, packageurl.PackageURL{Type: packageurl.TypeNPM, Name: "lodash"}); err == nil {
+		t.Error("LookupRepositoryPURL() with npm PURL should error")
+	}
+}
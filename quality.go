@@ -0,0 +1,91 @@
+package ecosystems
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// QualityFlag identifies a data-quality problem with a package record.
+type QualityFlag string
+
+const (
+	// QualityMissingRepository means the package has no repository URL.
+	QualityMissingRepository QualityFlag = "missing_repository_url"
+	// QualityUnparsedLicense means a license was declared but could not be normalized to SPDX.
+	QualityUnparsedLicense QualityFlag = "unparsed_license"
+	// QualityNoVersions means no versions have been recorded for the package.
+	QualityNoVersions QualityFlag = "no_versions"
+	// QualityStaleSync means the record was last synced longer ago than the allowed age.
+	QualityStaleSync QualityFlag = "stale_sync"
+)
+
+// DefaultMaxSyncAge is the sync age after which AnnotateQuality flags a record as stale.
+const DefaultMaxSyncAge = 30 * 24 * time.Hour
+
+// Quality lists the data-quality flags raised for a record.
+type Quality struct {
+	Flags []QualityFlag
+}
+
+// OK reports whether no quality flags were raised.
+func (q Quality) OK() bool {
+	return len(q.Flags) == 0
+}
+
+// Has reports whether the given flag was raised.
+func (q Quality) Has(flag QualityFlag) bool {
+	for _, f := range q.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnotatedPackage is a package result together with its data-quality assessment.
+type AnnotatedPackage struct {
+	*packages.PackageWithRegistry
+	Quality Quality
+}
+
+// AssessQuality checks a package record for common data-quality problems.
+// Records last synced longer ago than maxSyncAge are flagged as stale; a
+// maxSyncAge of zero uses DefaultMaxSyncAge.
+func AssessQuality(pkg *packages.PackageWithRegistry, maxSyncAge time.Duration) Quality {
+	if maxSyncAge == 0 {
+		maxSyncAge = DefaultMaxSyncAge
+	}
+
+	var q Quality
+	if pkg.RepositoryUrl == nil || strings.TrimSpace(*pkg.RepositoryUrl) == "" {
+		q.Flags = append(q.Flags, QualityMissingRepository)
+	}
+	if pkg.Licenses != nil && strings.TrimSpace(*pkg.Licenses) != "" && len(pkg.NormalizedLicenses) == 0 {
+		q.Flags = append(q.Flags, QualityUnparsedLicense)
+	}
+	if pkg.VersionsCount == 0 {
+		q.Flags = append(q.Flags, QualityNoVersions)
+	}
+	if pkg.LastSyncedAt == nil || time.Since(*pkg.LastSyncedAt) > maxSyncAge {
+		q.Flags = append(q.Flags, QualityStaleSync)
+	}
+	return q
+}
+
+// AnnotateQuality assesses every result of a bulk lookup so low-confidence
+// records can be routed for review.
+func AnnotateQuality(results map[string]*packages.PackageWithRegistry, maxSyncAge time.Duration) map[string]*AnnotatedPackage {
+	annotated := make(map[string]*AnnotatedPackage, len(results))
+	for purl, pkg := range results {
+		if pkg == nil {
+			continue
+		}
+		annotated[purl] = &AnnotatedPackage{
+			PackageWithRegistry: pkg,
+			Quality:             AssessQuality(pkg, maxSyncAge),
+		}
+	}
+	return annotated
+}
@@ -0,0 +1,77 @@
+package ecosystems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestAssessQuality(t *testing.T) {
+	recent := time.Now().Add(-time.Hour)
+	old := time.Now().Add(-90 * 24 * time.Hour)
+
+	tests := []struct {
+		name string
+		pkg  packages.PackageWithRegistry
+		want []QualityFlag
+	}{
+		{
+			name: "clean record",
+			pkg: packages.PackageWithRegistry{
+				RepositoryUrl:      strPtr("https://github.com/rails/rails"),
+				Licenses:           strPtr("MIT"),
+				NormalizedLicenses: []string{"MIT"},
+				VersionsCount:      10,
+				LastSyncedAt:       &recent,
+			},
+		},
+		{
+			name: "everything wrong",
+			pkg: packages.PackageWithRegistry{
+				Licenses:     strPtr("see LICENSE file"),
+				LastSyncedAt: &old,
+			},
+			want: []QualityFlag{QualityMissingRepository, QualityUnparsedLicense, QualityNoVersions, QualityStaleSync},
+		},
+		{
+			name: "never synced",
+			pkg: packages.PackageWithRegistry{
+				RepositoryUrl: strPtr("https://github.com/rails/rails"),
+				VersionsCount: 1,
+			},
+			want: []QualityFlag{QualityStaleSync},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssessQuality(&tt.pkg, 0)
+			if len(got.Flags) != len(tt.want) {
+				t.Fatalf("Flags = %v, want %v", got.Flags, tt.want)
+			}
+			for _, f := range tt.want {
+				if !got.Has(f) {
+					t.Errorf("missing flag %q in %v", f, got.Flags)
+				}
+			}
+			if got.OK() != (len(tt.want) == 0) {
+				t.Errorf("OK() = %v, want %v", got.OK(), len(tt.want) == 0)
+			}
+		})
+	}
+}
+
+func TestAnnotateQuality(t *testing.T) {
+	results := map[string]*packages.PackageWithRegistry{
+		"pkg:gem/rails": {Purl: "pkg:gem/rails"},
+		"pkg:npm/gone":  nil,
+	}
+	annotated := AnnotateQuality(results, time.Hour)
+	if len(annotated) != 1 {
+		t.Fatalf("AnnotateQuality() = %d results, want 1", len(annotated))
+	}
+	if annotated["pkg:gem/rails"].Purl != "pkg:gem/rails" {
+		t.Errorf("Purl = %q, want %q", annotated["pkg:gem/rails"].Purl, "pkg:gem/rails")
+	}
+}
@@ -0,0 +1,73 @@
+package ecosystems
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithHostRateLimit throttles requests to host, such as
+// "packages.ecosyste.ms", to at most rps per second, spacing them evenly.
+// Each host has its own limit, so a busy service does not hold up requests
+// to the others. A host with a port, such as "localhost:8080", only limits
+// that port. A rate of zero or less removes the limit for host.
+func WithHostRateLimit(host string, rps float64) Option {
+	return func(c *clientConfig) {
+		if c.hostRateLimits == nil {
+			c.hostRateLimits = make(map[string]float64)
+		}
+		if rps <= 0 {
+			delete(c.hostRateLimits, host)
+			return
+		}
+		c.hostRateLimits[host] = rps
+	}
+}
+
+// hostRateLimitTransport delays requests so that each host with a limit
+// receives them no closer together than its interval.
+type hostRateLimitTransport struct {
+	next      http.RoundTripper
+	intervals map[string]time.Duration
+
+	mu    sync.Mutex
+	slots map[string]time.Time
+}
+
+func newHostRateLimitTransport(next http.RoundTripper, limits map[string]float64) *hostRateLimitTransport {
+	t := &hostRateLimitTransport{
+		next:      next,
+		intervals: make(map[string]time.Duration, len(limits)),
+		slots:     make(map[string]time.Time, len(limits)),
+	}
+	for host, rps := range limits {
+		t.intervals[host] = time.Duration(float64(time.Second) / rps)
+	}
+	return t
+}
+
+func (t *hostRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	interval, ok := t.intervals[host]
+	if !ok {
+		host = req.URL.Hostname()
+		if interval, ok = t.intervals[host]; !ok {
+			return t.next.RoundTrip(req)
+		}
+	}
+
+	t.mu.Lock()
+	slot := time.Now()
+	if next := t.slots[host]; next.After(slot) {
+		slot = next
+	}
+	t.slots[host] = slot.Add(interval)
+	t.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,69 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithHostRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "rubygems.org"}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		minTime time.Duration
+		maxTime time.Duration
+	}{
+		{"limited", []Option{WithHostRateLimit(u.Host, 20)}, 100 * time.Millisecond, time.Second},
+		{"limited by hostname", []Option{WithHostRateLimit(u.Hostname(), 20)}, 100 * time.Millisecond, time.Second},
+		{"other host", []Option{WithHostRateLimit("repos.ecosyste.ms", 1)}, 0, 500 * time.Millisecond},
+		{"removed", []Option{WithHostRateLimit(u.Host, 1), WithHostRateLimit(u.Host, 0)}, 0, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-agent/1.0", append(tt.opts, WithPackagesServer(srv.URL))...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			start := time.Now()
+			var wg sync.WaitGroup
+			for range 3 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := client.GetRegistry(context.Background(), "rubygems.org"); err != nil {
+						t.Errorf("GetRegistry() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+			if elapsed := time.Since(start); elapsed < tt.minTime || elapsed > tt.maxTime {
+				t.Errorf("3 requests took %v, want between %v and %v", elapsed, tt.minTime, tt.maxTime)
+			}
+		})
+	}
+}
+
+func TestHostRateLimitCanceled(t *testing.T) {
+	transport := newHostRateLimitTransport(http.DefaultTransport, map[string]float64{"example.com": 0.001})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	transport.slots["example.com"] = time.Now().Add(time.Hour)
+	_, err := transport.RoundTrip(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+}
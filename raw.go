@@ -0,0 +1,77 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// rawClient sends requests to API paths the generated clients do not
+// cover, through the same transport and with the same headers.
+type rawClient struct {
+	server *url.URL
+	doer   *http.Client
+	edit   func(ctx context.Context, req *http.Request) error
+}
+
+// parseServerURL parses a service base URL, adding the trailing slash
+// that relative paths are resolved against.
+func parseServerURL(server string) (*url.URL, error) {
+	if !strings.HasSuffix(server, "/") {
+		server += "/"
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("parsing server URL: %w", err)
+	}
+	return u, nil
+}
+
+// get requests path, given as escaped segments relative to the server URL.
+// The caller must close the response body.
+func (r *rawClient) get(ctx context.Context, call *callConfig, segments ...string) (*http.Response, error) {
+	return r.getQuery(ctx, call, nil, segments...)
+}
+
+// getQuery is like get, adding query parameters to the request.
+func (r *rawClient) getQuery(ctx context.Context, call *callConfig, query url.Values, segments ...string) (*http.Response, error) {
+	if err := checkPathSegments(segments...); err != nil {
+		return nil, err
+	}
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	u, err := r.server.Parse("./" + strings.Join(escaped, "/"))
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.edit(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := call.editRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.doer.Do(req)
+}
+
+// checkPathSegments rejects values that cannot be escaped into a single
+// path segment: an empty value would select a different endpoint, and "."
+// or ".." would be resolved against the rest of the path. Anything else,
+// including slashes in names such as @babel/core, is percent-encoded.
+func checkPathSegments(segments ...string) error {
+	for _, s := range segments {
+		if s == "" || s == "." || s == ".." {
+			return fmt.Errorf("invalid path segment %q", s)
+		}
+	}
+	return nil
+}
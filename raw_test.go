@@ -0,0 +1,165 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestRegistryNamePathEscaping(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		uris []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		uris = append(uris, r.URL.EscapedPath())
+		mu.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithReposServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	names := []struct {
+		name    string
+		escaped string
+	}{
+		{"@babel/core", "@babel%2Fcore"},
+		{"github.com/go-git/go-git", "github.com%2Fgo-git%2Fgo-git"},
+		{"org.apache.commons:commons-lang3", "org.apache.commons:commons-lang3"},
+		{"github.com/!azure/azure-sdk-for-go", "github.com%2F%21azure%2Fazure-sdk-for-go"},
+		{"https://github.com/apple/swift-nio.git", "https:%2F%2Fgithub.com%2Fapple%2Fswift-nio.git"},
+		{"a b?c#d%e", "a%20b%3Fc%23d%25e"},
+	}
+	calls := []struct {
+		name string
+		call func(name string) error
+		path string
+	}{
+		{"LookupByRegistryAndName", func(name string) error {
+			_, err := client.LookupByRegistryAndName(ctx, "npmjs.org", name)
+			return err
+		}, ""},
+		{"GetVersion", func(name string) error {
+			_, err := client.GetVersion(ctx, "npmjs.org", name, "1.0.0+build/1")
+			return err
+		}, "/versions/1.0.0+build%2F1"},
+		{"GetAllVersions", func(name string) error {
+			_, err := client.GetAllVersions(ctx, "npmjs.org", name)
+			return err
+		}, "/versions"},
+		{"SyncPackage", func(name string) error {
+			_, err := client.SyncPackage(ctx, "npmjs.org", name)
+			return err
+		}, "/ping"},
+	}
+
+	for _, c := range calls {
+		for _, n := range names {
+			t.Run(c.name+" "+n.name, func(t *testing.T) {
+				mu.Lock()
+				uris = nil
+				mu.Unlock()
+				_ = c.call(n.name)
+
+				want := "/registries/npmjs.org/packages/" + n.escaped + c.path
+				mu.Lock()
+				defer mu.Unlock()
+				if len(uris) != 1 || uris[0] != want {
+					t.Errorf("request path = %v, want %q", uris, want)
+				}
+			})
+		}
+	}
+}
+
+func TestRepositoryNamePathEscaping(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithReposServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetRepositoryByHostAndName(context.Background(), "GitHub", "/rails/rails/"); err != nil {
+		t.Fatalf("GetRepositoryByHostAndName() error = %v", err)
+	}
+	if want := "/hosts/GitHub/repositories/rails%2Frails"; path != want {
+		t.Errorf("request path = %q, want %q", path, want)
+	}
+}
+
+func TestInvalidPathSegments(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"empty name", func() error {
+			_, err := client.LookupByRegistryAndName(ctx, "npmjs.org", "")
+			return err
+		}},
+		{"dot dot name", func() error {
+			_, err := client.GetAllVersions(ctx, "npmjs.org", "..")
+			return err
+		}},
+		{"dot version", func() error {
+			_, err := client.GetVersion(ctx, "npmjs.org", "lodash", ".")
+			return err
+		}},
+		{"empty registry", func() error {
+			_, err := client.SyncPackage(ctx, "", "lodash")
+			return err
+		}},
+		{"empty owner", func() error {
+			_, err := client.GetOwner(ctx, "GitHub", "")
+			return err
+		}},
+		{"dot dot repository", func() error {
+			_, err := client.GetRepositoryByHostAndName(ctx, "GitHub", "/../")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err == nil || !strings.Contains(err.Error(), "invalid path segment") {
+				t.Errorf("error = %v, want invalid path segment", err)
+			}
+		})
+	}
+	if got := srv.Requests(); len(got) != 0 {
+		t.Errorf("requests = %v, want none", got)
+	}
+}
+
+func TestScopedNameRoundTrip(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddPackage("npmjs.org", packages.PackageWithRegistry{Name: "@babel/core"})
+	srv.AddVersion("npmjs.org", "@babel/core", packages.VersionWithDependencies{Number: "7.24.0"})
+	ctx := context.Background()
+
+	pkg, err := client.LookupByRegistryAndName(ctx, "npmjs.org", "@babel/core")
+	if err != nil || pkg == nil || pkg.Name != "@babel/core" {
+		t.Fatalf("LookupByRegistryAndName() = %v, %v, want @babel/core", pkg, err)
+	}
+	v, err := client.GetVersion(ctx, "npmjs.org", "@babel/core", "7.24.0")
+	if err != nil || v == nil || v.Number != "7.24.0" {
+		t.Errorf("GetVersion() = %v, %v, want 7.24.0", v, err)
+	}
+}
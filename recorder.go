@@ -0,0 +1,186 @@
+package ecosystems
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RecorderMode controls how a Recorder uses its cassette directory.
+type RecorderMode int
+
+const (
+	// RecorderReplayOrRecord replays saved responses and records any request
+	// that has no cassette yet.
+	RecorderReplayOrRecord RecorderMode = iota
+	// RecorderReplay only replays saved responses. Requests without a
+	// cassette fail with ErrCassetteNotFound.
+	RecorderReplay
+	// RecorderRecord always sends requests and overwrites saved responses.
+	RecorderRecord
+)
+
+// ErrCassetteNotFound is returned in RecorderReplay mode when no saved
+// response matches a request.
+var ErrCassetteNotFound = errors.New("no recorded response for request")
+
+// Recorder is an http.RoundTripper that records API responses to cassette
+// files and replays them, so tests can run without live API access.
+// Cassettes are keyed by request method, URL and body. Request headers are
+// never written, so credentials do not end up in cassettes. Only successful,
+// redirect and 404 responses are recorded: a rate limit or server error hit
+// while recording is passed through rather than replayed forever.
+type Recorder struct {
+	dir  string
+	mode RecorderMode
+	next http.RoundTripper
+}
+
+// NewRecorder creates a Recorder storing cassettes in dir and sending
+// unrecorded requests through next. A nil next uses http.DefaultTransport.
+func NewRecorder(dir string, mode RecorderMode, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, mode: mode, next: next}
+}
+
+// WithRecorder records responses to, and replays them from, cassette files in dir.
+func WithRecorder(dir string, mode RecorderMode) Option {
+	return func(c *clientConfig) {
+		c.recorderDir = dir
+		c.recorderMode = mode
+	}
+}
+
+type cassette struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body"`
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("recorder: reading request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	path := r.cassettePath(req, reqBody)
+
+	if r.mode != RecorderRecord {
+		c, err := readCassette(path)
+		if err == nil {
+			return c.response(req), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if r.mode == RecorderReplay {
+			return nil, fmt.Errorf("recorder: %s %s: %w", req.Method, req.URL, ErrCassetteNotFound)
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if !recordable(resp.StatusCode) {
+		return resp, nil
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	c := &cassette{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: string(reqBody),
+		Status:      resp.StatusCode,
+		Header:      header,
+		Body:        string(body),
+	}
+	if err := writeCassette(path, c); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// recordable reports whether a response with the given status is worth
+// replaying: transient failures such as 429 and 5xx are not.
+func recordable(status int) bool {
+	return status < 400 || status == http.StatusNotFound
+}
+
+func (r *Recorder) cassettePath(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL.String()))
+	h.Write([]byte{0})
+	h.Write(body)
+	name := strings.ToLower(req.Method) + "-" + hex.EncodeToString(h.Sum(nil))[:16] + ".json"
+	return filepath.Join(r.dir, name)
+}
+
+func readCassette(path string) (*cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("recorder: decoding %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func writeCassette(path string, c *cassette) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("recorder: encoding cassette: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	return nil
+}
+
+func (c *cassette) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Status, http.StatusText(c.Status)),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
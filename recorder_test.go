@@ -0,0 +1,101 @@
+package ecosystems
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := ecosystemstest.NewServer()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+
+	recording, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithRecorder(dir, RecorderReplayOrRecord),
+		WithAPIKey("secret-key"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := recording.BulkLookup(context.Background(), []string{"pkg:gem/rails"}); err != nil {
+		t.Fatalf("BulkLookup() error = %v", err)
+	}
+	srv.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("cassettes = %v (err %v), want 1", entries, err)
+	}
+	data, _ := os.ReadFile(dir + "/" + entries[0].Name())
+	if bytes.Contains(data, []byte("secret-key")) {
+		t.Error("cassette contains API key")
+	}
+
+	replaying, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithRecorder(dir, RecorderReplay),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	results, err := replaying.BulkLookup(context.Background(), []string{"pkg:gem/rails"})
+	if err != nil {
+		t.Fatalf("replayed BulkLookup() error = %v", err)
+	}
+	if results["pkg:gem/rails"] == nil {
+		t.Error("replayed BulkLookup() missing rails")
+	}
+
+	_, err = replaying.BulkLookup(context.Background(), []string{"pkg:npm/lodash"})
+	if !errors.Is(err, ErrCassetteNotFound) {
+		t.Errorf("BulkLookup() without cassette error = %v, want ErrCassetteNotFound", err)
+	}
+}
+
+func TestRecorderSkipsTransientFailures(t *testing.T) {
+	tests := []struct {
+		status int
+		saved  bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusNotModified, true},
+		{http.StatusNotFound, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			dir := t.TempDir()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+			recorder := NewRecorder(dir, RecorderReplayOrRecord, nil)
+			req, _ := http.NewRequest(http.MethodGet, srv.URL+"/registries", nil)
+			resp, err := recorder.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			entries, _ := os.ReadDir(dir)
+			if saved := len(entries) == 1; saved != tt.saved {
+				t.Errorf("cassette saved = %v, want %v", saved, tt.saved)
+			}
+		})
+	}
+}
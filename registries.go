@@ -0,0 +1,270 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// registryCatalogue memoizes the registry list for the client's lifetime.
+// The mutex only guards its fields; the list is fetched without holding it.
+type registryCatalogue struct {
+	mu     sync.Mutex
+	list   []packages.Registry
+	loaded bool
+	// fetch is the fetch in progress, shared by concurrent callers.
+	fetch *registryFetch
+}
+
+// registryFetch is one fetch of the registry list. Its fields are set
+// before done is closed.
+type registryFetch struct {
+	done chan struct{}
+	list []packages.Registry
+	err  error
+}
+
+// loadRegistries returns the memoized registry list, fetching it on
+// first use. Concurrent callers share one fetch, and each stops waiting
+// when its own ctx is done. Failed fetches are not memoized; a fetch that
+// failed because the context of the caller making it ended is retried by
+// the callers still waiting.
+func (c *Client) loadRegistries(ctx context.Context, opts ...CallOption) ([]packages.Registry, error) {
+	for {
+		cat := c.registries
+		cat.mu.Lock()
+		if cat.loaded {
+			list := cat.list
+			cat.mu.Unlock()
+			return list, nil
+		}
+		f := cat.fetch
+		if f == nil {
+			f = &registryFetch{done: make(chan struct{})}
+			cat.fetch = f
+			cat.mu.Unlock()
+			f.list, f.err = c.ListRegistries(ctx, opts...)
+			cat.mu.Lock()
+			if f.err == nil {
+				cat.list, cat.loaded = f.list, true
+			}
+			cat.fetch = nil
+			cat.mu.Unlock()
+			close(f.done)
+			return f.list, f.err
+		}
+		cat.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-f.done:
+		}
+		if f.err == nil || !errors.Is(f.err, context.Canceled) && !errors.Is(f.err, context.DeadlineExceeded) {
+			return f.list, f.err
+		}
+	}
+}
+
+// RefreshRegistries refetches the registry list used by GetRegistry and
+// PURL routing, which is otherwise fetched once and kept for the client's
+// lifetime.
+func (c *Client) RefreshRegistries(ctx context.Context, opts ...CallOption) error {
+	list, err := c.ListRegistries(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	c.registries.mu.Lock()
+	defer c.registries.mu.Unlock()
+	c.registries.list, c.registries.loaded = list, true
+	return nil
+}
+
+// GetRegistry returns the named registry, or nil if it does not exist. It
+// is answered from the memoized registry list when that has been fetched,
+// and from the single registry endpoint otherwise.
+func (c *Client) GetRegistry(ctx context.Context, name string, opts ...CallOption) (*packages.Registry, error) {
+	c.registries.mu.Lock()
+	loaded, list := c.registries.loaded, c.registries.list
+	c.registries.mu.Unlock()
+	if loaded {
+		for _, r := range list {
+			if r.Name == name {
+				return &r, nil
+			}
+		}
+	}
+
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetRegistry", name)
+	resp, err := c.packagesAPI().GetRegistryWithResponse(ctx, name, nil, call.packagesEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get registry: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get registry", resp.HTTPResponse, resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// registriesFor returns the registries to query for purl, in order. Maven
+// PURLs whose repository_url qualifier does not select a registry try the
+// WithMavenRegistries order, then Maven Central. PURL types without a
+// built-in mapping are looked up in the memoized registry list, preferring
+// the default registry for the type. Linux package PURLs naming a
+// distribution without a known registry are an error.
+func (c *Client) registriesFor(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) ([]string, error) {
+	if registry := c.registryFor(purl); registry != "" {
+		return c.withMavenFallbacks(purl, registry), nil
+	}
+	if _, ok := distroRegistries[purl.Type]; ok && purl.Namespace != "" {
+		return nil, fmt.Errorf("unsupported %s distribution %q", purl.Type, purl.Namespace)
+	}
+	registry, err := c.catalogueRegistry(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return []string{registry}, nil
+}
+
+// withMavenFallbacks returns the WithMavenRegistries order ending with
+// registry, the default, for Maven PURLs without a known repository_url.
+func (c *Client) withMavenFallbacks(purl packageurl.PackageURL, registry string) []string {
+	if purl.Type != packageurl.TypeMaven || len(c.mavenOrder) == 0 {
+		return []string{registry}
+	}
+	if _, ok := repositoryURLRegistries[repositoryURLHost(purl.Qualifiers.Map()["repository_url"])]; ok {
+		return []string{registry}
+	}
+	registries := slices.Clone(c.mavenOrder)
+	if !slices.Contains(registries, registry) {
+		registries = append(registries, registry)
+	}
+	return registries
+}
+
+// catalogueRegistry looks up the registry for a PURL type without a
+// built-in mapping in the memoized registry list.
+func (c *Client) catalogueRegistry(ctx context.Context, purl packageurl.PackageURL, opts ...CallOption) (string, error) {
+	list, err := c.loadRegistries(ctx, opts...)
+	if err != nil {
+		return "", fmt.Errorf("resolving registry for PURL type %s: %w", purl.Type, err)
+	}
+	var registry string
+	for _, r := range list {
+		if r.PurlType != purl.Type {
+			continue
+		}
+		if r.Default {
+			return r.Name, nil
+		}
+		if registry == "" {
+			registry = r.Name
+		}
+	}
+	if registry == "" {
+		return "", fmt.Errorf("unsupported PURL type: %s", purl.Type)
+	}
+	return registry, nil
+}
+
+// ListRegistriesByEcosystem returns the registries for an ecosystem, such as
+// "maven" or "docker", with the ecosystem's default registry first. It is
+// answered from the memoized registry list, which is fetched in full on
+// first use.
+func (c *Client) ListRegistriesByEcosystem(ctx context.Context, ecosystem string, opts ...CallOption) ([]packages.Registry, error) {
+	list, err := c.loadRegistries(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []packages.Registry
+	for _, r := range list {
+		if strings.EqualFold(r.Ecosystem, ecosystem) {
+			matching = append(matching, r)
+		}
+	}
+
+	slices.SortStableFunc(matching, func(a, b packages.Registry) int {
+		switch {
+		case a.Default == b.Default:
+			return 0
+		case a.Default:
+			return -1
+		}
+		return 1
+	})
+	return matching, nil
+}
+
+// DefaultRegistry returns the registry flagged as its ecosystem's default,
+// such as npmjs.org for npm, from registries returned by ListRegistries or
+// ListRegistriesByEcosystem.
+func DefaultRegistry(registries []packages.Registry) (packages.Registry, bool) {
+	for _, r := range registries {
+		if r.Default {
+			return r, true
+		}
+	}
+	return packages.Registry{}, false
+}
+
+// VerifyRegistryMappings cross-checks the built-in PURL type, repository
+// URL and distribution mappings against the registries the API lists, so
+// that renamed or removed registries are caught. It reports every mapped
+// registry the API does not list, and every one whose ecosystem maps back
+// to a different PURL type.
+func (c *Client) VerifyRegistryMappings(ctx context.Context, opts ...CallOption) error {
+	list, err := c.ListRegistries(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	ecosystemOf := make(map[string]string, len(list))
+	for _, r := range list {
+		ecosystemOf[r.Name] = r.Ecosystem
+	}
+
+	var problems []error
+	check := func(source, registry, purlType string) {
+		ecosystem, ok := ecosystemOf[registry]
+		if !ok {
+			problems = append(problems, fmt.Errorf("%s maps to unknown registry %q", source, registry))
+			return
+		}
+		if t := EcosystemToPURLType(ecosystem); purlType != "" && t != "" && t != purlType {
+			problems = append(problems, fmt.Errorf("%s maps to registry %q, whose ecosystem %q has PURL type %q", source, registry, ecosystem, t))
+		}
+	}
+	for _, t := range slices.Sorted(maps.Keys(purlTypeToRegistry)) {
+		if registry := purlTypeToRegistry[t]; registry != "" {
+			check(fmt.Sprintf("PURL type %q", t), registry, t)
+		}
+	}
+	for _, host := range slices.Sorted(maps.Keys(repositoryURLRegistries)) {
+		check(fmt.Sprintf("repository_url %q", host), repositoryURLRegistries[host], "")
+	}
+	for _, t := range slices.Sorted(maps.Keys(distroRegistries)) {
+		for _, distro := range slices.Sorted(maps.Keys(distroRegistries[t])) {
+			check(fmt.Sprintf("%s distribution %q", t, distro), distroRegistries[t][distro], t)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("registry mappings out of date:\n%w", errors.Join(problems...))
+	}
+	return nil
+}
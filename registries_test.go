@@ -0,0 +1,296 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+func TestGetRegistry(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"rubygems.org", true},
+		{"missing.example", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetRegistry(ctx, tt.name)
+			if err != nil {
+				t.Fatalf("GetRegistry() error = %v", err)
+			}
+			if (got != nil) != tt.want {
+				t.Fatalf("GetRegistry() = %v, want found %v", got, tt.want)
+			}
+			if got != nil && got.Name != tt.name {
+				t.Errorf("GetRegistry().Name = %q, want %q", got.Name, tt.name)
+			}
+		})
+	}
+
+	if n := countRequests(srv.Requests(), "/registries"); n != 0 {
+		t.Errorf("registry list requests = %d, want 0", n)
+	}
+}
+
+func TestGetRegistryMemoized(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+
+	if err := client.RefreshRegistries(ctx); err != nil {
+		t.Fatalf("RefreshRegistries() error = %v", err)
+	}
+	for range 3 {
+		got, err := client.GetRegistry(ctx, "npmjs.org")
+		if err != nil || got == nil || got.Ecosystem != "npm" {
+			t.Fatalf("GetRegistry() = %v, %v, want npmjs.org", got, err)
+		}
+	}
+	if n := countRequests(srv.Requests(), "/registries"); n != 1 {
+		t.Errorf("registry requests = %d, want 1: %v", n, srv.Requests())
+	}
+
+	srv.AddRegistry(packages.Registry{Name: "registry.example", PurlType: "example"})
+	if got, _ := client.GetRegistry(ctx, "registry.example"); got == nil {
+		t.Error("GetRegistry(registry.example) = nil, want registry not in the memoized list")
+	}
+}
+
+func TestRegistryForUnmappedPURLType(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddRegistry(packages.Registry{Name: "mirror.example", PurlType: "example"})
+	srv.AddRegistry(packages.Registry{Name: "registry.example", PurlType: "example", Default: true})
+	srv.AddPackage("registry.example", packages.PackageWithRegistry{Name: "widget"})
+	ctx := context.Background()
+
+	for range 2 {
+		pkg, err := client.LookupPURL(ctx, packageurl.PackageURL{Type: "example", Name: "widget"})
+		if err != nil {
+			t.Fatalf("LookupPURL() error = %v", err)
+		}
+		if pkg == nil || pkg.Name != "widget" {
+			t.Fatalf("LookupPURL() = %v, want widget", pkg)
+		}
+	}
+	if n := countRequests(srv.Requests(), "/registries"); n != 1 {
+		t.Errorf("registry list requests = %d, want 1", n)
+	}
+
+	_, err := client.LookupPURL(ctx, packageurl.PackageURL{Type: "unknown", Name: "widget"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported PURL type") {
+		t.Errorf("LookupPURL(unknown) error = %v, want unsupported PURL type", err)
+	}
+}
+
+// countRequests counts the requests whose path ends with suffix.
+func countRequests(requests []string, suffix string) int {
+	n := 0
+	for _, r := range requests {
+		if strings.HasSuffix(r, suffix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestListRegistriesByEcosystem(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddRegistry(packages.Registry{Name: "npm.mirror.example", Ecosystem: "npm"})
+	srv.AddRegistry(packages.Registry{Name: "repo1.maven.org", Ecosystem: "maven", Default: true})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		refresh   bool
+		ecosystem string
+		want      []string
+	}{
+		{"fetched on first use", false, "npm", []string{"npmjs.org", "npm.mirror.example"}},
+		{"memoized list", true, "npm", []string{"npmjs.org", "npm.mirror.example"}},
+		{"case insensitive", true, "Maven", []string{"repo1.maven.org"}},
+		{"unknown ecosystem", true, "docker", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.refresh {
+				if err := client.RefreshRegistries(ctx); err != nil {
+					t.Fatalf("RefreshRegistries() error = %v", err)
+				}
+			}
+			registries, err := client.ListRegistriesByEcosystem(ctx, tt.ecosystem)
+			if err != nil {
+				t.Fatalf("ListRegistriesByEcosystem() error = %v", err)
+			}
+			var got []string
+			for _, r := range registries {
+				got = append(got, r.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ListRegistriesByEcosystem(%q) = %v, want %v", tt.ecosystem, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRegistriesByEcosystemPaginates(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddRegistry(packages.Registry{Name: "npm.mirror.example", Ecosystem: "npm"})
+
+	registries, err := client.ListRegistriesByEcosystem(context.Background(), "npm", CallPageSize(1))
+	if err != nil {
+		t.Fatalf("ListRegistriesByEcosystem() error = %v", err)
+	}
+	if len(registries) != 2 {
+		t.Errorf("ListRegistriesByEcosystem() = %d registries, want 2 across pages", len(registries))
+	}
+	if got := countRequests(srv.Requests(), "/registries"); got < 2 {
+		t.Errorf("made %d registry list requests, want one per page", got)
+	}
+}
+
+func TestLoadRegistriesSharedFetch(t *testing.T) {
+	var lists atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/registries" {
+			lists.Add(1)
+			<-release
+			_, _ = w.Write([]byte(`[{"name": "npmjs.org", "ecosystem": "npm"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "rubygems.org", "ecosystem": "rubygems"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list, err := client.loadRegistries(context.Background())
+			if err != nil || len(list) != 1 {
+				t.Errorf("loadRegistries() = %v, %v", list, err)
+			}
+		}()
+	}
+	for lists.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Waiters give up with their own context, and other lookups do not
+	// wait for the fetch.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.loadRegistries(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("loadRegistries(canceled) error = %v, want context.Canceled", err)
+	}
+	if reg, err := client.GetRegistry(context.Background(), "rubygems.org"); err != nil || reg == nil {
+		t.Errorf("GetRegistry() during fetch = %v, %v", reg, err)
+	}
+
+	close(release)
+	wg.Wait()
+	if got := lists.Load(); got != 1 {
+		t.Errorf("registry list fetched %d times, want 1", got)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	tests := []struct {
+		name       string
+		registries []packages.Registry
+		want       string
+	}{
+		{"default present", []packages.Registry{{Name: "mirror"}, {Name: "npmjs.org", Default: true}}, "npmjs.org"},
+		{"no default", []packages.Registry{{Name: "mirror"}}, ""},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DefaultRegistry(tt.registries)
+			if got.Name != tt.want || ok != (tt.want != "") {
+				t.Errorf("DefaultRegistry() = %q, %v, want %q", got.Name, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRegistryMappings(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddRegistry(packages.Registry{Name: "crates.io", Ecosystem: "npm"})
+
+	err := client.VerifyRegistryMappings(context.Background())
+	if err == nil {
+		t.Fatal("VerifyRegistryMappings() against a partial registry list should error")
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		`PURL type "pypi" maps to unknown registry "pypi.org"`,
+		`PURL type "cargo" maps to registry "crates.io", whose ecosystem "npm" has PURL type "npm"`,
+		`deb distribution "ubuntu" maps to unknown registry "ubuntu"`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("VerifyRegistryMappings() error missing %q", want)
+		}
+	}
+	for _, mapped := range []string{`"rubygems.org"`, `"npmjs.org"`} {
+		if strings.Contains(msg, mapped) {
+			t.Errorf("VerifyRegistryMappings() error mentions listed registry %s", mapped)
+		}
+	}
+}
+
+func TestVerifyRegistryMappingsComplete(t *testing.T) {
+	client, srv := newTestClient(t)
+	listed := map[string]bool{"rubygems.org": true, "npmjs.org": true}
+	add := func(registry, purlType string) {
+		if registry == "" || listed[registry] {
+			return
+		}
+		listed[registry] = true
+		var ecosystem string
+		for e, t := range ecosystemToPURLType {
+			if t == purlType {
+				ecosystem = e
+			}
+		}
+		srv.AddRegistry(packages.Registry{Name: registry, Ecosystem: ecosystem})
+	}
+	for t, registry := range purlTypeToRegistry {
+		add(registry, t)
+	}
+	for t, distros := range distroRegistries {
+		for _, registry := range distros {
+			add(registry, t)
+		}
+	}
+	for _, registry := range repositoryURLRegistries {
+		add(registry, "")
+	}
+
+	if err := client.VerifyRegistryMappings(context.Background()); err != nil {
+		t.Errorf("VerifyRegistryMappings() error = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Kinds of files ecosyste.ms detects in repositories, the keys of
+// RepositoryFiles.
+const (
+	FileReadme        = "readme"
+	FileLicense       = "license"
+	FileChangelog     = "changelog"
+	FileContributing  = "contributing"
+	FileCodeOfConduct = "code_of_conduct"
+	FileSecurity      = "security"
+	FileCodeowners    = "codeowners"
+	FileFunding       = "funding"
+	FileCitation      = "citation"
+)
+
+// RepositoryFiles maps the kinds of community and policy files found in a
+// repository, such as FileSecurity, to their paths.
+type RepositoryFiles map[string]string
+
+// Has reports whether the repository has a file of the given kind.
+func (f RepositoryFiles) Has(kind string) bool {
+	return f[kind] != ""
+}
+
+// RepositoryFile is the content of one file of a repository.
+type RepositoryFile struct {
+	Path     string
+	Contents string
+}
+
+// GetRepositoryFiles returns the README, license, security policy,
+// CODEOWNERS and other community files ecosyste.ms found in a repository,
+// for documentation and security policy checks. It returns nil if the
+// repository is not known, and an empty map if it has none of them.
+func (c *Client) GetRepositoryFiles(ctx context.Context, repoURL string, opts ...CallOption) (RepositoryFiles, error) {
+	repo, err := c.GetRepository(ctx, repoURL, opts...)
+	if err != nil || repo == nil {
+		return nil, err
+	}
+	files := make(RepositoryFiles)
+	if repo.Metadata == nil {
+		return files, nil
+	}
+	found, _ := (*repo.Metadata)["files"].(map[string]interface{})
+	for kind, path := range found {
+		if p, ok := path.(string); ok && p != "" {
+			files[kind] = p
+		}
+	}
+	return files, nil
+}
+
+// GetRepositoryFile returns the contents of a file, such as the path
+// GetRepositoryFiles reports for FileSecurity, from the repository's
+// default branch archive on archives.ecosyste.ms. It returns nil if the
+// repository or the file is not known.
+func (c *Client) GetRepositoryFile(ctx context.Context, repoURL, path string, opts ...CallOption) (*RepositoryFile, error) {
+	repo, err := c.GetRepository(ctx, repoURL, opts...)
+	if err != nil || repo == nil {
+		return nil, err
+	}
+	if deref(repo.DownloadUrl) == "" {
+		return nil, fmt.Errorf("repository %s has no archive download URL", repoURL)
+	}
+
+	var entry struct {
+		Name      string `json:"name"`
+		Directory bool   `json:"directory"`
+		Contents  string `json:"contents"`
+	}
+	query := url.Values{"url": {*repo.DownloadUrl}, "path": {path}}
+	err = c.services.lookup(ServiceArchives).GetJSON(ctx, "archives/contents", query, &entry, opts...)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Directory {
+		return nil, fmt.Errorf("%s in %s is a directory", path, repoURL)
+	}
+	return &RepositoryFile{Path: path, Contents: entry.Contents}, nil
+}
@@ -0,0 +1,99 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+func TestGetRepositoryFiles(t *testing.T) {
+	client, srv := newTestClient(t)
+	repoURL := "https://github.com/example/files"
+	srv.AddRepository(repos.Repository{
+		HtmlUrl: &repoURL,
+		Metadata: &map[string]interface{}{
+			"files": map[string]interface{}{
+				"readme":     "README.md",
+				"security":   ".github/SECURITY.md",
+				"codeowners": nil,
+			},
+		},
+	})
+
+	files, err := client.GetRepositoryFiles(context.Background(), repoURL)
+	if err != nil {
+		t.Fatalf("GetRepositoryFiles() error = %v", err)
+	}
+	want := RepositoryFiles{FileReadme: "README.md", FileSecurity: ".github/SECURITY.md"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("GetRepositoryFiles() = %v, want %v", files, want)
+	}
+	if !files.Has(FileSecurity) || files.Has(FileCodeowners) {
+		t.Errorf("Has() = %v, %v, want true, false", files.Has(FileSecurity), files.Has(FileCodeowners))
+	}
+
+	missing, err := client.GetRepositoryFiles(context.Background(), "https://github.com/example/missing")
+	if err != nil || missing != nil {
+		t.Errorf("GetRepositoryFiles(unknown) = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestGetRepositoryFile(t *testing.T) {
+	_, srv := newTestClient(t)
+	downloadURL := "https://codeload.github.com/example/files/tar.gz/main"
+	archives := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/archives/contents" || q.Get("url") != downloadURL {
+			t.Errorf("request = %s, want archives/contents for %s", r.URL, downloadURL)
+		}
+		entry := map[string]any{"name": q.Get("path")}
+		switch q.Get("path") {
+		case "SECURITY.md":
+			entry["contents"] = "Report issues to security@example.com"
+		case ".github":
+			entry["directory"] = true
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entry)
+	}))
+	defer archives.Close()
+	client, err := NewClient("test-agent/1.0",
+		WithReposServer(srv.ReposURL()),
+		WithService(ServiceArchives, ServiceConfig{BaseURL: archives.URL}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	repoURL := "https://github.com/example/files"
+	srv.AddRepository(repos.Repository{HtmlUrl: &repoURL, DownloadUrl: &downloadURL})
+	ctx := context.Background()
+
+	file, err := client.GetRepositoryFile(ctx, repoURL, "SECURITY.md")
+	if err != nil {
+		t.Fatalf("GetRepositoryFile() error = %v", err)
+	}
+	want := &RepositoryFile{Path: "SECURITY.md", Contents: "Report issues to security@example.com"}
+	if !reflect.DeepEqual(file, want) {
+		t.Errorf("GetRepositoryFile() = %+v, want %+v", file, want)
+	}
+
+	file, err = client.GetRepositoryFile(ctx, repoURL, "CODEOWNERS")
+	if err != nil || file != nil {
+		t.Errorf("GetRepositoryFile(missing file) = %v, %v, want nil, nil", file, err)
+	}
+	if _, err := client.GetRepositoryFile(ctx, repoURL, ".github"); err == nil {
+		t.Error("GetRepositoryFile(directory) error = nil, want error")
+	}
+	file, err = client.GetRepositoryFile(ctx, "https://github.com/example/missing", "README.md")
+	if err != nil || file != nil {
+		t.Errorf("GetRepositoryFile(unknown repository) = %v, %v, want nil, nil", file, err)
+	}
+}
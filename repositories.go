@@ -0,0 +1,154 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// maxRepositoryWorkers bounds the lookups BulkGetRepositories makes at once.
+const maxRepositoryWorkers = 8
+
+// BulkRepositoriesResult is the outcome of BulkGetRepositories.
+type BulkRepositoriesResult struct {
+	// Repositories maps each found repository's normalized URL to its data.
+	Repositories map[string]*repos.Repository
+	// Missing lists requested URLs the API did not recognize, in request order.
+	Missing []string
+}
+
+// BulkGetRepositories looks up many repositories by URL, a few at a time.
+// URLs are normalized before lookup, so duplicates such as
+// "https://github.com/rails/rails" and "https://github.com/rails/rails.git"
+// are only requested once. The first failed lookup stops the rest and is
+// returned.
+func (c *Client) BulkGetRepositories(ctx context.Context, urls []string, opts ...CallOption) (*BulkRepositoriesResult, error) {
+	result := &BulkRepositoriesResult{Repositories: make(map[string]*repos.Repository)}
+
+	var unique []string
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		n := NormalizeRepoURL(u)
+		if !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+
+	var mu sync.Mutex
+	err := forEach(ctx, unique, maxRepositoryWorkers, func(ctx context.Context, u string) error {
+		repo, err := c.GetRepository(ctx, u, opts...)
+		if err != nil || repo == nil {
+			return err
+		}
+		mu.Lock()
+		result.Repositories[u] = repo
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range urls {
+		if result.Repositories[NormalizeRepoURL(u)] == nil {
+			result.Missing = append(result.Missing, u)
+		}
+	}
+	return result, nil
+}
+
+// ErrNoRepository is returned by GetRepositoryForPackage when the package
+// metadata does not link to a source repository.
+var ErrNoRepository = errors.New("package has no repository URL")
+
+// GetRepositoryForPackage looks up a package by PURL and then the source
+// repository its metadata links to. The package's repository URL is used,
+// or its homepage when that is on GitHub, GitLab or Bitbucket. It returns
+// nil if the package or the repository is not known, and an error wrapping
+// ErrNoRepository if the package has no repository URL.
+func (c *Client) GetRepositoryForPackage(ctx context.Context, purl string, opts ...CallOption) (*repos.Repository, error) {
+	pkg, err := c.Lookup(ctx, purl, opts...)
+	if err != nil || pkg == nil {
+		return nil, err
+	}
+
+	repoURL := packageRepoURL(pkg.RepositoryUrl, pkg.Homepage)
+	if repoURL == "" {
+		return nil, fmt.Errorf("%s: %w", purl, ErrNoRepository)
+	}
+	return c.GetRepository(ctx, repoURL, opts...)
+}
+
+// packageRepoURL picks the normalized repository URL from a package's
+// repository and homepage fields.
+func packageRepoURL(repository, homepage *string) string {
+	if repository != nil && strings.TrimSpace(*repository) != "" {
+		return NormalizeRepoURL(*repository)
+	}
+	if homepage == nil {
+		return ""
+	}
+	u, err := url.Parse(NormalizeRepoURL(*homepage))
+	if err != nil {
+		return ""
+	}
+	for _, host := range purlTypeToRepositoryHost {
+		if u.Host == host {
+			return u.String()
+		}
+	}
+	return ""
+}
+
+// NormalizeRepoURL rewrites a repository URL as found in package metadata
+// into the form repos.ecosyste.ms knows: "git+" prefixes, ".git" suffixes,
+// trailing slashes, credentials, queries and fragments are removed, SSH and
+// git:// URLs become https, and the host is lowercased. For example
+// "git+ssh://git@GitHub.com/rails/rails.git" becomes
+// "https://github.com/rails/rails". A URL without a scheme, such as
+// "github.com/rails/rails", is taken as https only if its host contains a
+// dot. Other strings that are not URLs, such as a bare word like "rails",
+// are returned trimmed but otherwise unchanged.
+func NormalizeRepoURL(raw string) string {
+	s := strings.TrimSpace(raw)
+	if len(s) >= 4 && strings.EqualFold(s[:4], "git+") {
+		s = s[4:]
+	}
+	if !strings.Contains(s, "://") {
+		// scp-like SSH syntax: git@github.com:rails/rails.git
+		if at, colon := strings.Index(s, "@"), strings.Index(s, ":"); at >= 0 && colon > at {
+			s = "ssh://" + s[:colon] + "/" + s[colon+1:]
+		} else if host, _, _ := strings.Cut(s, "/"); strings.Contains(host, ".") {
+			s = "https://" + s
+		} else {
+			return strings.TrimSpace(raw)
+		}
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+	switch u.Scheme {
+	case "ssh", "git":
+		u.Scheme = "https"
+		u.Host = u.Hostname()
+		if u.Host == "" {
+			return strings.TrimSpace(raw)
+		}
+	}
+	u.User = nil
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.RawPath = ""
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.Path = strings.TrimSuffix(u.Path, ".git")
+	return u.String()
+}
@@ -0,0 +1,176 @@
+package ecosystems
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// repositoryURLRegistries maps repository_url qualifier hosts to the
+// ecosyste.ms registries that mirror them.
+var repositoryURLRegistries = map[string]string{
+	"repo1.maven.org":       "repo1.maven.org",
+	"repo.maven.apache.org": "repo1.maven.org",
+	"maven.google.com":      "maven.google.com",
+	"dl.google.com":         "maven.google.com",
+	"repo.clojars.org":      "clojars.org",
+	"clojars.org":           "clojars.org",
+	"registry.npmjs.org":    "npmjs.org",
+	"npmjs.org":             "npmjs.org",
+	"pypi.org":              "pypi.org",
+	"rubygems.org":          "rubygems.org",
+	"crates.io":             "crates.io",
+	"nuget.org":             "nuget.org",
+	"api.nuget.org":         "nuget.org",
+}
+
+// distroRegistries maps Linux distribution PURL namespaces to ecosyste.ms
+// registries, per PURL type.
+var distroRegistries = map[string]map[string]string{
+	packageurl.TypeDebian: {
+		"debian": "debian",
+		"ubuntu": "ubuntu",
+	},
+	packageurl.TypeRPM: {
+		"fedora": "fedora",
+	},
+	packageurl.TypeApk: {
+		"alpine": "alpine-edge",
+	},
+	packageurl.TypeAlpm: {
+		"arch":      "archlinux.org",
+		"archlinux": "archlinux.org",
+	},
+}
+
+// distroQualifiers are the qualifiers of Linux package PURLs that describe
+// a build of a package rather than select it, and are trimmed before
+// lookups.
+var distroQualifiers = []string{"arch", "epoch"}
+
+// trimDistroQualifiers returns purl without its distroQualifiers if it is
+// a Linux package PURL.
+func trimDistroQualifiers(purl packageurl.PackageURL) packageurl.PackageURL {
+	if _, ok := distroRegistries[purl.Type]; !ok {
+		return purl
+	}
+	purl.Qualifiers = slices.DeleteFunc(slices.Clone(purl.Qualifiers), func(q packageurl.Qualifier) bool {
+		return slices.Contains(distroQualifiers, q.Key)
+	})
+	if len(purl.Qualifiers) == 0 {
+		purl.Qualifiers = nil
+	}
+	return purl
+}
+
+// trimDistroPURL is trimDistroQualifiers for a PURL string, parsed and
+// formatted with the client's PURLParser so bare PURLs are trimmed too.
+// Strings that do not parse, or have nothing to trim, are returned
+// unchanged.
+func (c *Client) trimDistroPURL(s string) string {
+	purl, err := c.ParsePURL(s)
+	if err != nil {
+		return s
+	}
+	trimmed := trimDistroQualifiers(purl)
+	if len(trimmed.Qualifiers) == len(purl.Qualifiers) {
+		return s
+	}
+	return c.FormatPURL(trimmed)
+}
+
+// ResolveRegistry selects the ecosyste.ms registry for a PURL and explains
+// the choice. A repository_url qualifier naming a known registry takes
+// precedence, followed by the distribution namespace of Linux package
+// PURLs such as pkg:deb/ubuntu/curl, then the PURL type's default registry.
+// It returns an empty registry when the PURL cannot be mapped, including
+// Linux package PURLs naming a distribution without a known registry, such
+// as pkg:rpm/redhat/curl.
+func ResolveRegistry(purl packageurl.PackageURL) (registry, reason string) {
+	return resolveRegistry(purl, nil)
+}
+
+// ResolveRegistry is like the package-level ResolveRegistry, with the
+// client's WithRegistryOverride mappings replacing the default registries.
+func (c *Client) ResolveRegistry(purl packageurl.PackageURL) (registry, reason string) {
+	return resolveRegistry(purl, c.purlRegistries)
+}
+
+// registryFor returns the registry the client's PURL methods query for purl.
+func (c *Client) registryFor(purl packageurl.PackageURL) string {
+	registry, _ := c.ResolveRegistry(purl)
+	return registry
+}
+
+func resolveRegistry(purl packageurl.PackageURL, overrides map[string]string) (registry, reason string) {
+	var note string
+	if repoURL := purl.Qualifiers.Map()["repository_url"]; repoURL != "" {
+		host := repositoryURLHost(repoURL)
+		if registry, ok := repositoryURLRegistries[host]; ok {
+			return registry, fmt.Sprintf("repository_url qualifier %q", host)
+		}
+		note = fmt.Sprintf("; repository_url %q is not a known registry", host)
+	}
+
+	if distros, ok := distroRegistries[purl.Type]; ok {
+		namespace := strings.ToLower(purl.Namespace)
+		if registry, ok := distros[namespace]; ok {
+			return registry, fmt.Sprintf("%s distribution %q%s", purl.Type, namespace, note)
+		}
+		if _, ok := overrides[purl.Type]; !ok && namespace != "" {
+			return "", fmt.Sprintf("unsupported %s distribution %q%s", purl.Type, namespace, note)
+		}
+	}
+
+	if registry, ok := overrides[purl.Type]; ok {
+		return registry, fmt.Sprintf("registry override for PURL type %q%s", purl.Type, note)
+	}
+	registry = purlTypeToRegistry[purl.Type]
+	if registry == "" {
+		return "", fmt.Sprintf("no registry for PURL type %q%s", purl.Type, note)
+	}
+	return registry, fmt.Sprintf("default registry for PURL type %q%s", purl.Type, note)
+}
+
+// repositoryURLHost returns the host of a repository_url qualifier, which
+// is often given without a scheme.
+func repositoryURLHost(repoURL string) string {
+	if !strings.Contains(repoURL, "://") {
+		repoURL = "https://" + repoURL
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// WithRegistryOverride routes PURLs of purlType to registry instead of the
+// type's default, for example pkg:npm to the name of an internal npm mirror
+// indexed by a self-hosted ecosyste.ms instance. A repository_url qualifier
+// or distribution namespace that selects a registry still takes
+// precedence. Bulk lookups are resolved by the server and are not
+// affected.
+func WithRegistryOverride(purlType, registry string) Option {
+	return func(c *clientConfig) {
+		if c.registryOverride == nil {
+			c.registryOverride = make(map[string]string)
+		}
+		c.registryOverride[purlType] = registry
+	}
+}
+
+// WithMavenRegistries sets the registries tried, in order, by LookupPURL,
+// GetVersionPURL and GetAllVersionsPURL for Maven PURLs whose
+// repository_url qualifier does not select a registry, for example
+// "maven.google.com" for Android libraries. Maven Central is tried last
+// unless it is listed. Bulk lookups are resolved by the server and are not
+// affected.
+func WithMavenRegistries(registries ...string) Option {
+	return func(c *clientConfig) {
+		c.mavenRegistries = registries
+	}
+}
@@ -0,0 +1,197 @@
+package ecosystems
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestResolveRegistry(t *testing.T) {
+	tests := []struct {
+		purl       string
+		registry   string
+		reasonPart string
+	}{
+		{"pkg:maven/androidx.core/core@1.12.0?repository_url=https://maven.google.com", "maven.google.com", "repository_url"},
+		{"pkg:maven/androidx.core/core?repository_url=maven.google.com", "maven.google.com", "repository_url"},
+		{"pkg:maven/org.clojure/clojure?repository_url=https://repo.clojars.org/", "clojars.org", "repository_url"},
+		{"pkg:maven/com.example/lib?repository_url=https://nexus.example.com/repo", "repo1.maven.org", "not a known registry"},
+		{"pkg:maven/org.apache.commons/commons-lang3", "repo1.maven.org", "default registry"},
+		{"pkg:npm/lodash?repository_url=https://registry.npmjs.org", "npmjs.org", "repository_url"},
+		{"pkg:gem/rails", "rubygems.org", "default registry"},
+		{"pkg:deb/ubuntu/curl@7.81.0-1ubuntu1.15?arch=amd64&distro=jammy", "ubuntu", "distribution \"ubuntu\""},
+		{"pkg:deb/debian/curl@7.88.1-10?arch=arm64", "debian", "distribution \"debian\""},
+		{"pkg:deb/kali/curl", "", "unsupported deb distribution \"kali\""},
+		{"pkg:rpm/fedora/curl@8.2.1-3.fc39?arch=x86_64&epoch=1", "fedora", "distribution \"fedora\""},
+		{"pkg:rpm/redhat/curl", "", "unsupported rpm distribution \"redhat\""},
+		{"pkg:rpm/opensuse/curl?arch=x86_64", "", "unsupported rpm distribution \"opensuse\""},
+		{"pkg:apk/alpine/curl", "alpine-edge", "distribution"},
+		{"pkg:alpm/arch/curl", "archlinux.org", "distribution"},
+		{"pkg:generic/foo", "", "no registry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			purl, err := ParsePURL(tt.purl)
+			if err != nil {
+				t.Fatalf("ParsePURL() error = %v", err)
+			}
+			registry, reason := ResolveRegistry(purl)
+			if registry != tt.registry {
+				t.Errorf("ResolveRegistry() registry = %q, want %q", registry, tt.registry)
+			}
+			if !strings.Contains(reason, tt.reasonPart) {
+				t.Errorf("ResolveRegistry() reason = %q, want it to contain %q", reason, tt.reasonPart)
+			}
+			if got := PURLToRegistry(purl); got != tt.registry {
+				t.Errorf("PURLToRegistry() = %q, want %q", got, tt.registry)
+			}
+		})
+	}
+}
+
+func TestWithMavenRegistries(t *testing.T) {
+	_, srv := newTestClient(t)
+	srv.AddPackage("maven.google.com", registryPackage("maven.google.com", "androidx.core:core"))
+	srv.AddPackage("repo1.maven.org", registryPackage("repo1.maven.org", "com.google.guava:guava"))
+	srv.AddPackage("maven.google.com", registryPackage("maven.google.com", "com.example:both"))
+	srv.AddPackage("repo1.maven.org", registryPackage("repo1.maven.org", "com.example:both"))
+
+	tests := []struct {
+		name     string
+		order    []string
+		purl     string
+		registry string
+	}{
+		{"configured order", []string{"maven.google.com"}, "pkg:maven/androidx.core/core", "maven.google.com"},
+		{"falls back to central", []string{"maven.google.com"}, "pkg:maven/com.google.guava/guava", "repo1.maven.org"},
+		{"first match wins", []string{"maven.google.com"}, "pkg:maven/com.example/both", "maven.google.com"},
+		{"qualifier overrides order", []string{"maven.google.com"}, "pkg:maven/com.example/both?repository_url=repo1.maven.org", "repo1.maven.org"},
+		{"central only by default", nil, "pkg:maven/androidx.core/core", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.PackagesURL()), WithMavenRegistries(tt.order...))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			purl, err := ParsePURL(tt.purl)
+			if err != nil {
+				t.Fatalf("ParsePURL() error = %v", err)
+			}
+			pkg, err := client.LookupPURL(context.Background(), purl)
+			if err != nil {
+				t.Fatalf("LookupPURL() error = %v", err)
+			}
+			var got string
+			if pkg != nil {
+				got = deref(pkg.RegistryUrl)
+			}
+			if got != tt.registry {
+				t.Errorf("LookupPURL() registry = %q, want %q", got, tt.registry)
+			}
+		})
+	}
+}
+
+func TestWithRegistryOverride(t *testing.T) {
+	_, srv := newTestClient(t)
+	srv.AddPackage("npm.internal.example.com", registryPackage("npm.internal.example.com", "left-pad"))
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithRegistryOverride("npm", "npm.internal.example.com"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		purl       string
+		registry   string
+		reasonPart string
+	}{
+		{"pkg:npm/left-pad", "npm.internal.example.com", "registry override"},
+		{"pkg:npm/left-pad?repository_url=https://registry.npmjs.org", "npmjs.org", "repository_url"},
+		{"pkg:gem/rails", "rubygems.org", "default registry"},
+	}
+	for _, tt := range tests {
+		purl, _ := ParsePURL(tt.purl)
+		registry, reason := client.ResolveRegistry(purl)
+		if registry != tt.registry || !strings.Contains(reason, tt.reasonPart) {
+			t.Errorf("ResolveRegistry(%s) = %q, %q, want %q with %q", tt.purl, registry, reason, tt.registry, tt.reasonPart)
+		}
+	}
+
+	purl, _ := ParsePURL("pkg:npm/left-pad")
+	pkg, err := client.LookupPURL(context.Background(), purl)
+	if err != nil || pkg == nil || deref(pkg.RegistryUrl) != "npm.internal.example.com" {
+		t.Errorf("LookupPURL() = %v, %v, want the package from the internal registry", pkg, err)
+	}
+}
+
+func TestDistroPURLLookups(t *testing.T) {
+	client, srv := newTestClient(t)
+	pkg := registryPackage("fedora", "curl")
+	pkg.Purl = "pkg:rpm/fedora/curl"
+	srv.AddPackage("fedora", pkg)
+	srv.AddPackage("opensuse", registryPackage("opensuse", "curl"))
+	ctx := context.Background()
+
+	for _, s := range []string{"pkg:rpm/redhat/curl", "pkg:rpm/opensuse/curl@8.0.1?arch=x86_64"} {
+		purl, _ := ParsePURL(s)
+		if got, err := client.LookupPURL(ctx, purl); err == nil || !strings.Contains(err.Error(), "unsupported rpm distribution") {
+			t.Errorf("LookupPURL(%s) = %v, %v, want an unsupported distribution error", s, got, err)
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("made %d requests for unsupported distributions, want 0", n)
+	}
+
+	purl, _ := ParsePURL("pkg:rpm/fedora/curl@8.2.1-3.fc39?arch=x86_64&epoch=1")
+	if got, err := client.LookupPURL(ctx, purl); err != nil || got == nil {
+		t.Errorf("LookupPURL(fedora) = %v, %v, want the fedora package", got, err)
+	}
+
+	const withQualifiers = "pkg:rpm/fedora/curl?arch=x86_64&epoch=1"
+	got, err := client.Lookup(ctx, withQualifiers)
+	if err != nil || got == nil || got.Name != "curl" {
+		t.Errorf("Lookup(%s) = %v, %v, want curl", withQualifiers, got, err)
+	}
+
+	const bare = "rpm/fedora/curl?arch=x86_64"
+	result, err := client.BulkLookupDetailed(ctx, []string{bare}, CallStrict())
+	if err != nil || result.Packages[bare] == nil || len(result.Missing) != 0 {
+		t.Errorf("BulkLookupDetailed(%s) = %+v, %v, want curl under the given PURL", bare, result, err)
+	}
+}
+
+func TestTrimDistroPURL(t *testing.T) {
+	client, _ := newTestClient(t)
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{"pkg:rpm/fedora/curl@8.2.1-3.fc39?arch=x86_64&epoch=1", "pkg:rpm/fedora/curl@8.2.1-3.fc39"},
+		{"pkg:deb/ubuntu/curl@7.81.0?arch=amd64&distro=jammy", "pkg:deb/ubuntu/curl@7.81.0?distro=jammy"},
+		{"pkg:apk/alpine/curl?arch=x86", "pkg:apk/alpine/curl"},
+		{"rpm/fedora/curl@8.2.1-3.fc39?arch=x86_64", "pkg:rpm/fedora/curl@8.2.1-3.fc39"},
+		{"rpm/fedora/curl", "rpm/fedora/curl"},
+		{"pkg:deb/debian/curl", "pkg:deb/debian/curl"},
+		{"pkg:npm/lodash?arch=x86", "pkg:npm/lodash?arch=x86"},
+		{"not a purl", "not a purl"},
+	}
+
+	for _, tt := range tests {
+		if got := client.trimDistroPURL(tt.purl); got != tt.want {
+			t.Errorf("trimDistroPURL(%q) = %q, want %q", tt.purl, got, tt.want)
+		}
+	}
+}
+
+// registryPackage returns a package whose registry_url names its registry.
+func registryPackage(registry, name string) packages.PackageWithRegistry {
+	return packages.PackageWithRegistry{Name: name, RegistryUrl: &registry}
+}
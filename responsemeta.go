@@ -0,0 +1,141 @@
+package ecosystems
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResponseMeta holds the caching and freshness headers of a single API
+// response, for callers that implement their own cache or conditional
+// re-fetching. It is filled in by CallResponseMeta.
+type ResponseMeta struct {
+	StatusCode   int
+	ETag         string
+	LastModified time.Time
+	Date         time.Time
+	Expires      time.Time
+	CacheControl string
+	// Age is the time the response spent in caches, from the Age header.
+	Age time.Duration
+	// Header is the full response header.
+	Header http.Header
+}
+
+// NotModified reports whether the server answered a conditional request
+// (sent with an If-None-Match or If-Modified-Since CallHeader) with
+// 304 Not Modified. The method itself returns an error in that case.
+func (m *ResponseMeta) NotModified() bool {
+	return m.StatusCode == http.StatusNotModified
+}
+
+func (m *ResponseMeta) set(resp *http.Response) {
+	h := resp.Header
+	m.StatusCode = resp.StatusCode
+	m.ETag = h.Get("ETag")
+	m.LastModified = parseHTTPTime(h.Get("Last-Modified"))
+	m.Date = parseHTTPTime(h.Get("Date"))
+	m.Expires = parseHTTPTime(h.Get("Expires"))
+	m.CacheControl = h.Get("Cache-Control")
+	m.Age = 0
+	if secs, err := strconv.Atoi(h.Get("Age")); err == nil {
+		m.Age = time.Duration(secs) * time.Second
+	}
+	m.Header = h.Clone()
+}
+
+func parseHTTPTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// CallResponseMeta fills meta with the caching headers of the call's
+// response. Calls that make several requests, such as GetAllVersions,
+// record only the first, so meta never mixes headers from different
+// responses; a rate limited response that is retried is replaced by the
+// retry's.
+func CallResponseMeta(meta *ResponseMeta) CallOption {
+	return func(c *callConfig) {
+		c.meta = &responseSink{meta: meta}
+	}
+}
+
+// CallRawBody fills body with the undecoded JSON of the call's response,
+// alongside the typed result, for fields the API added before the
+// generated types caught up. Calls that make several requests, such as
+// GetAllVersions, record only the body of the first response, as
+// CallResponseMeta does.
+func CallRawBody(body *json.RawMessage) CallOption {
+	return func(c *callConfig) {
+		c.rawBody = &responseSink{body: body}
+	}
+}
+
+// responseSink fills a CallResponseMeta or CallRawBody destination once,
+// guarding it against concurrent pages.
+type responseSink struct {
+	mu     sync.Mutex
+	status int
+	meta   *ResponseMeta
+	body   *json.RawMessage
+}
+
+// record fills the destination from resp if the sink is empty, or only
+// holds a rate limited response that is being retried.
+func (s *responseSink) record(resp *http.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status != 0 && s.status != http.StatusTooManyRequests {
+		return nil
+	}
+	s.status = resp.StatusCode
+	if s.meta != nil {
+		s.meta.set(resp)
+	}
+	if s.body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		*s.body = body
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return nil
+}
+
+type responseMetaKey struct{}
+
+type rawBodyKey struct{}
+
+// metaTransport records response headers into the ResponseMeta, and
+// response bodies into the CallRawBody destination, carried by the request
+// context, if any.
+type metaTransport struct {
+	next http.RoundTripper
+}
+
+func (t *metaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []any{responseMetaKey{}, rawBodyKey{}} {
+		if sink, ok := req.Context().Value(key).(*responseSink); ok {
+			if err := sink.record(resp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
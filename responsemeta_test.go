@@ -0,0 +1,134 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallResponseMeta(t *testing.T) {
+	lastModified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Header().Set("Age", "42")
+		_, _ = w.Write([]byte(`{"name": "lodash"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var meta ResponseMeta
+	pkg, err := client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash", CallResponseMeta(&meta))
+	if err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if pkg == nil || pkg.Name != "lodash" {
+		t.Fatalf("LookupByRegistryAndName() = %v, want lodash", pkg)
+	}
+	if meta.ETag != `"v1"` {
+		t.Errorf("ETag = %q, want %q", meta.ETag, `"v1"`)
+	}
+	if !meta.LastModified.Equal(lastModified) {
+		t.Errorf("LastModified = %v, want %v", meta.LastModified, lastModified)
+	}
+	if meta.CacheControl != "public, max-age=300" {
+		t.Errorf("CacheControl = %q", meta.CacheControl)
+	}
+	if meta.Age != 42*time.Second {
+		t.Errorf("Age = %v, want 42s", meta.Age)
+	}
+	if meta.NotModified() {
+		t.Error("NotModified() = true, want false")
+	}
+
+	var revalidated ResponseMeta
+	_, err = client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash",
+		CallHeader("If-None-Match", meta.ETag), CallResponseMeta(&revalidated))
+	if err == nil {
+		t.Error("LookupByRegistryAndName() error = nil, want status error for 304")
+	}
+	if !revalidated.NotModified() {
+		t.Errorf("NotModified() = false, StatusCode = %d", revalidated.StatusCode)
+	}
+}
+
+func TestCallResponseMetaFirstResponse(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"page-`+page+`"`)
+		w.Header().Set("Current-Page", page)
+		w.Header().Set("Total-Pages", "4")
+		_, _ = w.Write([]byte(`[{"number": "1.0.` + page + `"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var meta ResponseMeta
+	var raw json.RawMessage
+	versions, err := client.GetAllVersions(context.Background(), "npmjs.org", "lodash", CallResponseMeta(&meta), CallRawBody(&raw))
+	if err != nil {
+		t.Fatalf("GetAllVersions() error = %v", err)
+	}
+	if len(versions) != 4 {
+		t.Fatalf("GetAllVersions() = %d versions, want 4", len(versions))
+	}
+	if meta.StatusCode != http.StatusOK || meta.ETag != `"page-1"` {
+		t.Errorf("meta = %d %s, want 200 \"page-1\"", meta.StatusCode, meta.ETag)
+	}
+	if string(raw) != `[{"number": "1.0.1"}]` {
+		t.Errorf("raw body = %s, want the first page", raw)
+	}
+}
+
+func TestCallRawBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "lodash", "brand_new_field": {"score": 7}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(server.URL), WithCompression())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var raw json.RawMessage
+	pkg, err := client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash", CallRawBody(&raw))
+	if err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+	if pkg == nil || pkg.Name != "lodash" {
+		t.Fatalf("LookupByRegistryAndName() = %v, want lodash", pkg)
+	}
+	var extra struct {
+		BrandNewField struct{ Score int } `json:"brand_new_field"`
+	}
+	if err := json.Unmarshal(raw, &extra); err != nil || extra.BrandNewField.Score != 7 {
+		t.Errorf("raw body = %s, %v, want brand_new_field.score 7", raw, err)
+	}
+}
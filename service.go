@@ -0,0 +1,294 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Names of the ecosyste.ms services the client knows the URLs of. Other
+// services can be added by name with WithService.
+const (
+	ServicePackages       = "packages"
+	ServiceRepos          = "repos"
+	ServiceAdvisories     = "advisories"
+	ServiceSummary        = "summary"
+	ServiceSponsors       = "sponsors"
+	ServiceArchives       = "archives"
+	ServiceOpenCollective = "opencollective"
+)
+
+// defaultServiceURLs are the base URLs of the known services.
+var defaultServiceURLs = map[string]string{
+	ServicePackages:       DefaultPackagesServer,
+	ServiceRepos:          DefaultReposServer,
+	ServiceAdvisories:     "https://advisories.ecosyste.ms/api/v1",
+	ServiceSummary:        "https://summary.ecosyste.ms/api/v1",
+	ServiceSponsors:       "https://sponsors.ecosyste.ms/api/v1",
+	ServiceArchives:       "https://archives.ecosyste.ms/api/v1",
+	ServiceOpenCollective: "https://opencollective.ecosyste.ms/api/v1",
+}
+
+// WithBaseDomain derives the URL of every service not given one with
+// WithService, WithPackagesServer or WithReposServer from domain, as
+// https://<service>.<domain>/api/v1, for staging or self-hosted
+// deployments. The domain may carry a scheme, as in
+// "http://ecosystems.internal".
+func WithBaseDomain(domain string) Option {
+	return func(c *clientConfig) {
+		c.baseDomain = domain
+	}
+}
+
+// serviceURL returns the base URL of the named service under domain, or
+// its default URL when domain is empty.
+func serviceURL(name, domain string) string {
+	if domain == "" {
+		return defaultServiceURLs[name]
+	}
+	scheme, host, ok := strings.Cut(strings.TrimSuffix(domain, "/"), "://")
+	if !ok {
+		scheme, host = "https", scheme
+	}
+	return fmt.Sprintf("%s://%s.%s/api/v1", scheme, name, host)
+}
+
+// ErrServiceDisabled is returned by requests to a service disabled with
+// WithService.
+var ErrServiceDisabled = errors.New("service disabled")
+
+// ServiceConfig configures one ecosyste.ms service.
+type ServiceConfig struct {
+	// BaseURL is the service's API root. Empty keeps the default URL of a
+	// known service, or the one derived by WithBaseDomain; other services
+	// need one.
+	BaseURL string
+	// Disabled makes every request to the service fail with
+	// ErrServiceDisabled without contacting it.
+	Disabled bool
+	// APIKey replaces the key set with WithAPIKey for this service.
+	APIKey string
+	// NoAPIKey sends no key to this service, even with WithAPIKey.
+	NoAPIKey bool
+	// Header holds headers added to every request to the service, after
+	// the client's own and before those of WithRequestEditor.
+	Header http.Header
+}
+
+// WithService configures the named service, replacing any earlier
+// configuration of it, including that of WithPackagesServer or
+// WithReposServer.
+func WithService(name string, cfg ServiceConfig) Option {
+	return func(c *clientConfig) {
+		if c.services == nil {
+			c.services = make(map[string]ServiceConfig)
+		}
+		c.services[name] = cfg
+	}
+}
+
+// WithServiceAPIKey sets the API key sent to one service, replacing the
+// key set with WithAPIKey, for credentials that differ between services.
+// An empty key sends none to the service.
+func WithServiceAPIKey(service, key string) Option {
+	return func(c *clientConfig) {
+		c.updateService(service, func(cfg *ServiceConfig) {
+			cfg.APIKey, cfg.NoAPIKey = key, key == ""
+		})
+	}
+}
+
+// WithServiceHeader sets a header on every request to one service.
+func WithServiceHeader(service, key, value string) Option {
+	return func(c *clientConfig) {
+		c.updateService(service, func(cfg *ServiceConfig) {
+			cfg.Header = cfg.Header.Clone()
+			if cfg.Header == nil {
+				cfg.Header = make(http.Header)
+			}
+			cfg.Header.Set(key, value)
+		})
+	}
+}
+
+// updateService changes part of a service's configuration, keeping the
+// rest of it.
+func (c *clientConfig) updateService(name string, update func(*ServiceConfig)) {
+	if c.services == nil {
+		c.services = make(map[string]ServiceConfig)
+	}
+	cfg := c.services[name]
+	update(&cfg)
+	c.services[name] = cfg
+}
+
+// serviceAPIKey returns the API key sent to the named service, asking the
+// WithTokenProvider function for it unless the service has its own.
+func (c *clientConfig) serviceAPIKey(ctx context.Context, name string) (string, error) {
+	if svc := c.services[name]; svc.APIKey != "" || svc.NoAPIKey {
+		return svc.APIKey, nil
+	}
+	if c.tokenProvider != nil {
+		key, err := c.tokenProvider(ctx)
+		if err != nil {
+			return "", fmt.Errorf("getting API key: %w", err)
+		}
+		return key, nil
+	}
+	return c.apiKey, nil
+}
+
+// authenticated reports whether requests to the named service carry an
+// API key, without calling a token provider.
+func (c *clientConfig) authenticated(name string) bool {
+	if svc := c.services[name]; svc.APIKey != "" || svc.NoAPIKey {
+		return svc.APIKey != ""
+	}
+	return c.tokenProvider != nil || c.apiKey != ""
+}
+
+// Service is one ecosyste.ms API, reached through the client's transport
+// with its headers and authentication.
+type Service struct {
+	name     string
+	raw      *rawClient
+	disabled bool
+}
+
+// Name returns the service's name, such as "advisories".
+func (s *Service) Name() string {
+	return s.name
+}
+
+// URL returns the service's base URL.
+func (s *Service) URL() string {
+	return s.raw.server.String()
+}
+
+// Disabled reports whether the service was disabled with WithService.
+func (s *Service) Disabled() bool {
+	return s.disabled
+}
+
+// GetJSON requests path, relative to the service's base URL, with the
+// given query parameters, and decodes the JSON response into v. Each
+// element of path is escaped separately. Responses with a status other than
+// 2xx are returned as *APIError.
+func (s *Service) GetJSON(ctx context.Context, path string, query url.Values, v any, opts ...CallOption) error {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "GetJSON", "")
+	op := s.name + " " + path
+	resp, err := s.raw.getQuery(ctx, call, query, strings.Split(strings.Trim(path, "/"), "/")...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return readAPIError(op, resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s response: %w", op, err)
+	}
+	return nil
+}
+
+// serviceRegistry holds the client's services, each created on first use.
+type serviceRegistry struct {
+	mu       sync.Mutex
+	domain   string
+	urls     map[string]*url.URL
+	configs  map[string]ServiceConfig
+	services map[string]*Service
+	doer     *http.Client
+	edit     func(ctx context.Context, req *http.Request, name string) error
+}
+
+// newServiceRegistry checks the base URL of every known or configured
+// service, so that creating them later cannot fail.
+func newServiceRegistry(configs map[string]ServiceConfig, domain string, doer *http.Client, edit func(ctx context.Context, req *http.Request, name string) error) (*serviceRegistry, error) {
+	r := &serviceRegistry{
+		domain:   domain,
+		urls:     make(map[string]*url.URL),
+		configs:  configs,
+		services: make(map[string]*Service),
+		doer:     doer,
+		edit:     edit,
+	}
+	names := slices.Collect(maps.Keys(defaultServiceURLs))
+	for name := range configs {
+		if _, ok := defaultServiceURLs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		server := configs[name].BaseURL
+		if server == "" {
+			server = serviceURL(name, domain)
+		}
+		if server == "" {
+			return nil, fmt.Errorf("service %q needs a base URL or WithBaseDomain", name)
+		}
+		u, err := parseServerURL(server)
+		if err != nil {
+			return nil, fmt.Errorf("creating %s client: %w", name, err)
+		}
+		r.urls[name] = u
+	}
+	return r, nil
+}
+
+// lookup returns the named service, creating it on first use. It returns
+// nil for services without a known, configured or derived URL.
+func (r *serviceRegistry) lookup(name string) *Service {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.services[name]; ok {
+		return s
+	}
+	u, ok := r.urls[name]
+	if !ok && r.domain != "" {
+		u, _ = parseServerURL(serviceURL(name, r.domain))
+	}
+	if u == nil {
+		return nil
+	}
+	s := &Service{name: name, disabled: r.configs[name].Disabled}
+	edit := func(ctx context.Context, req *http.Request) error {
+		return r.edit(ctx, req, name)
+	}
+	if s.disabled {
+		edit = func(context.Context, *http.Request) error {
+			return fmt.Errorf("%s: %w", name, ErrServiceDisabled)
+		}
+	}
+	s.raw = &rawClient{server: u, doer: r.doer, edit: edit}
+	r.services[name] = s
+	return s
+}
+
+// Service returns the named ecosyste.ms service, for endpoints the
+// high-level methods do not cover. Services other than the known ones need
+// a URL from WithService or WithBaseDomain; it returns an error for others.
+func (c *Client) Service(name string) (*Service, error) {
+	if s := c.services.lookup(name); s != nil {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown service %q: configure its URL with WithService or WithBaseDomain", name)
+}
+
+// Advisories returns the advisories.ecosyste.ms service.
+func (c *Client) Advisories() *Service {
+	return c.services.lookup(ServiceAdvisories)
+}
+
+// Summary returns the summary.ecosyste.ms service.
+func (c *Client) Summary() *Service {
+	return c.services.lookup(ServiceSummary)
+}
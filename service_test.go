@@ -0,0 +1,266 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestServiceGetJSON(t *testing.T) {
+	var gotPath, gotQuery, gotAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery, gotAgent = r.URL.EscapedPath(), r.URL.RawQuery, r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"uuid": "GSA_1", "title": "Command Injection"}]`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithService(ServiceAdvisories, ServiceConfig{BaseURL: srv.URL + "/api/v1"}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	advisories := client.Advisories()
+	if advisories != client.Advisories() {
+		t.Error("Advisories() should return the same service each time")
+	}
+	if advisories.Name() != ServiceAdvisories || advisories.URL() != srv.URL+"/api/v1/" {
+		t.Errorf("Advisories() = %q at %q", advisories.Name(), advisories.URL())
+	}
+
+	var got []struct{ UUID, Title string }
+	err = advisories.GetJSON(context.Background(), "advisories/lookup", url.Values{"purl": {"pkg:npm/lodash"}}, &got)
+	if err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Command Injection" {
+		t.Errorf("GetJSON() decoded %+v", got)
+	}
+	if gotPath != "/api/v1/advisories/lookup" || gotQuery != "purl=pkg%3Anpm%2Flodash" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	if gotAgent == "" {
+		t.Error("GetJSON() request has no User-Agent")
+	}
+}
+
+func TestServiceGetJSONError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0", WithService(ServiceSummary, ServiceConfig{BaseURL: srv.URL}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	var v any
+	err = client.Summary().GetJSON(context.Background(), "projects/lookup", nil, &v)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetJSON() error = %v, want 404 *APIError", err)
+	}
+}
+
+func TestServiceByName(t *testing.T) {
+	client, err := NewClient("test-agent/1.0", WithService("timeline", ServiceConfig{BaseURL: "https://timeline.example/api/v1"}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		wantURL string
+	}{
+		{"timeline", "https://timeline.example/api/v1/"},
+		{ServicePackages, DefaultPackagesServer + "/"},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := client.Service(tt.name)
+			if tt.wantURL == "" {
+				if err == nil {
+					t.Errorf("Service(%q) should error", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Service(%q) error = %v", tt.name, err)
+			}
+			if s.URL() != tt.wantURL {
+				t.Errorf("Service(%q).URL() = %q, want %q", tt.name, s.URL(), tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestWithServiceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"missing URL", WithService("timeline", ServiceConfig{})},
+		{"bad URL", WithService(ServiceAdvisories, ServiceConfig{BaseURL: "http://[::1"})},
+		{"bad packages URL", WithPackagesServer("http://[::1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient("test-agent/1.0", tt.opt); err == nil {
+				t.Error("NewClient() should error")
+			}
+		})
+	}
+}
+
+func TestServiceDisabled(t *testing.T) {
+	_, srv := newTestClient(t)
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithReposServer(srv.ReposURL()),
+		WithService(ServiceRepos, ServiceConfig{BaseURL: srv.ReposURL(), Disabled: true}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.GetRepository(ctx, "https://github.com/rails/rails"); !errors.Is(err, ErrServiceDisabled) {
+		t.Errorf("GetRepository() error = %v, want ErrServiceDisabled", err)
+	}
+	if _, err := client.LookupByRegistryAndName(ctx, "rubygems.org", "rails"); err != nil {
+		t.Errorf("LookupByRegistryAndName() error = %v", err)
+	}
+
+	statuses, err := client.Ping(ctx)
+	if err != nil || len(statuses) != 1 || statuses[0].Service != ServicePackages {
+		t.Errorf("Ping() = %+v, %v, want only packages", statuses, err)
+	}
+	for _, r := range srv.Requests() {
+		if strings.Contains(r, "/repos/") {
+			t.Errorf("disabled service was requested: %s", r)
+		}
+	}
+}
+
+func TestWithBaseDomain(t *testing.T) {
+	client, err := NewClient("test-agent/1.0",
+		WithBaseDomain("staging.ecosyste.ms"),
+		WithReposServer("http://localhost:3001/api/v1"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		service string
+		want    string
+	}{
+		{ServicePackages, "https://packages.staging.ecosyste.ms/api/v1/"},
+		{ServiceRepos, "http://localhost:3001/api/v1/"},
+		{ServiceAdvisories, "https://advisories.staging.ecosyste.ms/api/v1/"},
+		{"timeline", "https://timeline.staging.ecosyste.ms/api/v1/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			s, err := client.Service(tt.service)
+			if err != nil {
+				t.Fatalf("Service(%q) error = %v", tt.service, err)
+			}
+			if s.URL() != tt.want {
+				t.Errorf("Service(%q).URL() = %q, want %q", tt.service, s.URL(), tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+	}{
+		{ServiceSummary, "", "https://summary.ecosyste.ms/api/v1"},
+		{ServiceSummary, "ecosyste.ms", "https://summary.ecosyste.ms/api/v1"},
+		{ServiceRepos, "http://ecosystems.internal:8080/", "http://repos.ecosystems.internal:8080/api/v1"},
+		{"timeline", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := serviceURL(tt.name, tt.domain); got != tt.want {
+			t.Errorf("serviceURL(%q, %q) = %q, want %q", tt.name, tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestWithServiceAPIKey(t *testing.T) {
+	var mu sync.Mutex
+	auth := make(map[string]string)
+	var tenant string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		service, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		auth[service] = r.Header.Get("Authorization")
+		if service == ServiceAdvisories {
+			tenant = r.Header.Get("X-Tenant")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-agent/1.0",
+		WithAPIKey("shared"),
+		WithPackagesServer(srv.URL+"/packages"),
+		WithReposServer(srv.URL+"/repos"),
+		WithService(ServiceAdvisories, ServiceConfig{BaseURL: srv.URL + "/advisories"}),
+		WithServiceAPIKey(ServiceRepos, "repos-key"),
+		WithServiceAPIKey(ServiceAdvisories, ""),
+		WithServiceHeader(ServiceAdvisories, "X-Tenant", "acme"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	if _, err := client.ListRegistries(ctx); err != nil {
+		t.Fatalf("ListRegistries() error = %v", err)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	var v any
+	if err := client.Advisories().GetJSON(ctx, "advisories", nil, &v); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+
+	want := map[string]string{
+		ServicePackages:   "Bearer shared",
+		ServiceRepos:      "Bearer repos-key",
+		ServiceAdvisories: "",
+	}
+	for service, w := range want {
+		if auth[service] != w {
+			t.Errorf("%s Authorization = %q, want %q", service, auth[service], w)
+		}
+	}
+	if tenant != "acme" {
+		t.Errorf("advisories X-Tenant = %q, want %q", tenant, "acme")
+	}
+}
+
+func TestWithServiceAPIKeyBulkBatchSize(t *testing.T) {
+	if _, err := NewClient("test-agent/1.0", WithBulkBatchSize(500), WithServiceAPIKey(ServicePackages, "key")); err != nil {
+		t.Errorf("NewClient() with a packages key error = %v", err)
+	}
+	if _, err := NewClient("test-agent/1.0", WithBulkBatchSize(500), WithServiceAPIKey(ServiceRepos, "key")); err == nil {
+		t.Error("NewClient() with only a repos key should reject large batches")
+	}
+}
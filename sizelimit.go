@@ -0,0 +1,78 @@
+package ecosystems
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseTooLargeError is returned when a response body is larger than
+// the limit set by WithMaxResponseBytes.
+type ResponseTooLargeError struct {
+	Method string
+	URL    string
+	Limit  int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s %s: response body exceeds %d bytes", e.Method, e.URL, e.Limit)
+}
+
+// WithMaxResponseBytes limits the size of each decompressed response body.
+// Reading past the limit fails with a *ResponseTooLargeError, so a
+// pathological or misbehaving proxy's response cannot exhaust memory. Zero,
+// the default, means no limit.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *clientConfig) {
+		c.maxResponseBytes = n
+	}
+}
+
+// sizeLimitTransport limits the size of response bodies.
+type sizeLimitTransport struct {
+	next  http.RoundTripper
+	limit int64
+}
+
+func (t *sizeLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	tooLarge := &ResponseTooLargeError{Method: req.Method, URL: req.URL.String(), Limit: t.limit}
+	if resp.ContentLength > t.limit {
+		resp.Body.Close()
+		return nil, tooLarge
+	}
+	resp.Body = &limitedBody{body: resp.Body, remaining: t.limit, err: tooLarge}
+	return resp, nil
+}
+
+// limitedBody reads at most remaining bytes from body, failing with err if
+// the body has more.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+	// Read one byte past the limit to tell a body of exactly the limit
+	// from a longer one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), b.err
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,60 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	body := `{"name":"lodash","ecosystem":"npm","description":"` + strings.Repeat("x", 200) + `"}`
+
+	tests := []struct {
+		name    string
+		limit   int64
+		chunked bool
+		wantErr bool
+	}{
+		{"under limit", 1024, false, false},
+		{"exact limit", int64(len(body)), true, false},
+		{"content length over limit", 100, false, true},
+		{"chunked over limit", 100, true, true},
+		{"no limit", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tt.chunked {
+					w.(http.Flusher).Flush()
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer srv.Close()
+
+			client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithMaxResponseBytes(tt.limit))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			pkg, err := client.LookupByRegistryAndName(context.Background(), "npmjs.org", "lodash")
+
+			var tooLarge *ResponseTooLargeError
+			if got := errors.As(err, &tooLarge); got != tt.wantErr {
+				t.Fatalf("LookupByRegistryAndName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if tooLarge.Limit != tt.limit {
+					t.Errorf("Limit = %d, want %d", tooLarge.Limit, tt.limit)
+				}
+				return
+			}
+			if pkg == nil || pkg.Name != "lodash" {
+				t.Errorf("LookupByRegistryAndName() = %v, want lodash", pkg)
+			}
+		})
+	}
+}
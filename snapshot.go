@@ -0,0 +1,96 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// Snapshot is a saved set of bulk lookup results, keyed by PURL.
+type Snapshot struct {
+	TakenAt  time.Time                 `json:"taken_at"`
+	Packages map[string]*SnapshotEntry `json:"packages"`
+}
+
+// SnapshotEntry is a package record and the time it was fetched from the API.
+type SnapshotEntry struct {
+	FetchedAt time.Time                     `json:"fetched_at"`
+	Package   *packages.PackageWithRegistry `json:"package"`
+}
+
+// NewSnapshot creates a snapshot of bulk lookup results fetched now.
+func NewSnapshot(results map[string]*packages.PackageWithRegistry) *Snapshot {
+	now := time.Now()
+	s := &Snapshot{TakenAt: now, Packages: make(map[string]*SnapshotEntry, len(results))}
+	for purl, pkg := range results {
+		s.Packages[purl] = &SnapshotEntry{FetchedAt: now, Package: pkg}
+	}
+	return s
+}
+
+// Results returns the snapshot's packages in the form BulkLookup returns.
+func (s *Snapshot) Results() map[string]*packages.PackageWithRegistry {
+	results := make(map[string]*packages.PackageWithRegistry, len(s.Packages))
+	for purl, entry := range s.Packages {
+		results[purl] = entry.Package
+	}
+	return results
+}
+
+// Save writes the snapshot as JSON.
+func (s *Snapshot) Save(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("saving snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot written by Save.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("loading snapshot: %w", err)
+	}
+	if s.Packages == nil {
+		s.Packages = make(map[string]*SnapshotEntry)
+	}
+	return &s, nil
+}
+
+// LookupDelta looks up purls, re-querying only those missing from previous
+// or fetched longer than maxAge ago, and merges the fresh data with the
+// still-fresh snapshot records. The returned snapshot covers the requested
+// PURLs that exist; previous is not modified and may be nil.
+func (c *Client) LookupDelta(ctx context.Context, purls []string, previous *Snapshot, maxAge time.Duration, opts ...CallOption) (*Snapshot, error) {
+	now := time.Now()
+	next := &Snapshot{TakenAt: now, Packages: make(map[string]*SnapshotEntry, len(purls))}
+
+	var stale []string
+	for _, purl := range purls {
+		if previous != nil {
+			if entry, ok := previous.Packages[purl]; ok && entry.Package != nil && now.Sub(entry.FetchedAt) <= maxAge {
+				next.Packages[purl] = entry
+				continue
+			}
+		}
+		stale = append(stale, purl)
+	}
+
+	if len(stale) == 0 {
+		return next, nil
+	}
+
+	results, err := c.BulkLookup(ctx, stale, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for purl, pkg := range results {
+		next.Packages[purl] = &SnapshotEntry{FetchedAt: now, Package: pkg}
+	}
+
+	return next, nil
+}
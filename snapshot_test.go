@@ -0,0 +1,74 @@
+package ecosystems
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestLookupDelta(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	previous := &Snapshot{
+		Packages: map[string]*SnapshotEntry{
+			"pkg:gem/rails": {
+				FetchedAt: time.Now().Add(-time.Hour),
+				Package:   &packages.PackageWithRegistry{Purl: "pkg:gem/rails", Name: "cached-rails"},
+			},
+			"pkg:npm/lodash": {
+				FetchedAt: time.Now().Add(-48 * time.Hour),
+				Package:   &packages.PackageWithRegistry{Purl: "pkg:npm/lodash", Name: "stale-lodash"},
+			},
+		},
+	}
+
+	next, err := client.LookupDelta(context.Background(), []string{"pkg:gem/rails", "pkg:npm/lodash"}, previous, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("LookupDelta() error = %v", err)
+	}
+
+	if got := next.Packages["pkg:gem/rails"].Package.Name; got != "cached-rails" {
+		t.Errorf("rails Name = %q, want cached record", got)
+	}
+	if got := next.Packages["pkg:npm/lodash"].Package.Name; got != "lodash" {
+		t.Errorf("lodash Name = %q, want refreshed record", got)
+	}
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestLookupDeltaAllFresh(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	previous := NewSnapshot(map[string]*packages.PackageWithRegistry{
+		"pkg:gem/rails": {Purl: "pkg:gem/rails"},
+	})
+	if _, err := client.LookupDelta(context.Background(), []string{"pkg:gem/rails"}, previous, time.Hour); err != nil {
+		t.Fatalf("LookupDelta() error = %v", err)
+	}
+	if got := len(srv.Requests()); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+func TestSnapshotSaveLoad(t *testing.T) {
+	s := NewSnapshot(map[string]*packages.PackageWithRegistry{
+		"pkg:gem/rails": {Purl: "pkg:gem/rails", Name: "rails"},
+	})
+
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if got := loaded.Results()["pkg:gem/rails"].Name; got != "rails" {
+		t.Errorf("Name = %q, want %q", got, "rails")
+	}
+}
@@ -0,0 +1,206 @@
+package ecosystems
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// SPDXOptions describes the document written by WriteSPDX.
+type SPDXOptions struct {
+	// Name is the document name, by default "dependencies".
+	Name string
+	// Namespace is the unique URI of the document. By default a random one
+	// under https://spdx.org/spdxdocs/ is generated.
+	Namespace string
+	// Created is the creation time, by default the current time.
+	Created time.Time
+	// Creators default to "Tool: ecosystems-go".
+	Creators []string
+	// Packages holds ecosyste.ms data, such as BulkLookup results, keyed by
+	// PURL with or without a version. It supplies declared licenses,
+	// homepages and descriptions; packages without data are written with
+	// NOASSERTION.
+	Packages map[string]*packages.PackageWithRegistry
+	// PURLParser parses graph nodes and formats the versionless PURLs
+	// looked up in Packages. Set it to the parser of the client that
+	// produced Packages; by default PackageURLParser is used.
+	PURLParser PURLParser
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Homepage         string            `json:"homepage,omitempty"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Description      string            `json:"description,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxDependencyOf maps dependency kinds to the SPDX relationship from the
+// dependency to its dependent.
+var spdxDependencyOf = map[string]string{
+	DependencyDevelopment: "DEV_DEPENDENCY_OF",
+	DependencyTest:        "TEST_DEPENDENCY_OF",
+	DependencyBuild:       "BUILD_DEPENDENCY_OF",
+	DependencyOptional:    "OPTIONAL_DEPENDENCY_OF",
+}
+
+// WriteSPDX writes the graph as an SPDX 2.3 JSON document. Each node
+// becomes a package with a purl external reference, and each edge a
+// DEPENDS_ON relationship, or DEV_DEPENDENCY_OF, TEST_DEPENDENCY_OF,
+// BUILD_DEPENDENCY_OF or OPTIONAL_DEPENDENCY_OF by NormalizeDependencyKind
+// of its kind. The document DESCRIBES the packages nothing depends on.
+// Several declared licenses are combined with AND, since ecosyste.ms does
+// not record whether a choice is offered.
+func (g *DependencyGraph) WriteSPDX(w io.Writer, opts SPDXOptions) error {
+	if opts.Name == "" {
+		opts.Name = "dependencies"
+	}
+	if opts.Namespace == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return fmt.Errorf("generating SPDX namespace: %w", err)
+		}
+		opts.Namespace = fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s", opts.Name, hex.EncodeToString(id))
+	}
+	if opts.PURLParser == nil {
+		opts.PURLParser = PackageURLParser{}
+	}
+	if opts.Created.IsZero() {
+		opts.Created = time.Now()
+	}
+	if len(opts.Creators) == 0 {
+		opts.Creators = []string{"Tool: ecosystems-go"}
+	}
+
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              opts.Name,
+		DocumentNamespace: opts.Namespace,
+		CreationInfo: spdxCreationInfo{
+			Created:  opts.Created.UTC().Format(time.RFC3339),
+			Creators: opts.Creators,
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+
+	ids := make(map[string]string)
+	for i, node := range g.Nodes() {
+		ids[node] = fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		doc.Packages = append(doc.Packages, spdxPackageFor(ids[node], node, opts.Packages, opts.PURLParser))
+	}
+
+	hasDependents := make(map[string]bool)
+	var deps []spdxRelationship
+	for _, e := range g.Edges() {
+		rel := spdxRelationship{SPDXElementID: ids[e.From], RelationshipType: "DEPENDS_ON", RelatedSPDXElement: ids[e.To]}
+		if t, ok := spdxDependencyOf[NormalizeDependencyKind(e.Kind)]; ok {
+			rel = spdxRelationship{SPDXElementID: ids[e.To], RelationshipType: t, RelatedSPDXElement: ids[e.From]}
+		}
+		if e.From != e.To {
+			hasDependents[e.To] = true
+		}
+		deps = append(deps, rel)
+	}
+	for _, node := range g.Nodes() {
+		if !hasDependents[node] {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{
+				SPDXElementID: doc.SPDXID, RelationshipType: "DESCRIBES", RelatedSPDXElement: ids[node],
+			})
+		}
+	}
+	doc.Relationships = append(doc.Relationships, deps...)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("writing SPDX: %w", err)
+	}
+	return nil
+}
+
+// spdxPackageFor describes one graph node, using data from pkgs when it
+// has the node's PURL with or without its version. The versionless PURL is
+// formatted by parser, as the keys of BulkLookup results are.
+func spdxPackageFor(id, node string, pkgs map[string]*packages.PackageWithRegistry, parser PURLParser) spdxPackage {
+	pkg := spdxPackage{
+		SPDXID:           id,
+		Name:             node,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+		ExternalRefs: []spdxExternalRef{{
+			ReferenceCategory: "PACKAGE-MANAGER",
+			ReferenceType:     "purl",
+			ReferenceLocator:  node,
+		}},
+	}
+
+	data := pkgs[node]
+	if purl, err := parser.Parse(node); err == nil {
+		pkg.Name = PURLToName(purl)
+		pkg.VersionInfo = purl.Version
+		if data == nil {
+			purl.Version = ""
+			data = pkgs[parser.Format(purl)]
+		}
+	}
+	if data == nil {
+		return pkg
+	}
+
+	if len(data.NormalizedLicenses) == 1 {
+		pkg.LicenseDeclared = data.NormalizedLicenses[0]
+	} else if len(data.NormalizedLicenses) > 1 {
+		pkg.LicenseDeclared = "(" + strings.Join(data.NormalizedLicenses, " AND ") + ")"
+	}
+	pkg.Homepage = deref(data.Homepage)
+	pkg.Description = deref(data.Description)
+	return pkg
+}
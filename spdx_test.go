@@ -0,0 +1,112 @@
+package ecosystems
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestDependencyGraphWriteSPDX(t *testing.T) {
+	g := testGraph(t)
+	homepage := "https://lodash.com/"
+	var buf bytes.Buffer
+	err := g.WriteSPDX(&buf, SPDXOptions{
+		Name:      "app",
+		Namespace: "https://example.com/spdx/app-1.0.0",
+		Created:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Packages: map[string]*packages.PackageWithRegistry{
+			"pkg:npm/lodash":    {NormalizedLicenses: []string{"MIT"}, Homepage: &homepage},
+			"pkg:npm/app@1.0.0": {NormalizedLicenses: []string{"MIT", "Apache-2.0"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteSPDX() error = %v", err)
+	}
+
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteSPDX() wrote invalid JSON: %v", err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" || doc.DataLicense != "CC0-1.0" || doc.SPDXID != "SPDXRef-DOCUMENT" {
+		t.Errorf("document header = %q %q %q", doc.SPDXVersion, doc.DataLicense, doc.SPDXID)
+	}
+	if doc.CreationInfo.Created != "2024-03-01T12:00:00Z" || !reflect.DeepEqual(doc.CreationInfo.Creators, []string{"Tool: ecosystems-go"}) {
+		t.Errorf("CreationInfo = %+v", doc.CreationInfo)
+	}
+
+	if len(doc.Packages) != 3 {
+		t.Fatalf("WriteSPDX() wrote %d packages, want 3", len(doc.Packages))
+	}
+	babel, app, lodash := doc.Packages[0], doc.Packages[1], doc.Packages[2]
+	if babel.Name != "@babel/core" || babel.LicenseDeclared != spdxNoAssertion || babel.ExternalRefs[0].ReferenceLocator != "pkg:npm/%40babel/core" {
+		t.Errorf("babel package = %+v", babel)
+	}
+	if app.Name != "app" || app.VersionInfo != "1.0.0" || app.LicenseDeclared != "(MIT AND Apache-2.0)" {
+		t.Errorf("app package = %+v", app)
+	}
+	if lodash.LicenseDeclared != "MIT" || lodash.Homepage != homepage || lodash.DownloadLocation != spdxNoAssertion {
+		t.Errorf("lodash package = %+v", lodash)
+	}
+
+	want := []spdxRelationship{
+		{"SPDXRef-DOCUMENT", "DESCRIBES", app.SPDXID},
+		{babel.SPDXID, "DEV_DEPENDENCY_OF", app.SPDXID},
+		{app.SPDXID, "DEPENDS_ON", lodash.SPDXID},
+	}
+	if !reflect.DeepEqual(doc.Relationships, want) {
+		t.Errorf("Relationships = %+v, want %+v", doc.Relationships, want)
+	}
+}
+
+func TestDependencyGraphWriteSPDXDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewDependencyGraph().WriteSPDX(&buf, SPDXOptions{}); err != nil {
+		t.Fatalf("WriteSPDX() error = %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteSPDX() wrote invalid JSON: %v", err)
+	}
+	if doc.Name != "dependencies" || !strings.HasPrefix(doc.DocumentNamespace, "https://spdx.org/spdxdocs/dependencies-") {
+		t.Errorf("document = %q %q", doc.Name, doc.DocumentNamespace)
+	}
+	if doc.Packages == nil || doc.Relationships == nil {
+		t.Error("WriteSPDX() should write empty packages and relationships arrays")
+	}
+}
+
+func TestDependencyGraphWriteSPDXPURLParser(t *testing.T) {
+	g := testGraph(t)
+	var buf bytes.Buffer
+	err := g.WriteSPDX(&buf, SPDXOptions{
+		Packages: map[string]*packages.PackageWithRegistry{
+			"pkg:npm/LODASH": {NormalizedLicenses: []string{"MIT"}},
+			"pkg:npm/APP":    {NormalizedLicenses: []string{"ISC"}},
+		},
+		PURLParser: upperNameParser{},
+	})
+	if err != nil {
+		t.Fatalf("WriteSPDX() error = %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteSPDX() wrote invalid JSON: %v", err)
+	}
+	got := make(map[string]string)
+	for _, pkg := range doc.Packages {
+		got[pkg.ExternalRefs[0].ReferenceLocator] = pkg.LicenseDeclared
+	}
+	want := map[string]string{
+		"pkg:npm/%40babel/core": spdxNoAssertion,
+		"pkg:npm/app@1.0.0":     "ISC",
+		"pkg:npm/lodash":        "MIT",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("declared licenses = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package ecosystems
+
+import "context"
+
+// Ranking keys used in PackageStats.Rankings.
+const (
+	RankingDownloads         = "downloads"
+	RankingDependentPackages = "dependent_packages_count"
+	RankingDependentRepos    = "dependent_repos_count"
+	RankingDockerDownloads   = "docker_downloads_count"
+	RankingDockerDependents  = "docker_dependents_count"
+	RankingAverage           = "average"
+)
+
+// PackageStats holds a package's usage counts and registry rankings.
+type PackageStats struct {
+	PURL     string
+	Registry string
+	// Downloads is the registry's download count over DownloadsPeriod, such
+	// as "last-month" or "total". Registries that do not publish download
+	// counts report 0 and an empty period.
+	Downloads       int
+	DownloadsPeriod string
+	// DependentPackages and DependentRepos count the packages and
+	// repositories that depend on this package.
+	DependentPackages int
+	DependentRepos    int
+	DockerDownloads   int
+	DockerDependents  int
+	// Rankings maps the Ranking constants to the package's position in its
+	// registry as the top N percent, so lower is better. Keys without a
+	// ranking are absent.
+	Rankings map[string]float64
+}
+
+// Percentile converts a ranking into the percentage of packages in the
+// registry this package ranks above, or nil if it has no such ranking.
+func (s *PackageStats) Percentile(key string) *float64 {
+	rank, ok := s.Rankings[key]
+	if !ok {
+		return nil
+	}
+	p := max(100-rank, 0)
+	return &p
+}
+
+// GetPackageStats returns the download counts, dependent counts and rankings
+// of a package, or nil if the API does not recognize the PURL. The API
+// reports only the latest download count, not its history, so chart trends
+// by storing the stats from repeated calls.
+func (c *Client) GetPackageStats(ctx context.Context, purl string, opts ...CallOption) (*PackageStats, error) {
+	pkg, err := c.Lookup(ctx, purl, opts...)
+	if err != nil || pkg == nil {
+		return nil, err
+	}
+
+	stats := &PackageStats{
+		PURL:              purl,
+		Registry:          pkg.Registry.Name,
+		Downloads:         pkg.Downloads,
+		DependentPackages: pkg.DependentPackagesCount,
+		DependentRepos:    pkg.DependentReposCount,
+		DockerDownloads:   pkg.DockerDownloadsCount,
+		DockerDependents:  pkg.DockerDependentsCount,
+		Rankings:          make(map[string]float64),
+	}
+	if pkg.DownloadsPeriod != nil {
+		stats.DownloadsPeriod = *pkg.DownloadsPeriod
+	}
+	for key, v := range pkg.Rankings {
+		if rank, ok := v.(float64); ok {
+			stats.Rankings[key] = rank
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,42 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetPackageStats(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	stats, err := client.GetPackageStats(context.Background(), "pkg:gem/rails")
+	if err != nil {
+		t.Fatalf("GetPackageStats() error = %v", err)
+	}
+	if stats == nil {
+		t.Fatal("GetPackageStats() = nil, want stats")
+	}
+	if stats.Registry != "rubygems.org" || stats.Downloads != 500000000 || stats.DependentPackages != 12000 || stats.DependentRepos != 400000 {
+		t.Errorf("GetPackageStats() = %+v", stats)
+	}
+	if got := stats.Rankings[RankingDownloads]; got != 0.1 {
+		t.Errorf("Rankings[downloads] = %v, want 0.1", got)
+	}
+	if p := stats.Percentile(RankingDependentRepos); p == nil || *p != 99.98 {
+		t.Errorf("Percentile(dependent_repos_count) = %v, want 99.98", p)
+	}
+	if p := stats.Percentile(RankingDockerDownloads); p != nil {
+		t.Errorf("Percentile(docker_downloads_count) = %v, want nil", *p)
+	}
+}
+
+func TestGetPackageStatsNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	stats, err := client.GetPackageStats(context.Background(), "pkg:npm/nope")
+	if err != nil {
+		t.Fatalf("GetPackageStats() error = %v", err)
+	}
+	if stats != nil {
+		t.Errorf("GetPackageStats() = %+v, want nil", stats)
+	}
+}
@@ -0,0 +1,71 @@
+package ecosystems
+
+import (
+	"context"
+	"path"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// SubpathOptions controls how LookupPURLSubpath treats a PURL's subpath.
+type SubpathOptions struct {
+	// ResolveRoot falls back to the package the PURL names without its
+	// subpath when the subpath is not a package of its own.
+	ResolveRoot bool
+}
+
+// SubpathPackage is the package found for a PURL that may carry a
+// subpath, such as pkg:golang/github.com/aws/aws-sdk-go-v2#service/s3.
+type SubpathPackage struct {
+	// PURL is the PURL that was looked up, subpath included.
+	PURL    packageurl.PackageURL
+	Subpath string
+	Package *packages.Package
+	// Root is set when Package is the root package rather than one
+	// published from the subpath, so results for different modules of a
+	// monorepo are not mistaken for each other.
+	Root bool
+}
+
+// LookupPURLSubpath looks up the package a PURL refers to, taking its
+// subpath into account where LookupPURL ignores it. Go modules nested in a
+// repository, such as github.com/aws/aws-sdk-go-v2/service/s3, are looked
+// up as packages of their own. Other subpaths name files or directories
+// inside the root package, which is returned only with opts.ResolveRoot.
+// It returns nil if no package is found.
+func (c *Client) LookupPURLSubpath(ctx context.Context, purl packageurl.PackageURL, opts SubpathOptions, callOpts ...CallOption) (*SubpathPackage, error) {
+	result := &SubpathPackage{PURL: purl, Subpath: purl.Subpath}
+	root := purl
+	root.Subpath = ""
+
+	if purl.Subpath == "" || purl.Type == packageurl.TypeGolang {
+		nested := root
+		if purl.Subpath != "" {
+			dir, name := path.Split(purl.Subpath)
+			nested.Namespace = path.Join(root.Namespace, root.Name, dir)
+			nested.Name = name
+		}
+		pkg, err := c.LookupPURL(ctx, nested, callOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil {
+			result.Package = pkg
+			return result, nil
+		}
+		if purl.Subpath == "" {
+			return nil, nil
+		}
+	}
+
+	if !opts.ResolveRoot {
+		return nil, nil
+	}
+	pkg, err := c.LookupPURL(ctx, root, callOpts...)
+	if err != nil || pkg == nil {
+		return nil, err
+	}
+	result.Package, result.Root = pkg, true
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLookupPURLSubpath(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddPackage("proxy.golang.org", registryPackage("proxy.golang.org", "github.com/aws/aws-sdk-go-v2"))
+	srv.AddPackage("proxy.golang.org", registryPackage("proxy.golang.org", "github.com/aws/aws-sdk-go-v2/service/s3"))
+
+	tests := []struct {
+		purl        string
+		opts        SubpathOptions
+		wantPackage string
+		wantRoot    bool
+	}{
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2#service/s3", SubpathOptions{}, "github.com/aws/aws-sdk-go-v2/service/s3", false},
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2#service/s3", SubpathOptions{ResolveRoot: true}, "github.com/aws/aws-sdk-go-v2/service/s3", false},
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2#internal/ini", SubpathOptions{}, "", false},
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2#internal/ini", SubpathOptions{ResolveRoot: true}, "github.com/aws/aws-sdk-go-v2", true},
+		{"pkg:golang/github.com/aws/aws-sdk-go-v2", SubpathOptions{}, "github.com/aws/aws-sdk-go-v2", false},
+		{"pkg:gem/rails#lib/rails.rb", SubpathOptions{}, "", false},
+		{"pkg:gem/rails#lib/rails.rb", SubpathOptions{ResolveRoot: true}, "rails", true},
+		{"pkg:gem/does-not-exist#lib", SubpathOptions{ResolveRoot: true}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			purl, err := ParsePURL(tt.purl)
+			if err != nil {
+				t.Fatalf("ParsePURL() error = %v", err)
+			}
+			got, err := client.LookupPURLSubpath(context.Background(), purl, tt.opts)
+			if err != nil {
+				t.Fatalf("LookupPURLSubpath() error = %v", err)
+			}
+			if tt.wantPackage == "" {
+				if got != nil {
+					t.Errorf("LookupPURLSubpath() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Package.Name != tt.wantPackage || got.Root != tt.wantRoot {
+				t.Fatalf("LookupPURLSubpath() = %+v, want %s with Root %v", got, tt.wantPackage, tt.wantRoot)
+			}
+			if got.Subpath != purl.Subpath || got.PURL.String() != purl.String() {
+				t.Errorf("LookupPURLSubpath() PURL = %v, Subpath = %q, want %v", got.PURL, got.Subpath, purl)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SyncRepository asks repos.ecosyste.ms to refresh a repository from its
+// host. It reports whether a sync was scheduled, which is false when the
+// repository is not known to the API.
+func (c *Client) SyncRepository(ctx context.Context, url string, opts ...CallOption) (bool, error) {
+	repo, err := c.GetRepository(ctx, url, opts...)
+	if err != nil {
+		return false, err
+	}
+	if repo == nil || repo.Host == nil || repo.Host.Name == nil || repo.FullName == nil {
+		return false, nil
+	}
+
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "SyncRepository", "")
+	resp, err := c.services.lookup(ServiceRepos).raw.get(ctx, call, "hosts", *repo.Host.Name, "repositories", *repo.FullName, "ping")
+	if err != nil {
+		return false, fmt.Errorf("sync repository: %w", err)
+	}
+	return pingScheduled(resp, "sync repository")
+}
+
+// SyncPackage asks packages.ecosyste.ms to refresh a package from its
+// registry, for example right after publishing a release. It reports
+// whether a sync was scheduled, which is false when the package is not
+// known to the API.
+func (c *Client) SyncPackage(ctx context.Context, registry, name string, opts ...CallOption) (bool, error) {
+	call := newCallConfig(opts)
+	ctx = withOperation(call.context(ctx), "SyncPackage", registry)
+	resp, err := c.services.lookup(ServicePackages).raw.get(ctx, call, "registries", registry, "packages", name, "ping")
+	if err != nil {
+		return false, fmt.Errorf("sync package: %w", err)
+	}
+	return pingScheduled(resp, "sync package")
+}
+
+// pingScheduled reads a ping endpoint's response and closes its body.
+func pingScheduled(resp *http.Response, op string) (bool, error) {
+	defer resp.Body.Close()
+	defer func() { _, _ = io.Copy(io.Discard, resp.Body) }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	}
+	return false, readAPIError(op, resp)
+}
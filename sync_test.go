@@ -0,0 +1,114 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSyncRepository(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	scheduled, err := client.SyncRepository(context.Background(), "https://github.com/rails/rails", CallHeader("X-Test", "1"))
+	if err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+	if !scheduled {
+		t.Error("SyncRepository() = false, want true")
+	}
+	reqs := srv.Requests()
+	if want := "GET /repos/api/v1/hosts/GitHub/repositories/rails/rails/ping"; len(reqs) != 2 || reqs[1] != want {
+		t.Errorf("Requests() = %v, want lookup then %q", reqs, want)
+	}
+
+	scheduled, err = client.SyncRepository(context.Background(), "https://github.com/rails/missing")
+	if err != nil {
+		t.Fatalf("SyncRepository() missing error = %v", err)
+	}
+	if scheduled {
+		t.Error("SyncRepository() missing = true, want false")
+	}
+}
+
+func TestSyncRepositoryHeaders(t *testing.T) {
+	var gotUA, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repositories/lookup" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"full_name": "a/b", "host": {"name": "GitHub"}}`))
+			return
+		}
+		gotUA = r.Header.Get("User-Agent")
+		gotHeader = r.Header.Get("X-Test")
+		if r.URL.EscapedPath() != "/hosts/GitHub/repositories/a%2Fb/ping" {
+			t.Errorf("ping path = %q", r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-agent/1.0", WithReposServer(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	scheduled, err := client.SyncRepository(context.Background(), "https://github.com/a/b", CallHeader("X-Test", "1"))
+	if err != nil {
+		t.Fatalf("SyncRepository() error = %v", err)
+	}
+	if !scheduled {
+		t.Error("SyncRepository() = false, want true")
+	}
+	if gotUA != "test-agent/1.0 "+sdkProduct() {
+		t.Errorf("User-Agent = %q, want %q", gotUA, "test-agent/1.0 "+sdkProduct())
+	}
+	if gotHeader != "1" {
+		t.Errorf("X-Test = %q, want %q", gotHeader, "1")
+	}
+}
+
+func TestSyncPackage(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	tests := []struct {
+		registry string
+		name     string
+		want     bool
+	}{
+		{"npmjs.org", "@babel/core", true},
+		{"rubygems.org", "rails", true},
+		{"rubygems.org", "missing", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.SyncPackage(context.Background(), tt.registry, tt.name)
+			if err != nil {
+				t.Fatalf("SyncPackage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SyncPackage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if reqs := srv.Requests(); len(reqs) != 3 || reqs[0] != "GET /packages/api/v1/registries/npmjs.org/packages/@babel/core/ping" {
+		t.Errorf("Requests() = %v", reqs)
+	}
+}
+
+func TestSyncPackageServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.SyncPackage(context.Background(), "npmjs.org", "lodash"); err == nil {
+		t.Error("SyncPackage() error = nil, want status error")
+	}
+}
@@ -0,0 +1,153 @@
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/ecosyste-ms/ecosystems-go"
+
+// WithTracerProvider records a client span for every API request.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *clientConfig) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider records request latency, error and batch size metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *clientConfig) {
+		c.meterProvider = mp
+	}
+}
+
+// operation describes the high-level call a request belongs to, so
+// telemetry can be labelled by endpoint rather than by raw URL.
+type operation struct {
+	name     string
+	registry string
+}
+
+type operationKey struct{}
+
+func withOperation(ctx context.Context, name, registry string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{name: name, registry: registry})
+}
+
+func operationFrom(ctx context.Context) (operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(operation)
+	return op, ok
+}
+
+// telemetry holds the OpenTelemetry instruments used by a client.
+// A nil *telemetry records nothing.
+type telemetry struct {
+	tracer    trace.Tracer
+	duration  metric.Float64Histogram
+	errors    metric.Int64Counter
+	batchSize metric.Int64Histogram
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*telemetry, error) {
+	if tp == nil && mp == nil {
+		return nil, nil
+	}
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+	t := &telemetry{tracer: tp.Tracer(instrumentationName)}
+
+	var err error
+	t.duration, err = meter.Float64Histogram("ecosystems.client.request.duration",
+		metric.WithDescription("Duration of ecosyste.ms API requests"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	t.errors, err = meter.Int64Counter("ecosystems.client.request.errors",
+		metric.WithDescription("Failed ecosyste.ms API requests"))
+	if err != nil {
+		return nil, err
+	}
+	t.batchSize, err = meter.Int64Histogram("ecosystems.client.bulk_lookup.batch_size",
+		metric.WithDescription("Number of PURLs sent per bulk lookup request"))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *telemetry) recordBatchSize(ctx context.Context, n int) {
+	if t == nil {
+		return
+	}
+	t.batchSize.Record(ctx, int64(n))
+}
+
+// telemetryTransport wraps each HTTP request in a span and records metrics.
+type telemetryTransport struct {
+	next      http.RoundTripper
+	telemetry *telemetry
+}
+
+func (t *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	endpoint := req.URL.Path
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Host),
+	}
+	if op, ok := operationFrom(ctx); ok {
+		endpoint = op.name
+		if op.registry != "" {
+			attrs = append(attrs, attribute.String("ecosystems.registry", op.registry))
+		}
+	}
+	attrs = append(attrs, attribute.String("ecosystems.endpoint", endpoint))
+
+	ctx, span := t.telemetry.tracer.Start(ctx, "ecosystems."+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	elapsed := time.Since(start).Seconds()
+
+	status := "error"
+	failed := err != nil
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		status = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		// 404 is an expected "not found" answer, not a failure
+		if resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound {
+			failed = true
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+
+	metricAttrs := metric.WithAttributes(append(attrs, attribute.String("http.response.status_code", status))...)
+	t.telemetry.duration.Record(ctx, elapsed, metricAttrs)
+	if failed {
+		t.telemetry.errors.Add(ctx, 1, metricAttrs)
+	}
+
+	return resp, err
+}
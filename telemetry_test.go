@@ -0,0 +1,79 @@
+package ecosystems
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ecosyste-ms/ecosystems-go/ecosystemstest"
+)
+
+type recordedSpan struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	t.mu.Lock()
+	t.spans = append(t.spans, recordedSpan{name: name, attrs: cfg.Attributes()})
+	t.mu.Unlock()
+	return t.Tracer.Start(ctx, name, opts...)
+}
+
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	tracer *recordingTracer
+}
+
+func (p recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	srv := ecosystemstest.NewServer()
+	defer srv.Close()
+	if err := srv.LoadDefaultFixtures(); err != nil {
+		t.Fatalf("LoadDefaultFixtures() error = %v", err)
+	}
+
+	tracer := &recordingTracer{}
+	client, err := NewClient("test-agent/1.0",
+		WithPackagesServer(srv.PackagesURL()),
+		WithTracerProvider(recordingTracerProvider{tracer: tracer}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.LookupByRegistryAndName(context.Background(), "rubygems.org", "rails"); err != nil {
+		t.Fatalf("LookupByRegistryAndName() error = %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "ecosystems.LookupByRegistryAndName" {
+		t.Errorf("span name = %q, want %q", span.name, "ecosystems.LookupByRegistryAndName")
+	}
+	var registry string
+	for _, kv := range span.attrs {
+		if kv.Key == "ecosystems.registry" {
+			registry = kv.Value.AsString()
+		}
+	}
+	if registry != "rubygems.org" {
+		t.Errorf("ecosystems.registry = %q, want %q", registry, "rubygems.org")
+	}
+}
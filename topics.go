@@ -0,0 +1,78 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go/repos"
+)
+
+// ListTopics returns a page of repository topics, most used first.
+// Topics are shared across all hosts.
+func (c *Client) ListTopics(ctx context.Context, opts ListOptions, callOpts ...CallOption) (*Page[repos.Topic], error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "ListTopics", "")
+	opts = c.listOptions(call, opts)
+	resp, err := c.reposAPI().TopicsWithResponse(ctx, &repos.TopicsParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+	}, call.reposEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("list topics", resp.HTTPResponse, resp.Body)
+	}
+
+	var items []repos.Topic
+	if resp.JSON200 != nil {
+		items = *resp.JSON200
+	}
+	return newPage(items, resp.HTTPResponse, opts), nil
+}
+
+// GetRepositoriesByTopic returns a page of the repositories tagged with
+// topic. The API serves topics across all hosts, so when host is set (for
+// example "GitHub") repositories from other hosts are dropped from the
+// page, which may then hold fewer than opts.PerPage results. It returns
+// nil if no repository uses the topic.
+func (c *Client) GetRepositoriesByTopic(ctx context.Context, host, topic string, opts ListOptions, callOpts ...CallOption) ([]repos.Repository, error) {
+	call := newCallConfig(callOpts)
+	ctx = withOperation(call.context(ctx), "GetRepositoriesByTopic", "")
+	opts = c.listOptions(call, opts)
+	resp, err := c.reposAPI().TopicWithResponse(ctx, topic, &repos.TopicParams{
+		Page:    intParam(opts.Page),
+		PerPage: intParam(opts.PerPage),
+		Sort:    stringParam(opts.Sort),
+		Order:   stringParam(opts.Order),
+	}, call.reposEditors()...)
+	if err != nil {
+		return nil, fmt.Errorf("get repositories by topic: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("get repositories by topic", resp.HTTPResponse, resp.Body)
+	}
+
+	if resp.JSON200 == nil || resp.JSON200.Repositories == nil {
+		return nil, nil
+	}
+
+	if host == "" {
+		return *resp.JSON200.Repositories, nil
+	}
+	var matched []repos.Repository
+	for _, repo := range *resp.JSON200.Repositories {
+		if repo.Host != nil && repo.Host.Name != nil && strings.EqualFold(*repo.Host.Name, host) {
+			matched = append(matched, repo)
+		}
+	}
+	return matched, nil
+}
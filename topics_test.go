@@ -0,0 +1,50 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListTopics(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	page, err := client.ListTopics(context.Background(), ListOptions{PerPage: 2})
+	if err != nil {
+		t.Fatalf("ListTopics() error = %v", err)
+	}
+	topics := page.Items
+	if len(topics) != 2 {
+		t.Fatalf("ListTopics() returned %d topics, want 2", len(topics))
+	}
+	if *topics[0].Name != "javascript" || *topics[1].Name != "ruby" {
+		t.Errorf("ListTopics() = [%q %q], want [\"javascript\" \"ruby\"]", *topics[0].Name, *topics[1].Name)
+	}
+}
+
+func TestGetRepositoriesByTopic(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		host  string
+		topic string
+		want  int
+	}{
+		{"", "ruby", 2},
+		{"GitHub", "ruby", 1},
+		{"github", "ruby", 1},
+		{"GitLab.com", "javascript", 0},
+		{"", "missing", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host+"/"+tt.topic, func(t *testing.T) {
+			got, err := client.GetRepositoriesByTopic(context.Background(), tt.host, tt.topic, ListOptions{})
+			if err != nil {
+				t.Fatalf("GetRepositoriesByTopic() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("GetRepositoriesByTopic() returned %d repositories, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
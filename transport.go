@@ -0,0 +1,209 @@
+package ecosystems
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// maxRateLimitRetries bounds how often a rate-limited request is retried.
+const maxRateLimitRetries = 8
+
+// rateLimitBackoff is the first wait after a 429 response without a
+// Retry-After header. It doubles with each retry, up to maxRateLimitDelay.
+var rateLimitBackoff = time.Second
+
+// maxRateLimitDelay is the longest wait before retrying a rate limited
+// request. A 429 asking for a longer Retry-After is not retried.
+const maxRateLimitDelay = time.Minute
+
+// Transport is an http.RoundTripper that behaves like the client towards
+// servers: it identifies itself with User-Agent and From headers and waits
+// out 429 Too Many Requests responses, honoring Retry-After, before
+// retrying. The client sends its own API requests through one. Use it for
+// requests next to the API calls, such as downloading artifacts referenced
+// in package metadata. Client.Transport returns one configured like the
+// client. It never sends the client's API keys.
+type Transport struct {
+	// Base sends the requests. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+	// UserAgent and From are set on requests that do not carry them.
+	UserAgent string
+	From      string
+	// Header is set on every request, replacing values of the same keys.
+	Header http.Header
+	// MaxRetries bounds the retries of a rate limited request. Zero means
+	// 8 retries; a negative value disables them.
+	MaxRetries int
+
+	stats  *clientStats
+	closed *atomic.Bool
+}
+
+// RoundTrip implements http.RoundTripper. Requests with a body are only
+// retried if they have GetBody, as http.NewRequest sets for common bodies.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.closed != nil && t.closed.Load() {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ErrClientClosed
+	}
+	req = req.Clone(req.Context())
+	if t.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", t.UserAgent)
+	}
+	if t.From != "" && req.Header.Get("From") == "" {
+		req.Header.Set("From", t.From)
+	}
+	for k, v := range t.Header {
+		req.Header[k] = slices.Clone(v)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	maxRetries := t.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = maxRateLimitRetries
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(req.WithContext(withAttempt(req.Context(), attempt)))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRetries {
+			return resp, nil
+		}
+		delay := rateLimitDelay(attempt, resp.Header)
+		if !retryWithin(req.Context(), delay) {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		t.stats.rateLimited(delay)
+		t.stats.retry()
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// Transport returns a Transport that sends requests like the client does,
+// with its User-Agent and From headers, WithHostRateLimit throttling and
+// rate limit retries counted in Stats, over the client's underlying HTTP
+// transport but without the API middleware such as caching, offline mode
+// or the circuit breaker. Its requests fail with ErrClientClosed after
+// Close.
+func (c *Client) Transport() *Transport {
+	t := *c.roundTripper
+	return &t
+}
+
+// buildHTTPClient returns the HTTP client used for API requests, with the
+// configured middleware wrapped around its transport and api, a Transport
+// that sets the headers and retries rate limited requests, outermost. The
+// caller's client is copied rather than modified. It also returns the
+// Transport for Client.Transport, which skips the middleware.
+func buildHTTPClient(cfg *clientConfig, tel *telemetry, api *Transport) (*http.Client, *Transport) {
+	base := cfg.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if len(cfg.hostRateLimits) > 0 {
+		base = newHostRateLimitTransport(base, cfg.hostRateLimits)
+	}
+	stats := api.stats
+
+	transport := base
+	if cfg.compression {
+		transport = &decompressTransport{next: transport}
+	}
+	if cfg.maxResponseBytes > 0 {
+		transport = &sizeLimitTransport{next: transport, limit: cfg.maxResponseBytes}
+	}
+	if cfg.gzipRequests {
+		transport = &gzipRequestTransport{next: transport, stats: stats}
+	}
+	if cfg.recorderDir != "" {
+		transport = NewRecorder(cfg.recorderDir, cfg.recorderMode, transport)
+	}
+	if cfg.offlineStore != nil {
+		transport = &offlineTransport{store: cfg.offlineStore, mode: cfg.offlineMode, next: transport, stats: stats}
+	}
+	transport = &statsTransport{next: transport, stats: stats}
+	if tel != nil {
+		transport = &telemetryTransport{next: transport, telemetry: tel}
+	}
+	if cfg.logger != nil {
+		transport = &loggingTransport{next: transport, logger: cfg.logger}
+	}
+	if cfg.debugWriter != nil {
+		transport = &debugTransport{next: transport, bodies: cfg.debugBodies, w: cfg.debugWriter}
+	}
+	transport = &metaTransport{next: transport}
+	transport = &timeoutTransport{next: transport, timeout: cfg.requestTimeout}
+	if cfg.breakerThreshold > 0 {
+		transport = newBreakerTransport(transport, cfg.breakerThreshold, cfg.breakerCooldown)
+	}
+
+	direct := *api
+	direct.Base = base
+	api.Base = transport
+
+	client := *cfg.httpClient
+	client.Transport = api
+	return &client, &direct
+}
+
+// retryWithin reports whether a retry after delay is worth waiting for:
+// delay is at most maxRateLimitDelay and ends before ctx's deadline.
+func retryWithin(ctx context.Context, delay time.Duration) bool {
+	if delay > maxRateLimitDelay {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+	return true
+}
+
+// rateLimitDelay is how long to wait before retry attempt+1 of a request
+// rejected with 429, honoring a Retry-After header in seconds or as a date.
+func rateLimitDelay(attempt int, h http.Header) time.Duration {
+	if after := h.Get("Retry-After"); after != "" {
+		if secs, err := strconv.Atoi(after); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+		if t := parseHTTPTime(after); !t.IsZero() {
+			return max(time.Until(t), 0)
+		}
+	}
+	return min(rateLimitBackoff<<attempt, maxRateLimitDelay)
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// limitedServer answers the first limited requests with 429 and the rest
+// with the request's User-Agent, From and body.
+func limitedServer(t *testing.T, limited int32) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= limited {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, want none", auth)
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, r.Header.Get("User-Agent")+"|"+r.Header.Get("From")+"|"+r.Header.Get("X-Extra")+"|"+string(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		transport  Transport
+		limited    int32
+		body       string
+		wantStatus int
+		wantBody   string
+		wantCalls  int32
+	}{
+		{"headers", Transport{UserAgent: "tool/1.0", From: "me@example.com", Header: http.Header{"X-Extra": {"1"}}}, 0, "", 200, "tool/1.0|me@example.com|1|", 1},
+		{"retried", Transport{UserAgent: "tool/1.0"}, 2, "", 200, "tool/1.0|||", 3},
+		{"body resent", Transport{}, 1, "payload", 200, "Go-http-client/1.1|||payload", 2},
+		{"retries exhausted", Transport{MaxRetries: 1}, 5, "", 429, "", 2},
+		{"retries disabled", Transport{MaxRetries: -1}, 5, "", 429, "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := limitedServer(t, tt.limited)
+			req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: &tt.transport}).Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus || (tt.wantBody != "" && string(body) != tt.wantBody) {
+				t.Errorf("response = %d %q, want %d %q", resp.StatusCode, body, tt.wantStatus, tt.wantBody)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("server got %d requests, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientTransport(t *testing.T) {
+	srv, _ := limitedServer(t, 1)
+	client, err := NewClient("test-agent/1.0", WithFrom("me@example.com"), WithAPIKey("secret"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: client.Transport()}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(string(body), "test-agent/1.0") || !strings.Contains(string(body), "|me@example.com|") {
+		t.Errorf("request headers = %q, want the client's User-Agent and From", body)
+	}
+	if stats := client.Stats(); stats.Retries != 1 || stats.RateLimitWaits != 1 {
+		t.Errorf("Stats() = %+v, want 1 rate limited retry", stats)
+	}
+}
+
+func TestClientTransportHostRateLimitAndClose(t *testing.T) {
+	srv, _ := limitedServer(t, 0)
+	u, _ := url.Parse(srv.URL)
+	client, err := NewClient("test-agent/1.0", WithHostRateLimit(u.Host, 20))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	hc := &http.Client{Transport: client.Transport()}
+
+	start := time.Now()
+	for range 3 {
+		resp, err := hc.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests took %v, want the host rate limit to apply", elapsed)
+	}
+
+	client.Close()
+	if _, err := hc.Get(srv.URL); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Get() after Close error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if ua := r.Header.Get("User-Agent"); !strings.HasPrefix(ua, "test-agent/1.0") {
+			t.Errorf("User-Agent = %q, want the client's", ua)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "rubygems.org"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	reg, err := client.GetRegistry(context.Background(), "rubygems.org")
+	if err != nil || reg == nil {
+		t.Fatalf("GetRegistry() = %v, %v, want the registry after a retry", reg, err)
+	}
+	if calls != 2 {
+		t.Errorf("server got %d requests, want 2", calls)
+	}
+	if stats := client.Stats(); stats.Retries != 1 {
+		t.Errorf("Stats().Retries = %d, want 1", stats.Retries)
+	}
+}
+
+func TestTransportLongRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		timeout    time.Duration
+		wantCalls  int32
+	}{
+		{"a day", "86400", 0, 1},
+		{"past the deadline", "5", time.Second, 1},
+		{"within both", "0", time.Second, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			defer srv.Close()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+			start := time.Now()
+			resp, err := (&Transport{}).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			resp.Body.Close()
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("RoundTrip() took %v", elapsed)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	srv, calls := limitedServer(t, 5)
+	client, err := NewClient("test-agent/1.0", WithPackagesServer(srv.URL), WithMaxRetries(2))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	_, err = client.GetRegistry(context.Background(), "rubygems.org")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("GetRegistry() error = %v, want 429 APIError", err)
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+}
@@ -0,0 +1,116 @@
+package ecosystems
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// SimilarPackage is a package whose name is close enough to another's to
+// be a potential typosquat of it.
+type SimilarPackage struct {
+	Name string
+	// Distance is the number of single character insertions, deletions,
+	// substitutions and adjacent transpositions between the two names,
+	// ignoring case.
+	Distance int
+	// Homoglyph is set when the names look alike once visually confusable
+	// characters, such as "0" and "o" or "rn" and "m", and separators are
+	// folded together.
+	Homoglyph bool
+}
+
+// homoglyphs folds visually confusable character sequences, including
+// Cyrillic lookalikes of Latin letters, into one form.
+var homoglyphs = strings.NewReplacer(
+	"rn", "m",
+	"vv", "w",
+	"а", "a",
+	"е", "e",
+	"о", "o",
+	"р", "p",
+	"с", "c",
+	"х", "x",
+	"0", "o",
+	"1", "l",
+	"i", "l",
+	"!", "l",
+	"|", "l",
+	"5", "s",
+	"$", "s",
+	"-", "",
+	"_", "",
+	".", "",
+)
+
+// FindSimilarPackages flags potential typosquats of a package: the packages
+// in the registry whose names are within maxDistance edits of name, or look
+// like it apart from confusable characters. ecosyste.ms has no fuzzy search
+// endpoint, so this streams every package name of the registry through
+// GetRegistryPackageNames; on registries with millions of packages that
+// takes thousands of requests, so run it as a batch job. Results are
+// ordered by distance, then name.
+func (c *Client) FindSimilarPackages(ctx context.Context, registry, name string, maxDistance int, opts ...CallOption) ([]SimilarPackage, error) {
+	target := strings.ToLower(name)
+	skeleton := homoglyphs.Replace(target)
+
+	var similar []SimilarPackage
+	for candidate, err := range c.GetRegistryPackageNames(ctx, registry, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		lower := strings.ToLower(candidate)
+		if lower == target {
+			continue
+		}
+		homoglyph := homoglyphs.Replace(lower) == skeleton
+		if !homoglyph && abs(utf8.RuneCountInString(lower)-utf8.RuneCountInString(target)) > maxDistance {
+			continue
+		}
+		if distance := editDistance(lower, target); distance <= maxDistance || homoglyph {
+			similar = append(similar, SimilarPackage{Name: candidate, Distance: distance, Homoglyph: homoglyph})
+		}
+	}
+
+	slices.SortFunc(similar, func(a, b SimilarPackage) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.Name, b.Name))
+	})
+	return similar, nil
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the Levenshtein distance extended with transpositions of adjacent
+// characters, the most common typing mistake.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(t)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
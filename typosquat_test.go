@@ -0,0 +1,51 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFindSimilarPackages(t *testing.T) {
+	client, srv := newTestClient(t)
+	for _, name := range []string{"lodash", "lodahs", "1odash", "lodash-es", "l0dash", "Lodash", "lоdash", "underscore", "iodash-utils"} {
+		srv.AddPackage("npmjs.org", registryPackage("npmjs.org", name))
+	}
+
+	similar, err := client.FindSimilarPackages(context.Background(), "npmjs.org", "lodash", 1)
+	if err != nil {
+		t.Fatalf("FindSimilarPackages() error = %v", err)
+	}
+	want := []SimilarPackage{
+		{"1odash", 1, true},
+		{"l0dash", 1, true},
+		{"lodahs", 1, false},
+		{"lоdash", 1, true},
+	}
+	if !reflect.DeepEqual(similar, want) {
+		t.Errorf("FindSimilarPackages() = %+v, want %+v", similar, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"lodash", "lodash", 0},
+		{"lodash", "lodahs", 1},
+		{"lodash", "lodas", 1},
+		{"lodash", "lodassh", 1},
+		{"lodash", "l0dash", 1},
+		{"react", "raect", 1},
+		{"express", "expresss", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
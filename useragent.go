@@ -0,0 +1,54 @@
+package ecosystems
+
+import (
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+const modulePath = "github.com/ecosyste-ms/ecosystems-go"
+
+// sdkProduct returns the User-Agent product token for this library, such as
+// "ecosystems-go/v1.2.0", read from the binary's build information.
+var sdkProduct = sync.OnceValue(func() string {
+	version := "devel"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		v := info.Main.Version
+		if info.Main.Path != modulePath {
+			v = ""
+			for _, dep := range info.Deps {
+				if dep.Path == modulePath {
+					v = dep.Version
+					if dep.Replace != nil && dep.Replace.Version != "" {
+						v = dep.Replace.Version
+					}
+					break
+				}
+			}
+		}
+		if v != "" && v != "(devel)" {
+			version = v
+		}
+	}
+	return "ecosystems-go/" + version
+})
+
+// UserAgent builds a User-Agent string for NewClient in the conventional
+// "app/version" format, followed by this library's product token so that
+// ecosyste.ms can tell which SDK release made a request.
+func UserAgent(app, version string) string {
+	ua := strings.TrimSpace(app)
+	if v := strings.TrimSpace(version); v != "" {
+		ua += "/" + v
+	}
+	return ua + " " + sdkProduct()
+}
+
+// fullUserAgent appends this library's product token to userAgent unless
+// it already has one.
+func fullUserAgent(userAgent string) string {
+	if strings.Contains(userAgent, "ecosystems-go/") {
+		return userAgent
+	}
+	return userAgent + " " + sdkProduct()
+}
@@ -0,0 +1,48 @@
+package ecosystems
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		app, version string
+		want         string
+	}{
+		{"myapp", "1.0", "myapp/1.0 "},
+		{"myapp", "", "myapp "},
+		{" myapp ", " 2.3.4 ", "myapp/2.3.4 "},
+	}
+
+	for _, tt := range tests {
+		got := UserAgent(tt.app, tt.version)
+		if want := tt.want + sdkProduct(); got != want {
+			t.Errorf("UserAgent(%q, %q) = %q, want %q", tt.app, tt.version, got, want)
+		}
+	}
+}
+
+func TestSDKProduct(t *testing.T) {
+	got := sdkProduct()
+	if !strings.HasPrefix(got, "ecosystems-go/") || got == "ecosystems-go/" {
+		t.Errorf("sdkProduct() = %q, want ecosystems-go/<version>", got)
+	}
+}
+
+func TestFullUserAgent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"myapp/1.0", "myapp/1.0 " + sdkProduct()},
+		{UserAgent("myapp", "1.0"), UserAgent("myapp", "1.0")},
+		{"myapp/1.0 ecosystems-go/v0.1.0", "myapp/1.0 ecosystems-go/v0.1.0"},
+	}
+
+	for _, tt := range tests {
+		if got := fullUserAgent(tt.in); got != tt.want {
+			t.Errorf("fullUserAgent(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
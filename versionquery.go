@@ -0,0 +1,105 @@
+package ecosystems
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/versions"
+	packageurl "github.com/git-pkgs/packageurl-go"
+)
+
+// GetVersionsMatching returns the versions of the package identified by purl
+// that satisfy constraint, newest first. The constraint uses the range
+// syntax of the PURL's ecosystem, such as "^1.2.0" for npm, "~> 1.2" for
+// gems or ">=2,<3" for PyPI; see versions.ParseConstraint. The PURL's own
+// version, if any, is ignored.
+func (c *Client) GetVersionsMatching(ctx context.Context, purl packageurl.PackageURL, constraint string, opts ...CallOption) ([]packages.Version, error) {
+	cons, err := versions.ParseConstraint(purl.Type, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := c.GetAllVersionsPURL(ctx, purl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []packages.Version
+	for _, v := range all {
+		if cons.Check(v.Number) {
+			matching = append(matching, v)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return versions.Compare(purl.Type, matching[i].Number, matching[j].Number) > 0
+	})
+	return matching, nil
+}
+
+// LatestVersionOptions controls which versions GetLatestVersion considers.
+type LatestVersionOptions struct {
+	// IncludePrereleases allows prerelease versions to be returned.
+	IncludePrereleases bool
+	// PublishedBefore, if set, excludes versions published at or after it
+	// and versions with no known publish date.
+	PublishedBefore time.Time
+}
+
+// GetLatestVersion returns the newest version number of the package
+// identified by purl, or "" if the package or no eligible version exists.
+// With default options it uses the package's latest release field, making
+// a single request; otherwise, or when that release is a prerelease, it
+// picks the newest eligible version from the package's version numbers, or
+// from all versions when their publish dates are needed.
+func (c *Client) GetLatestVersion(ctx context.Context, purl packageurl.PackageURL, opts LatestVersionOptions, callOpts ...CallOption) (string, error) {
+	if !opts.IncludePrereleases && opts.PublishedBefore.IsZero() {
+		pkg, err := c.LookupPURL(ctx, purl, callOpts...)
+		if err != nil {
+			return "", err
+		}
+		if pkg == nil {
+			return "", nil
+		}
+		if pkg.LatestReleaseNumber != nil && !versions.IsPrerelease(purl.Type, *pkg.LatestReleaseNumber) {
+			return *pkg.LatestReleaseNumber, nil
+		}
+	}
+
+	if opts.PublishedBefore.IsZero() {
+		numbers, err := c.GetVersionNumbersPURL(ctx, purl, callOpts...)
+		if err != nil {
+			return "", err
+		}
+		latest := ""
+		for _, n := range numbers {
+			if (opts.IncludePrereleases || !versions.IsPrerelease(purl.Type, n)) && (latest == "" || versions.Compare(purl.Type, n, latest) > 0) {
+				latest = n
+			}
+		}
+		return latest, nil
+	}
+
+	all, err := c.GetAllVersionsPURL(ctx, purl, callOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	latest := ""
+	for _, v := range all {
+		if !opts.IncludePrereleases && versions.IsPrerelease(purl.Type, v.Number) {
+			continue
+		}
+		if !opts.PublishedBefore.IsZero() {
+			published, ok := parseTimestamp(v.PublishedAt)
+			if !ok || !published.Before(opts.PublishedBefore) {
+				continue
+			}
+		}
+		if latest == "" || versions.Compare(purl.Type, v.Number, latest) > 0 {
+			latest = v.Number
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,114 @@
+package ecosystems
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestGetVersionsMatching(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	purl, err := ParsePURL("pkg:gem/rails")
+	if err != nil {
+		t.Fatalf("ParsePURL() error = %v", err)
+	}
+	got, err := client.GetVersionsMatching(context.Background(), purl, "~> 7.1")
+	if err != nil {
+		t.Fatalf("GetVersionsMatching() error = %v", err)
+	}
+
+	want := []string{"7.1.3", "7.1.0"}
+	if len(got) != len(want) {
+		t.Fatalf("GetVersionsMatching() = %d versions, want %d", len(got), len(want))
+	}
+	for i, v := range got {
+		if v.Number != want[i] {
+			t.Errorf("GetVersionsMatching()[%d] = %q, want %q", i, v.Number, want[i])
+		}
+	}
+}
+
+func TestGetVersionsMatchingInvalidConstraint(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purl, _ := ParsePURL("pkg:npm/lodash")
+	if _, err := client.GetVersionsMatching(context.Background(), purl, ">= 1 &&& < 2"); err == nil {
+		t.Error("GetVersionsMatching() with invalid constraint should error")
+	}
+	if got := len(srv.Requests()); got != 0 {
+		t.Errorf("GetVersionsMatching() made %d requests for an invalid constraint, want 0", got)
+	}
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+	srv.AddVersion("rubygems.org", "rails", packages.VersionWithDependencies{
+		Number:      "7.2.0.beta1",
+		PublishedAt: strPtr("2024-05-29T00:00:00Z"),
+	})
+
+	purl, _ := ParsePURL("pkg:gem/rails")
+	tests := []struct {
+		name     string
+		opts     LatestVersionOptions
+		expected string
+	}{
+		{"latest release field", LatestVersionOptions{}, "7.1.3"},
+		{"include prereleases", LatestVersionOptions{IncludePrereleases: true}, "7.2.0.beta1"},
+		{"published before", LatestVersionOptions{PublishedBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, "7.1.0"},
+		{"nothing eligible", LatestVersionOptions{PublishedBefore: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetLatestVersion(ctx, purl, tt.opts)
+			if err != nil {
+				t.Fatalf("GetLatestVersion() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("GetLatestVersion() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetLatestVersionUsesLatestReleaseField(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purl, _ := ParsePURL("pkg:npm/lodash")
+	if _, err := client.GetLatestVersion(context.Background(), purl, LatestVersionOptions{}); err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("GetLatestVersion() made %d requests, want 1", got)
+	}
+}
+
+func TestGetLatestVersionUsesVersionNumbers(t *testing.T) {
+	client, srv := newTestClient(t)
+
+	purl, _ := ParsePURL("pkg:gem/rails")
+	if _, err := client.GetLatestVersion(context.Background(), purl, LatestVersionOptions{IncludePrereleases: true}); err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if got := countRequests(srv.Requests(), "/versions"); got != 0 {
+		t.Errorf("GetLatestVersion() made %d full version requests, want 0", got)
+	}
+}
+
+func TestGetLatestVersionNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	purl, _ := ParsePURL("pkg:npm/does-not-exist")
+	got, err := client.GetLatestVersion(context.Background(), purl, LatestVersionOptions{})
+	if err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("GetLatestVersion() = %q, want empty", got)
+	}
+}
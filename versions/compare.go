@@ -0,0 +1,347 @@
+package versions
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Semantic versioning (npm, Cargo, Go modules and others).
+
+var semverPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+type semver struct {
+	major, minor, patch int64
+	pre                 []string
+}
+
+func parseSemver(v string) (semver, bool) {
+	m := semverPattern.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return semver{}, false
+	}
+	var sv semver
+	var err error
+	if sv.major, err = strconv.ParseInt(m[1], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if sv.minor, err = strconv.ParseInt(m[2], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if sv.patch, err = strconv.ParseInt(m[3], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if m[4] != "" {
+		sv.pre = strings.Split(m[4], ".")
+	}
+	return sv, true
+}
+
+// compareSemver follows semver 2.0.0 precedence, falling back to the
+// generic ordering for versions that are not valid semver.
+func compareSemver(a, b string) int {
+	sa, okA := parseSemver(a)
+	sb, okB := parseSemver(b)
+	if !okA || !okB {
+		return compareGeneric(a, b)
+	}
+	if c := compareInt(sa.major, sb.major); c != 0 {
+		return c
+	}
+	if c := compareInt(sa.minor, sb.minor); c != 0 {
+		return c
+	}
+	if c := compareInt(sa.patch, sb.patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(sa.pre) == 0 && len(sb.pre) == 0:
+		return 0
+	case len(sa.pre) == 0:
+		return 1
+	case len(sb.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(sa.pre) && i < len(sb.pre); i++ {
+		na, errA := strconv.ParseInt(sa.pre[i], 10, 64)
+		nb, errB := strconv.ParseInt(sb.pre[i], 10, 64)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = compareInt(na, nb)
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(sa.pre[i], sb.pre[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(int64(len(sa.pre)), int64(len(sb.pre)))
+}
+
+// PEP 440 (PyPI).
+
+var pep440Pattern = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+type pep440 struct {
+	epoch   int64
+	release []int64
+	phase   int // -1 dev-only release, 0-2 alpha/beta/rc, 3 final
+	pre     int64
+	post    int64 // -1 when absent
+	dev     int64 // math.MaxInt64 when absent
+	local   string
+}
+
+func parsePEP440(v string) (pep440, bool) {
+	m := pep440Pattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v)))
+	if m == nil {
+		return pep440{}, false
+	}
+	p := pep440{phase: 3, post: -1, dev: math.MaxInt64, local: m[10]}
+	p.epoch = atoi(m[1])
+	for _, s := range strings.Split(m[2], ".") {
+		p.release = append(p.release, atoi(s))
+	}
+	switch m[3] {
+	case "a", "alpha":
+		p.phase, p.pre = 0, atoi(m[4])
+	case "b", "beta":
+		p.phase, p.pre = 1, atoi(m[4])
+	case "c", "rc", "pre", "preview":
+		p.phase, p.pre = 2, atoi(m[4])
+	}
+	switch {
+	case m[5] != "":
+		p.post = atoi(m[5])
+	case m[6] != "":
+		p.post = atoi(m[7])
+	}
+	if m[8] != "" {
+		p.dev = atoi(m[9])
+		if m[3] == "" && p.post < 0 {
+			// 1.0.dev1 sorts before 1.0a1
+			p.phase = -1
+		}
+	}
+	return p, true
+}
+
+func (p pep440) prerelease() bool {
+	return p.phase < 3 || p.dev != math.MaxInt64
+}
+
+// comparePEP440 follows PEP 440 ordering, falling back to the generic
+// ordering for versions that do not parse.
+func comparePEP440(a, b string) int {
+	pa, okA := parsePEP440(a)
+	pb, okB := parsePEP440(b)
+	if !okA || !okB {
+		return compareGeneric(a, b)
+	}
+	if c := compareInt(pa.epoch, pb.epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(pa.release, pb.release); c != 0 {
+		return c
+	}
+	if c := compareInt(int64(pa.phase), int64(pb.phase)); c != 0 {
+		return c
+	}
+	if c := compareInt(pa.pre, pb.pre); c != 0 {
+		return c
+	}
+	if c := compareInt(pa.post, pb.post); c != 0 {
+		return c
+	}
+	if c := compareInt(pa.dev, pb.dev); c != 0 {
+		return c
+	}
+	return strings.Compare(pa.local, pb.local)
+}
+
+// RubyGems (Gem::Version).
+
+// compareGem follows Gem::Version ordering: a hyphen starts a prerelease
+// ("1.0-beta" is "1.0.pre.beta"), letters sort before numbers, and zeros
+// before a prerelease or at the end are ignored.
+func compareGem(a, b string) int {
+	sa := gemSegments(a)
+	sb := gemSegments(b)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		x, y := segment{numeric: true}, segment{numeric: true}
+		if i < len(sa) {
+			x = sa[i]
+		}
+		if i < len(sb) {
+			y = sb[i]
+		}
+		if c := compareSegment(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func gemSegments(v string) []segment {
+	v = strings.ReplaceAll(strings.TrimSpace(v), "-", ".pre.")
+	segs := parse(v).segments
+
+	// Drop zeros at the end of the release and before the prerelease part,
+	// so "1.0" == "1" and "1.0.a" == "1.a".
+	end := len(segs)
+	for i, s := range segs {
+		if !s.numeric {
+			end = i
+			break
+		}
+	}
+	start := end
+	for start > 0 && segs[start-1].numeric && segs[start-1].num == 0 {
+		start--
+	}
+	canonical := append([]segment(nil), segs[:start]...)
+	canonical = append(canonical, segs[end:]...)
+	for len(canonical) > 0 {
+		last := canonical[len(canonical)-1]
+		if !last.numeric || last.num != 0 {
+			break
+		}
+		canonical = canonical[:len(canonical)-1]
+	}
+	return canonical
+}
+
+// Debian (dpkg).
+
+// compareDebian follows dpkg ordering of [epoch:]upstream[-revision].
+func compareDebian(a, b string) int {
+	ea, ua, ra := splitDebian(a)
+	eb, ub, rb := splitDebian(b)
+	if c := compareInt(ea, eb); c != 0 {
+		return c
+	}
+	if c := compareDpkg(ua, ub); c != 0 {
+		return c
+	}
+	return compareDpkg(ra, rb)
+}
+
+func splitDebian(v string) (epoch int64, upstream, revision string) {
+	v = strings.TrimSpace(v)
+	if i := strings.IndexByte(v, ':'); i >= 0 {
+		if n, err := strconv.ParseInt(v[:i], 10, 64); err == nil {
+			epoch = n
+			v = v[i+1:]
+		}
+	}
+	if i := strings.LastIndexByte(v, '-'); i >= 0 {
+		return epoch, v[:i], v[i+1:]
+	}
+	return epoch, v, ""
+}
+
+// compareDpkg implements dpkg's verrevcmp: alternating runs of non-digits,
+// compared with "~" before everything and letters before other symbols,
+// and digits, compared numerically.
+func compareDpkg(a, b string) int {
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			var ca, cb int
+			if a != "" && !isDigit(a[0]) {
+				ca = dpkgOrder(a[0])
+				a = a[1:]
+			}
+			if b != "" && !isDigit(b[0]) {
+				cb = dpkgOrder(b[0])
+				b = b[1:]
+			}
+			if ca != cb {
+				return compareInt(int64(ca), int64(cb))
+			}
+		}
+
+		var da, db string
+		da, a = digitPrefix(a)
+		db, b = digitPrefix(b)
+		if c := compareDigits(da, db); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func dpkgOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	}
+	return int(c) + 256
+}
+
+func digitPrefix(s string) (digits, rest string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareDigits compares decimal strings of any length numerically.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if c := compareInt(int64(len(a)), int64(len(b))); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Helpers.
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareRelease(a, b []int64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareInt(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func atoi(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
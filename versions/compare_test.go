@@ -0,0 +1,84 @@
+package versions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		a, b      string
+		expected  int
+	}{
+		// semver
+		{"npm", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"npm", "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"npm", "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"npm", "1.0.0-rc.1", "1.0.0", -1},
+		{"npm", "1.0.0+build.1", "1.0.0+build.2", 0},
+		{"cargo", "1.10.0", "1.9.0", 1},
+		{"golang", "v0.3.0", "v0.2.9", 1},
+
+		// PEP 440
+		{"pypi", "1.0.dev1", "1.0a1", -1},
+		{"pypi", "1.0a1", "1.0b1", -1},
+		{"pypi", "1.0b2", "1.0rc1", -1},
+		{"pypi", "1.0rc1", "1.0", -1},
+		{"pypi", "1.0", "1.0.post1", -1},
+		{"pypi", "1.0.post1.dev1", "1.0.post1", -1},
+		{"pypi", "1.0", "1.0.0", 0},
+		{"pypi", "1!0.5", "2.0", 1},
+		{"pypi", "1.0-1", "1.0.post1", 0},
+		{"pypi", "1.0+local", "1.0", 1},
+
+		// Gem::Version
+		{"gem", "1.0.0.pre", "1.0.0", -1},
+		{"gem", "1.0.a", "1.0.b", -1},
+		{"gem", "1.0", "1", 0},
+		{"gem", "1.0.a", "1.a", 0},
+		{"rubygems", "1.0.0-beta", "1.0.0.pre.beta", 0},
+		{"gem", "1.10", "1.9", 1},
+
+		// dpkg
+		{"deb", "1.0~rc1", "1.0", -1},
+		{"deb", "1:0.9", "2.0", 1},
+		{"deb", "1.0-1", "1.0-2", -1},
+		{"deb", "1.0a", "1.0+", -1},
+		{"debian", "2.36.1-8ubuntu1", "2.36.1-8", 1},
+		{"deb", "1.0.10", "1.0.9", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ecosystem+" "+tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := Compare(tt.ecosystem, tt.a, tt.b); got != tt.expected {
+				t.Errorf("Compare(%q, %q, %q) = %d, want %d", tt.ecosystem, tt.a, tt.b, got, tt.expected)
+			}
+			if got := Compare(tt.ecosystem, tt.b, tt.a); got != -tt.expected {
+				t.Errorf("Compare(%q, %q, %q) = %d, want %d", tt.ecosystem, tt.b, tt.a, got, -tt.expected)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		input     []string
+		expected  []string
+	}{
+		{"npm", []string{"1.10.0", "1.2.0", "1.2.0-rc.1", "0.9.0"}, []string{"0.9.0", "1.2.0-rc.1", "1.2.0", "1.10.0"}},
+		{"pypi", []string{"1.0", "1.0.post1", "1.0rc1", "1.0.dev1"}, []string{"1.0.dev1", "1.0rc1", "1.0", "1.0.post1"}},
+		{"deb", []string{"1.0", "1.0~beta", "1:0.1"}, []string{"1.0~beta", "1.0", "1:0.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ecosystem, func(t *testing.T) {
+			got := append([]string(nil), tt.input...)
+			Sort(tt.ecosystem, got)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Sort(%q) = %v, want %v", tt.ecosystem, got, tt.expected)
+			}
+		})
+	}
+}
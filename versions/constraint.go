@@ -0,0 +1,278 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a parsed version range such as "^1.2.0" or ">= 2, < 3".
+type Constraint struct {
+	ecosystem string
+	// anyOf holds alternatives separated by "||"; a version matches when it
+	// satisfies every comparator in one of them.
+	anyOf    [][]comparator
+	allowPre bool
+}
+
+type comparator struct {
+	op string // "=", "!=", ">", ">=", "<", "<=" or "!range"
+	lo string
+	hi string // upper bound for "!range"
+}
+
+var (
+	comparatorPattern = regexp.MustCompile(`(~>|~=|===|==|!=|>=|<=|\^|~|>|<|=)?\s*([0-9A-Za-z*][0-9A-Za-z.*+_-]*)`)
+	hyphenPattern     = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+)
+
+// ParseConstraint parses a version range in the syntax used by ecosystem:
+//
+//   - npm: "^1.2.0", "~1.2", "1.x", ">=2 <3", "1.0.0 - 2.0.0", "a || b"
+//   - gem: "~> 1.2", ">= 2, < 3", "!= 1.5"
+//   - pypi: "~=1.4", ">=2,<3", "==1.*"
+//
+// Other ecosystems accept the same operators separated by commas or spaces.
+func ParseConstraint(ecosystem, s string) (*Constraint, error) {
+	c := &Constraint{ecosystem: normalizeEcosystem(ecosystem)}
+
+	for _, alt := range strings.Split(s, "||") {
+		alt = strings.TrimSpace(alt)
+		group, err := c.parseGroup(alt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q: %w", s, err)
+		}
+		c.anyOf = append(c.anyOf, group)
+	}
+	return c, nil
+}
+
+func (c *Constraint) parseGroup(s string) ([]comparator, error) {
+	if s == "" || s == "*" || s == "x" || s == "X" {
+		return nil, nil
+	}
+
+	if m := hyphenPattern.FindStringSubmatch(s); m != nil {
+		c.notePrerelease(m[1])
+		c.notePrerelease(m[2])
+		lo, err := c.expand(">=", m[1])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := c.expand("<=", m[2])
+		if err != nil {
+			return nil, err
+		}
+		return append(lo, hi...), nil
+	}
+
+	matches := comparatorPattern.FindAllStringSubmatchIndex(s, -1)
+	var group []comparator
+	prev := 0
+	for _, m := range matches {
+		if gap := strings.Trim(s[prev:m[0]], " ,"); gap != "" {
+			return nil, fmt.Errorf("unexpected %q", gap)
+		}
+		prev = m[1]
+
+		op := ""
+		if m[2] >= 0 {
+			op = s[m[2]:m[3]]
+		}
+		version := s[m[4]:m[5]]
+		c.notePrerelease(version)
+
+		expanded, err := c.expand(op, version)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, expanded...)
+	}
+	if gap := strings.Trim(s[prev:], " ,"); gap != "" {
+		return nil, fmt.Errorf("unexpected %q", gap)
+	}
+	return group, nil
+}
+
+func (c *Constraint) notePrerelease(version string) {
+	if !strings.ContainsAny(version, "*xX") && IsPrerelease(c.ecosystem, version) {
+		c.allowPre = true
+	}
+}
+
+// expand turns one operator and version into plain comparators.
+func (c *Constraint) expand(op, version string) ([]comparator, error) {
+	if prefix, ok := wildcardPrefix(version); ok {
+		return expandWildcard(op, prefix)
+	}
+
+	release := parse(version).release()
+	// npm treats partial versions as ranges: "1.2" means "1.2.x", so
+	// "<=1.2" means "<1.3.0" and ">1.2" means ">=1.3.0"
+	if c.ecosystem == "npm" && len(release) > 0 && len(release) < 3 && !parse(version).prerelease() {
+		switch op {
+		case "", "=", "!=", ">", ">=", "<", "<=":
+			return expandWildcard(op, release)
+		}
+	}
+
+	switch op {
+	case "", "=":
+		return []comparator{{op: "=", lo: version}}, nil
+	case "==", "===":
+		return []comparator{{op: "=", lo: version}}, nil
+	case "!=", ">", ">=", "<", "<=":
+		return []comparator{{op: op, lo: version}}, nil
+	case "^":
+		if len(release) == 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		i := len(release) - 1
+		for j, n := range release {
+			if n != 0 {
+				i = j
+				break
+			}
+		}
+		return []comparator{{op: ">=", lo: version}, {op: "<", lo: bump(release, i)}}, nil
+	case "~":
+		if len(release) == 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		return []comparator{{op: ">=", lo: version}, {op: "<", lo: bump(release, min(1, len(release)-1))}}, nil
+	case "~>", "~=":
+		if len(release) == 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		return []comparator{{op: ">=", lo: version}, {op: "<", lo: bump(release, max(0, len(release)-2))}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// wildcardPrefix returns the numeric segments before a "*" or "x" segment.
+func wildcardPrefix(version string) ([]int64, bool) {
+	parts := strings.Split(version, ".")
+	for i, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			var prefix []int64
+			for _, p := range parts[:i] {
+				n, err := strconv.ParseInt(strings.TrimPrefix(p, "v"), 10, 64)
+				if err != nil {
+					return nil, false
+				}
+				prefix = append(prefix, n)
+			}
+			return prefix, true
+		}
+	}
+	return nil, false
+}
+
+// expandWildcard converts an operator applied to a partial version such as
+// "1.2.*" into bounds on the range it covers.
+func expandWildcard(op string, prefix []int64) ([]comparator, error) {
+	if len(prefix) == 0 {
+		if op == "" || op == "=" || op == "==" || op == ">=" || op == "<=" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("operator %q cannot apply to *", op)
+	}
+
+	lo := join(prefix)
+	hi := bump(prefix, len(prefix)-1)
+	switch op {
+	case "", "=", "==", "===":
+		return []comparator{{op: ">=", lo: lo}, {op: "<", lo: hi}}, nil
+	case "!=":
+		return []comparator{{op: "!range", lo: lo, hi: hi}}, nil
+	case ">=":
+		return []comparator{{op: ">=", lo: lo}}, nil
+	case ">":
+		return []comparator{{op: ">=", lo: hi}}, nil
+	case "<":
+		return []comparator{{op: "<", lo: lo}}, nil
+	case "<=":
+		return []comparator{{op: "<", lo: hi}}, nil
+	}
+	return nil, fmt.Errorf("operator %q cannot apply to a wildcard", op)
+}
+
+// bump increments release[i] and drops the segments after it.
+func bump(release []int64, i int) string {
+	bumped := append([]int64(nil), release[:i+1]...)
+	bumped[i]++
+	return join(bumped)
+}
+
+func join(nums []int64) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.FormatInt(n, 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Check reports whether version satisfies the constraint. Prerelease
+// versions only match when the constraint itself names a prerelease; for
+// npm, as in node-semver, only one with the same major.minor.patch in the
+// same "||" alternative, so "^1.2.3-beta.2" matches "1.2.3-beta.3" but not
+// "1.3.0-beta.1".
+func (c *Constraint) Check(version string) bool {
+	pre := IsPrerelease(c.ecosystem, version)
+	if !c.allowPre && pre {
+		return false
+	}
+	for _, group := range c.anyOf {
+		if pre && c.ecosystem == "npm" && !allowsPrerelease(group, version) {
+			continue
+		}
+		if c.checkGroup(group, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsPrerelease reports whether a comparator in group names a
+// prerelease of the same release as version.
+func allowsPrerelease(group []comparator, version string) bool {
+	release := parse(version).release()
+	for _, cmp := range group {
+		if p := parse(cmp.lo); p.prerelease() && slices.Equal(p.release(), release) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Constraint) checkGroup(group []comparator, version string) bool {
+	for _, cmp := range group {
+		if !c.checkComparator(cmp, version) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Constraint) checkComparator(cmp comparator, version string) bool {
+	r := Compare(c.ecosystem, version, cmp.lo)
+	switch cmp.op {
+	case "=":
+		return r == 0
+	case "!=":
+		return r != 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case "!range":
+		return r < 0 || Compare(c.ecosystem, version, cmp.hi) >= 0
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package versions
+
+import "testing"
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		ecosystem  string
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"npm", "^1.2.0", "1.2.0", true},
+		{"npm", "^1.2.0", "1.9.3", true},
+		{"npm", "^1.2.0", "2.0.0", false},
+		{"npm", "^1.2.0", "1.1.9", false},
+		{"npm", "^0.2.3", "0.2.9", true},
+		{"npm", "^0.2.3", "0.3.0", false},
+		{"npm", "^0.0.3", "0.0.4", false},
+		{"npm", "~1.2.3", "1.2.9", true},
+		{"npm", "~1.2.3", "1.3.0", false},
+		{"npm", "1.x", "1.9.0", true},
+		{"npm", "1.x", "2.0.0", false},
+		{"npm", "1.2", "1.2.7", true},
+		{"npm", ">=2 <3", "2.5.0", true},
+		{"npm", ">=2 <3", "3.0.0", false},
+		{"npm", "1.0.0 - 2.0.0", "2.0.0", true},
+		{"npm", "1.0.0 - 2.0.0", "2.0.1", false},
+		{"npm", "^1.0.0 || ^3.0.0", "3.1.0", true},
+		{"npm", "^1.0.0 || ^3.0.0", "2.1.0", false},
+		{"npm", "*", "5.0.0", true},
+		{"npm", "^1.0.0", "1.5.0-beta.1", false},
+		{"npm", ">=1.5.0-beta.0", "1.5.0-beta.1", true},
+		{"npm", "<=1.2", "1.2.5", true},
+		{"npm", "<=1.2", "1.3.0", false},
+		{"npm", ">1.2", "1.2.5", false},
+		{"npm", ">1.2", "1.3.0", true},
+		{"npm", "<1.2", "1.1.9", true},
+		{"npm", "<1.2", "1.2.0", false},
+		{"npm", ">=1.2", "1.2.0", true},
+		{"npm", "!=1.2", "1.2.5", false},
+		{"npm", "!=1.2", "1.3.0", true},
+		{"npm", "1.2.3 - 2.3", "2.3.5", true},
+		{"npm", "1.2.3 - 2.3", "2.4.0", false},
+		{"npm", "1.2 - 2", "1.2.0", true},
+		{"npm", "1.2 - 2", "2.9.9", true},
+		{"npm", "^1.2.3-beta.2", "1.2.3-beta.3", true},
+		{"npm", "^1.2.3-beta.2", "1.2.3-beta.1", false},
+		{"npm", "^1.2.3-beta.2", "1.3.0-beta.1", false},
+		{"npm", "^1.2.3-beta.2", "1.3.0", true},
+		{"npm", "^1.2.3-beta.2 || ^2.0.0", "2.1.0-rc.1", false},
+		{"npm", "1.2.3-alpha.1 - 1.5.0", "1.4.0-beta.1", false},
+		{"cargo", "<=1.2", "1.2.5", false},
+		{"gem", "~> 1.2", "1.9", true},
+		{"gem", "~> 1.2", "2.0", false},
+		{"gem", "~> 1.2.3", "1.2.9", true},
+		{"gem", "~> 1.2.3", "1.3.0", false},
+		{"rubygems", ">= 2, < 3", "2.1", true},
+		{"rubygems", ">= 2, < 3", "3.0", false},
+		{"gem", "!= 1.5", "1.5", false},
+		{"gem", "= 1.5", "1.5.0", true},
+		{"pypi", "~=1.4", "1.9", true},
+		{"pypi", "~=1.4", "2.0", false},
+		{"pypi", "~=1.4.2", "1.4.5", true},
+		{"pypi", "~=1.4.2", "1.5.0", false},
+		{"pypi", ">=2,<3", "2.8.1", true},
+		{"pypi", "==1.*", "1.7", true},
+		{"pypi", "==1.*", "2.0", false},
+		{"pypi", "!=1.*", "1.7", false},
+		{"pypi", "!=1.*", "2.0", true},
+		{"pypi", ">=1.0", "2.0rc1", false},
+		{"pypi", "==1.2", "1.2.0", true},
+		{"cargo", "<=1.2.3", "1.2.3", true},
+		{"cargo", ">1.2.3", "1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ecosystem+" "+tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.ecosystem, tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			if got := c.Check(tt.version); got != tt.expected {
+				t.Errorf("Check(%q) = %v, want %v", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, s := range []string{">= 1 &&& < 2", "^", "> *", "@1.0"} {
+		if _, err := ParseConstraint("npm", s); err == nil {
+			t.Errorf("ParseConstraint(%q) should error", s)
+		}
+	}
+}
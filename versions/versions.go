@@ -0,0 +1,192 @@
+// Package versions compares package version strings and evaluates version
+// range constraints the way package managers do.
+//
+// Functions take an ecosystem, which may be a PURL type ("gem", "pypi") or
+// an ecosyste.ms ecosystem name ("rubygems", "pypi").
+package versions
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ecosystemAliases maps ecosyste.ms ecosystem names to PURL types.
+var ecosystemAliases = map[string]string{
+	"rubygems":  "gem",
+	"packagist": "composer",
+	"go":        "golang",
+	"debian":    "deb",
+	"ubuntu":    "deb",
+}
+
+// normalizeEcosystem returns the PURL type for ecosystem.
+func normalizeEcosystem(ecosystem string) string {
+	ecosystem = strings.ToLower(ecosystem)
+	if t, ok := ecosystemAliases[ecosystem]; ok {
+		return t
+	}
+	return ecosystem
+}
+
+// Compare returns -1, 0 or 1 as version a is older than, equal to or newer
+// than b under the ordering rules of ecosystem: PEP 440 for PyPI,
+// Gem::Version for RubyGems, dpkg for Debian and semver for npm, Cargo,
+// Go and other semver-based ecosystems. Other ecosystems, and versions that
+// do not parse under their ecosystem's rules, use a generic segment-wise
+// ordering.
+func Compare(ecosystem, a, b string) int {
+	switch normalizeEcosystem(ecosystem) {
+	case "pypi":
+		return comparePEP440(a, b)
+	case "gem":
+		return compareGem(a, b)
+	case "deb":
+		return compareDebian(a, b)
+	case "npm", "cargo", "golang", "composer", "nuget", "hex", "pub", "swift":
+		return compareSemver(a, b)
+	}
+	return compareGeneric(a, b)
+}
+
+// Sort sorts vs in place from oldest to newest under the ordering rules of
+// ecosystem. See Compare.
+func Sort(ecosystem string, vs []string) {
+	sort.SliceStable(vs, func(i, j int) bool {
+		return Compare(ecosystem, vs[i], vs[j]) < 0
+	})
+}
+
+// IsPrerelease reports whether version is a prerelease, such as
+// "1.0.0-beta.1", "2.0rc1", "1.0.dev3", "1.0.0.pre" or Debian's "1.0~rc1".
+func IsPrerelease(ecosystem, version string) bool {
+	switch normalizeEcosystem(ecosystem) {
+	case "pypi":
+		if p, ok := parsePEP440(version); ok {
+			return p.prerelease()
+		}
+	case "deb":
+		return strings.Contains(version, "~")
+	}
+	return parse(version).prerelease()
+}
+
+// segment is one numeric or alphabetic part of a version.
+type segment struct {
+	num     int64
+	str     string
+	numeric bool
+}
+
+// parsed is a version split into segments.
+type parsed struct {
+	segments []segment
+}
+
+// parse splits a version into numeric and alphabetic segments, ignoring a
+// leading "v" and any "+build" metadata.
+func parse(v string) parsed {
+	v = strings.TrimSpace(v)
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	var p parsed
+	start := 0
+	flush := func(end int) {
+		if end <= start {
+			return
+		}
+		s := v[start:end]
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			p.segments = append(p.segments, segment{num: n, numeric: true})
+		} else {
+			p.segments = append(p.segments, segment{str: strings.ToLower(s)})
+		}
+	}
+	for i, r := range v {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(v[i-1])):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(v))
+	return p
+}
+
+// prerelease reports whether the version has an alphabetic segment.
+func (p parsed) prerelease() bool {
+	for _, s := range p.segments {
+		if !s.numeric {
+			return true
+		}
+	}
+	return false
+}
+
+// release returns the leading numeric segments.
+func (p parsed) release() []int64 {
+	var nums []int64
+	for _, s := range p.segments {
+		if !s.numeric {
+			break
+		}
+		nums = append(nums, s.num)
+	}
+	return nums
+}
+
+// compareGeneric orders versions segment by segment. Numbers compare
+// numerically and sort after words, so "1.0.0" > "1.0.0-beta". Missing
+// numeric segments count as zero, so "1.0" == "1.0.0".
+func compareGeneric(a, b string) int {
+	pa, pb := parse(a), parse(b)
+	n := max(len(pa.segments), len(pb.segments))
+	for i := 0; i < n; i++ {
+		var sa, sb segment
+		switch {
+		case i >= len(pa.segments):
+			sb = pb.segments[i]
+			if !sb.numeric {
+				return 1
+			}
+			sa = segment{numeric: true}
+		case i >= len(pb.segments):
+			sa = pa.segments[i]
+			if !sa.numeric {
+				return -1
+			}
+			sb = segment{numeric: true}
+		default:
+			sa, sb = pa.segments[i], pb.segments[i]
+		}
+		if c := compareSegment(sa, sb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareSegment(a, b segment) int {
+	switch {
+	case a.numeric && b.numeric:
+		switch {
+		case a.num < b.num:
+			return -1
+		case a.num > b.num:
+			return 1
+		}
+		return 0
+	case a.numeric:
+		return 1
+	case b.numeric:
+		return -1
+	}
+	return strings.Compare(a.str, b.str)
+}
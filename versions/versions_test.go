@@ -0,0 +1,58 @@
+package versions
+
+import "testing"
+
+func TestCompareGeneric(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3+build.5", "1.2.3", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.1", "1.0", 1},
+		{"1.0.0", "1.0.0-beta", 1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"2.0rc1", "2.0", -1},
+		{"1.0.0.beta1", "1.0.0", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := compareGeneric(tt.a, tt.b); got != tt.expected {
+				t.Errorf("compareGeneric(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+			}
+			if got := compareGeneric(tt.b, tt.a); got != -tt.expected {
+				t.Errorf("compareGeneric(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		version   string
+		expected  bool
+	}{
+		{"npm", "1.0.0", false},
+		{"npm", "v2.3", false},
+		{"npm", "1.0.0+build.1", false},
+		{"npm", "1.0.0-beta.1", true},
+		{"pypi", "2.0rc1", true},
+		{"pypi", "2.0.dev3", true},
+		{"pypi", "2.0.post1", false},
+		{"gem", "7.1.0.pre", true},
+		{"deb", "2.36.1-8ubuntu1", false},
+		{"deb", "1.0~rc1-1", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsPrerelease(tt.ecosystem, tt.version); got != tt.expected {
+			t.Errorf("IsPrerelease(%q, %q) = %v, want %v", tt.ecosystem, tt.version, got, tt.expected)
+		}
+	}
+}
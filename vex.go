@@ -0,0 +1,203 @@
+package ecosystems
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// cdxBOM is the subset of a CycloneDX 1.5 document written by
+// ExportCycloneDXVEX.
+type cdxBOM struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	Version         int                `json:"version"`
+	Components      []cdxComponent     `json:"components,omitempty"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxComponent struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl"`
+}
+
+type cdxVulnerability struct {
+	BOMRef         string         `json:"bom-ref"`
+	ID             string         `json:"id"`
+	Source         *cdxSource     `json:"source,omitempty"`
+	References     []cdxReference `json:"references,omitempty"`
+	Ratings        []cdxRating    `json:"ratings,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	Recommendation string         `json:"recommendation,omitempty"`
+	Analysis       cdxAnalysis    `json:"analysis"`
+	Affects        []cdxAffect    `json:"affects"`
+}
+
+type cdxSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type cdxReference struct {
+	ID     string    `json:"id"`
+	Source cdxSource `json:"source"`
+}
+
+type cdxRating struct {
+	Source   *cdxSource `json:"source,omitempty"`
+	Score    float64    `json:"score,omitempty"`
+	Severity string     `json:"severity"`
+}
+
+type cdxAnalysis struct {
+	State  string `json:"state"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type cdxAffect struct {
+	Ref      string            `json:"ref"`
+	Versions []cdxAffectedVers `json:"versions,omitempty"`
+}
+
+type cdxAffectedVers struct {
+	Version string `json:"version,omitempty"`
+	Range   string `json:"range,omitempty"`
+	Status  string `json:"status"`
+}
+
+// ExportCycloneDXVEX writes a vulnerability report as a CycloneDX 1.5 VEX
+// document in JSON. Each affected PURL becomes a component referenced by
+// the vulnerabilities affecting it. Matches are recorded with the analysis
+// state in_triage, since matching versions against advisory ranges does not
+// establish whether a vulnerability is exploitable; tools applying their
+// own analysis can update it.
+func ExportCycloneDXVEX(w io.Writer, report *VulnerabilityReport) error {
+	bom := cdxBOM{
+		BOMFormat:       "CycloneDX",
+		SpecVersion:     "1.5",
+		Version:         1,
+		Vulnerabilities: []cdxVulnerability{},
+	}
+
+	components := make(map[string]bool)
+	for _, v := range report.Vulnerabilities {
+		id, source := primaryIdentifier(v)
+		vuln := cdxVulnerability{
+			BOMRef:      v.ID,
+			ID:          id,
+			Source:      source,
+			Description: v.Title,
+			Analysis: cdxAnalysis{
+				State:  "in_triage",
+				Detail: "version is within the advisory's vulnerable range",
+			},
+		}
+		for _, ident := range v.Identifiers {
+			if ident != id {
+				vuln.References = append(vuln.References, cdxReference{ID: ident, Source: *identifierSource(ident, v.URL)})
+			}
+		}
+		if severity := cdxSeverity(v.Severity); severity != "" || v.CVSSScore > 0 {
+			vuln.Ratings = []cdxRating{{Source: source, Score: v.CVSSScore, Severity: cmp.Or(severity, "unknown")}}
+		}
+
+		var fixes []string
+		for _, a := range v.Affected {
+			affect := cdxAffect{Ref: a.PURL}
+			purl, err := ParsePURL(a.PURL)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", a.PURL, err)
+			}
+			if purl.Version != "" {
+				affect.Versions = append(affect.Versions, cdxAffectedVers{Version: purl.Version, Status: "affected"})
+			}
+			if a.VulnerableRange != "" {
+				affect.Versions = append(affect.Versions, cdxAffectedVers{Range: versRange(purl.Type, a.VulnerableRange), Status: "affected"})
+			}
+			vuln.Affects = append(vuln.Affects, affect)
+			if a.FixedVersion != "" {
+				fixes = append(fixes, fmt.Sprintf("%s to %s", PURLToName(purl), a.FixedVersion))
+			}
+
+			if !components[a.PURL] {
+				components[a.PURL] = true
+				bom.Components = append(bom.Components, cdxComponent{
+					Type:    "library",
+					BOMRef:  a.PURL,
+					Name:    purl.Name,
+					Group:   purl.Namespace,
+					Version: purl.Version,
+					PURL:    a.PURL,
+				})
+			}
+		}
+		if len(fixes) > 0 {
+			slices.Sort(fixes)
+			vuln.Recommendation = "Upgrade " + strings.Join(slices.Compact(fixes), ", ")
+		}
+		bom.Vulnerabilities = append(bom.Vulnerabilities, vuln)
+	}
+	slices.SortFunc(bom.Components, func(a, b cdxComponent) int { return strings.Compare(a.BOMRef, b.BOMRef) })
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bom); err != nil {
+		return fmt.Errorf("writing CycloneDX VEX: %w", err)
+	}
+	return nil
+}
+
+// primaryIdentifier picks the ID a vulnerability is best known by: its
+// CVE, else its first public identifier, else the ecosyste.ms advisory ID.
+func primaryIdentifier(v Vulnerability) (string, *cdxSource) {
+	for _, ident := range v.Identifiers {
+		if strings.HasPrefix(ident, "CVE-") {
+			return ident, identifierSource(ident, v.URL)
+		}
+	}
+	if len(v.Identifiers) > 0 {
+		return v.Identifiers[0], identifierSource(v.Identifiers[0], v.URL)
+	}
+	return v.ID, &cdxSource{Name: "ecosyste.ms", URL: v.URL}
+}
+
+// identifierSource returns the database that issued an advisory ID.
+func identifierSource(id, fallbackURL string) *cdxSource {
+	switch {
+	case strings.HasPrefix(id, "CVE-"):
+		return &cdxSource{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/" + id}
+	case strings.HasPrefix(id, "GHSA-"):
+		return &cdxSource{Name: "GitHub", URL: "https://github.com/advisories/" + id}
+	}
+	return &cdxSource{Name: "ecosyste.ms", URL: fallbackURL}
+}
+
+// cdxSeverity maps an advisory severity to a CycloneDX severity.
+func cdxSeverity(severity string) string {
+	switch s := strings.ToLower(severity); s {
+	case "critical", "high", "medium", "low", "info", "none":
+		return s
+	case "moderate":
+		return "medium"
+	}
+	return ""
+}
+
+// versRange converts an advisory range such as ">= 1.0, < 1.2.3" to the
+// vers syntax CycloneDX uses, vers:npm/>=1.0|<1.2.3.
+func versRange(purlType, vulnerableRange string) string {
+	var constraints []string
+	for _, c := range strings.Split(vulnerableRange, ",") {
+		if c = strings.Join(strings.Fields(c), ""); c != "" {
+			constraints = append(constraints, c)
+		}
+	}
+	return fmt.Sprintf("vers:%s/%s", purlType, strings.Join(constraints, "|"))
+}
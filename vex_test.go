@@ -0,0 +1,93 @@
+package ecosystems
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportCycloneDXVEX(t *testing.T) {
+	report := &VulnerabilityReport{
+		Vulnerabilities: []Vulnerability{{
+			ID:          "GSA_1",
+			Identifiers: []string{"GHSA-35jh-r3h4-6jhm", "CVE-2021-23337"},
+			Title:       "Command Injection in lodash",
+			Severity:    "HIGH",
+			CVSSScore:   7.2,
+			URL:         "https://advisories.ecosyste.ms/advisories/GSA_1",
+			Affected: []AffectedPURL{
+				{PURL: "pkg:npm/lodash@4.17.20", VulnerableRange: "< 4.17.21", FixedVersion: "4.17.21"},
+			},
+		}, {
+			ID:       "GSA_2",
+			Title:    "Unpatched issue",
+			Severity: "MODERATE",
+			Affected: []AffectedPURL{{PURL: "pkg:npm/%40babel/core@7.0.0", VulnerableRange: ">= 7.0.0, < 8"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCycloneDXVEX(&buf, report); err != nil {
+		t.Fatalf("ExportCycloneDXVEX() error = %v", err)
+	}
+	var bom cdxBOM
+	if err := json.Unmarshal(buf.Bytes(), &bom); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" {
+		t.Errorf("bomFormat, specVersion = %q, %q, want CycloneDX, 1.5", bom.BOMFormat, bom.SpecVersion)
+	}
+	if len(bom.Components) != 2 || bom.Components[0].BOMRef != "pkg:npm/%40babel/core@7.0.0" || bom.Components[0].Group != "@babel" {
+		t.Errorf("Components = %+v, want babel and lodash sorted by bom-ref", bom.Components)
+	}
+	if len(bom.Vulnerabilities) != 2 {
+		t.Fatalf("Vulnerabilities = %d, want 2", len(bom.Vulnerabilities))
+	}
+
+	tests := []struct {
+		name           string
+		vuln           cdxVulnerability
+		id             string
+		source         string
+		severity       string
+		ranges         string
+		recommendation string
+	}{
+		{"cve preferred", bom.Vulnerabilities[0], "CVE-2021-23337", "NVD", "high", "vers:npm/<4.17.21", "Upgrade lodash to 4.17.21"},
+		{"no identifiers", bom.Vulnerabilities[1], "GSA_2", "ecosyste.ms", "medium", "vers:npm/>=7.0.0|<8", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.vuln
+			if v.ID != tt.id || v.Source == nil || v.Source.Name != tt.source {
+				t.Errorf("id, source = %q, %+v, want %q, %s", v.ID, v.Source, tt.id, tt.source)
+			}
+			if len(v.Ratings) != 1 || v.Ratings[0].Severity != tt.severity {
+				t.Errorf("Ratings = %+v, want severity %s", v.Ratings, tt.severity)
+			}
+			if len(v.Affects) != 1 || len(v.Affects[0].Versions) != 2 || v.Affects[0].Versions[1].Range != tt.ranges {
+				t.Errorf("Affects = %+v, want range %s", v.Affects, tt.ranges)
+			}
+			if v.Recommendation != tt.recommendation {
+				t.Errorf("Recommendation = %q, want %q", v.Recommendation, tt.recommendation)
+			}
+			if v.Analysis.State != "in_triage" {
+				t.Errorf("Analysis.State = %q, want in_triage", v.Analysis.State)
+			}
+		})
+	}
+	if refs := bom.Vulnerabilities[0].References; len(refs) != 1 || refs[0].ID != "GHSA-35jh-r3h4-6jhm" || refs[0].Source.Name != "GitHub" {
+		t.Errorf("References = %+v, want the GHSA ID", refs)
+	}
+}
+
+func TestExportCycloneDXVEXEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCycloneDXVEX(&buf, &VulnerabilityReport{}); err != nil {
+		t.Fatalf("ExportCycloneDXVEX() error = %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"vulnerabilities": []`)) {
+		t.Errorf("ExportCycloneDXVEX() = %s, want an empty vulnerabilities list", buf.String())
+	}
+}
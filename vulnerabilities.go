@@ -0,0 +1,175 @@
+package ecosystems
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+	"github.com/ecosyste-ms/ecosystems-go/versions"
+)
+
+// VulnerabilityReport lists the advisories affecting a set of packages.
+type VulnerabilityReport struct {
+	// Vulnerabilities holds each affecting advisory once, most severe first.
+	Vulnerabilities []Vulnerability
+	// Affected maps each affected PURL to the IDs of its advisories.
+	Affected map[string][]string
+	// Missing lists PURLs the API did not recognize, in request order.
+	Missing []string
+}
+
+// Vulnerability is an advisory affecting one or more requested PURLs.
+type Vulnerability struct {
+	// ID is the ecosyste.ms advisory UUID.
+	ID string
+	// Identifiers are public IDs such as CVE and GHSA numbers.
+	Identifiers []string
+	Title       string
+	// Severity is as reported by the advisory source, such as "HIGH".
+	Severity  string
+	CVSSScore float64
+	URL       string
+	// Affected lists the requested PURLs the advisory applies to, sorted.
+	Affected []AffectedPURL
+}
+
+// AffectedPURL is a requested PURL matched by an advisory.
+type AffectedPURL struct {
+	PURL            string
+	VulnerableRange string
+	// FixedVersion is the first release outside the vulnerable range, or
+	// "" if there is no fix.
+	FixedVersion string
+}
+
+// VulnerabilityReport looks up packages by PURL and reports the advisories
+// that apply to the versions given, deduplicated across the set. Pass every
+// PURL of a resolved dependency tree to scan it. A PURL without a version
+// is reported as affected by every advisory for its package. Withdrawn
+// advisories are ignored.
+func (c *Client) VulnerabilityReport(ctx context.Context, purls []string, opts ...CallOption) (*VulnerabilityReport, error) {
+	type request struct {
+		purl     string
+		pkgPURL  string
+		name     string
+		purlType string
+		version  string
+	}
+	var requests []request
+	var lookup []string
+	seen := make(map[string]bool)
+	for _, s := range purls {
+		p, err := c.ParsePURL(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", s, err)
+		}
+		version := p.Version
+		p.Version = ""
+		pkgPURL := c.FormatPURL(p)
+		requests = append(requests, request{purl: s, pkgPURL: pkgPURL, name: PURLToName(p), purlType: p.Type, version: version})
+		if !seen[pkgPURL] {
+			seen[pkgPURL] = true
+			lookup = append(lookup, pkgPURL)
+		}
+	}
+
+	result, err := c.BulkLookupDetailed(ctx, lookup, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VulnerabilityReport{Affected: make(map[string][]string)}
+	byID := make(map[string]*Vulnerability)
+	for _, req := range requests {
+		pkg := result.Packages[req.pkgPURL]
+		if pkg == nil {
+			report.Missing = append(report.Missing, req.purl)
+			continue
+		}
+		for _, adv := range pkg.Advisories {
+			if adv.WithdrawnAt != nil {
+				continue
+			}
+			for _, ap := range parseAdvisoryPackages(adv) {
+				if ap.Name != req.name || (ap.Ecosystem != "" && !strings.EqualFold(ap.Ecosystem, pkg.Ecosystem)) {
+					continue
+				}
+				for _, r := range ap.Versions {
+					if req.version != "" && !inVulnerableRange(req.purlType, r.VulnerableRange, req.version) {
+						continue
+					}
+					v := byID[adv.Uuid]
+					if v == nil {
+						v = newVulnerability(adv)
+						byID[adv.Uuid] = v
+					}
+					if !slices.ContainsFunc(v.Affected, func(a AffectedPURL) bool { return a.PURL == req.purl }) {
+						v.Affected = append(v.Affected, AffectedPURL{PURL: req.purl, VulnerableRange: r.VulnerableRange, FixedVersion: r.FirstPatchedVersion})
+						report.Affected[req.purl] = append(report.Affected[req.purl], adv.Uuid)
+					}
+				}
+			}
+		}
+	}
+
+	for _, v := range byID {
+		slices.SortFunc(v.Affected, func(a, b AffectedPURL) int { return cmp.Compare(a.PURL, b.PURL) })
+		report.Vulnerabilities = append(report.Vulnerabilities, *v)
+	}
+	slices.SortFunc(report.Vulnerabilities, func(a, b Vulnerability) int {
+		return cmp.Or(
+			cmp.Compare(severityRank(b.Severity), severityRank(a.Severity)),
+			cmp.Compare(b.CVSSScore, a.CVSSScore),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return report, nil
+}
+
+func newVulnerability(adv packages.Advisory) *Vulnerability {
+	v := &Vulnerability{ID: adv.Uuid, Identifiers: adv.Identifiers}
+	if adv.Title != nil {
+		v.Title = *adv.Title
+	}
+	if adv.Severity != nil {
+		v.Severity = *adv.Severity
+	}
+	if adv.CvssScore != nil {
+		v.CVSSScore = float64(*adv.CvssScore)
+	}
+	if adv.Url != nil {
+		v.URL = *adv.Url
+	}
+	return v
+}
+
+// inVulnerableRange reports whether version falls in an advisory range such
+// as ">= 4.0.0, < 4.17.21". Unparseable ranges match nothing.
+func inVulnerableRange(purlType, vulnerableRange, version string) bool {
+	if vulnerableRange == "" {
+		return false
+	}
+	cons, err := versions.ParseConstraint(purlType, vulnerableRange)
+	if err != nil {
+		return false
+	}
+	return cons.Check(version)
+}
+
+// severityRank orders advisory severities from least to most severe.
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "low":
+		return 1
+	case "moderate", "medium":
+		return 2
+	case "high":
+		return 3
+	case "critical":
+		return 4
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package ecosystems
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func severityAdvisory(uuid, severity, name, vulnerable, patched string) packages.Advisory {
+	return packages.Advisory{
+		Uuid:        uuid,
+		Severity:    &severity,
+		Identifiers: []string{"CVE-" + uuid},
+		Packages: []map[string]interface{}{{
+			"ecosystem":    "npm",
+			"package_name": name,
+			"versions": []interface{}{map[string]interface{}{
+				"vulnerable_version_range": vulnerable,
+				"first_patched_version":    patched,
+			}},
+		}},
+	}
+}
+
+func TestVulnerabilityReport(t *testing.T) {
+	client, srv := newTestClient(t)
+	withdrawn := "2024-01-01T00:00:00Z"
+	old := severityAdvisory("old", "LOW", "minimist", "< 0.2.1", "0.2.1")
+	old.WithdrawnAt = &withdrawn
+	srv.AddPackage("npmjs.org", packages.PackageWithRegistry{
+		Name: "minimist", Ecosystem: "npm", Purl: "pkg:npm/minimist",
+		Advisories: []packages.Advisory{
+			severityAdvisory("proto", "CRITICAL", "minimist", "< 1.2.6", "1.2.6"),
+			severityAdvisory("dos", "MODERATE", "minimist", ">= 1.0.0, < 1.2.3", "1.2.3"),
+			old,
+		},
+	})
+
+	report, err := client.VulnerabilityReport(context.Background(), []string{
+		"pkg:npm/minimist@1.2.0",
+		"pkg:npm/minimist@1.2.5",
+		"pkg:npm/minimist@1.2.8",
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/nope@1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("VulnerabilityReport() error = %v", err)
+	}
+
+	if len(report.Vulnerabilities) != 2 {
+		t.Fatalf("VulnerabilityReport() found %d vulnerabilities, want 2", len(report.Vulnerabilities))
+	}
+	critical := report.Vulnerabilities[0]
+	if critical.ID != "proto" || len(critical.Affected) != 2 {
+		t.Errorf("Vulnerabilities[0] = %+v, want proto affecting 2 PURLs", critical)
+	}
+	if critical.Affected[0].FixedVersion != "1.2.6" {
+		t.Errorf("FixedVersion = %q, want %q", critical.Affected[0].FixedVersion, "1.2.6")
+	}
+
+	wantAffected := map[string][]string{
+		"pkg:npm/minimist@1.2.0": {"proto", "dos"},
+		"pkg:npm/minimist@1.2.5": {"proto"},
+	}
+	if !reflect.DeepEqual(report.Affected, wantAffected) {
+		t.Errorf("Affected = %v, want %v", report.Affected, wantAffected)
+	}
+	if want := []string{"pkg:npm/nope@1.0.0"}; !reflect.DeepEqual(report.Missing, want) {
+		t.Errorf("Missing = %v, want %v", report.Missing, want)
+	}
+}
+
+func TestInVulnerableRange(t *testing.T) {
+	tests := []struct {
+		purlType, vulnerable, version string
+		want                          bool
+	}{
+		{"npm", "< 4.17.21", "4.17.20", true},
+		{"npm", "< 4.17.21", "4.17.21", false},
+		{"npm", ">= 1.0.0, < 1.2.3", "0.9.0", false},
+		{"gem", ">= 7.0.0, < 7.0.8", "7.0.4", true},
+		{"pypi", "<= 2.0", "2.0", true},
+		{"npm", "", "1.0.0", false},
+		{"npm", "not a range !!", "1.0.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := inVulnerableRange(tt.purlType, tt.vulnerable, tt.version); got != tt.want {
+			t.Errorf("inVulnerableRange(%q, %q, %q) = %v, want %v", tt.purlType, tt.vulnerable, tt.version, got, tt.want)
+		}
+	}
+}
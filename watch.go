@@ -0,0 +1,108 @@
+package ecosystems
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+// WatchPackage polls a package every interval and calls fn with each
+// version published since the watch started, oldest first. It blocks
+// until ctx is done, returning ctx.Err(), or until a request fails. The
+// interval must be positive.
+//
+// Each poll is one conditional package lookup, sent with the ETag of the
+// previous response; the version list is only fetched again when the
+// package has changed.
+func (c *Client) WatchPackage(ctx context.Context, purl string, interval time.Duration, fn func(newVersion packages.Version), opts ...CallOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch %s: interval must be positive, got %v", purl, interval)
+	}
+	p, err := c.ParsePURL(purl)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", purl, err)
+	}
+	registries, err := c.registriesFor(ctx, p, opts...)
+	if err != nil {
+		return err
+	}
+	name := PURLToName(p)
+
+	// Watch the first registry that has the package, as LookupPURL would
+	// find it, or the preferred one if none has it yet.
+	registry := registries[0]
+	var versions []packages.Version
+	for _, r := range registries {
+		versions, err = c.GetAllVersions(ctx, r, name, opts...)
+		if err != nil {
+			return err
+		}
+		if versions != nil {
+			registry = r
+			break
+		}
+	}
+	seen := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		seen[v.Number] = true
+	}
+
+	var etag, latest string
+	count := -1
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		meta := &ResponseMeta{}
+		lookupOpts := append(slices.Clip(opts), CallResponseMeta(meta))
+		if etag != "" {
+			lookupOpts = append(lookupOpts, CallHeader("If-None-Match", etag))
+		}
+		pkg, err := c.LookupByRegistryAndName(ctx, registry, name, lookupOpts...)
+		if meta.NotModified() {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		etag = meta.ETag
+		if pkg == nil || (pkg.VersionsCount == count && deref(pkg.LatestReleaseNumber) == latest) {
+			continue
+		}
+		count, latest = pkg.VersionsCount, deref(pkg.LatestReleaseNumber)
+
+		versions, err := c.GetAllVersions(ctx, registry, name, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		var fresh []packages.Version
+		for _, v := range versions {
+			if !seen[v.Number] {
+				seen[v.Number] = true
+				fresh = append(fresh, v)
+			}
+		}
+		slices.SortStableFunc(fresh, func(a, b packages.Version) int {
+			at, _ := parseTimestamp(a.PublishedAt)
+			bt, _ := parseTimestamp(b.PublishedAt)
+			return at.Compare(bt)
+		})
+		for _, v := range fresh {
+			fn(v)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package ecosystems
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecosyste-ms/ecosystems-go/packages"
+)
+
+func TestWatchPackage(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	got := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WatchPackage(ctx, "pkg:gem/rails", 5*time.Millisecond, func(v packages.Version) {
+			got <- v.Number
+		})
+	}()
+
+	// Publish once the watch has taken its baseline and polled.
+	for !slices.ContainsFunc(srv.Requests(), func(r string) bool { return strings.HasSuffix(r, "/packages/rails") }) {
+		time.Sleep(time.Millisecond)
+	}
+	for _, v := range []struct{ number, published string }{{"7.2.0", "2024-08-09T00:00:00Z"}, {"7.1.4", "2024-08-22T00:00:00Z"}} {
+		srv.AddVersion("rubygems.org", "rails", packages.VersionWithDependencies{Number: v.number, PublishedAt: &v.published})
+	}
+	latest := "7.2.0"
+	srv.AddPackage("rubygems.org", packages.PackageWithRegistry{
+		Name: "rails", Purl: "pkg:gem/rails", VersionsCount: 5, LatestReleaseNumber: &latest,
+		Registry: packages.Registry{Name: "rubygems.org"},
+	})
+
+	for _, want := range []string{"7.2.0", "7.1.4"} {
+		select {
+		case number := <-got:
+			if number != want {
+				t.Errorf("WatchPackage() reported %s, want %s", number, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("WatchPackage() did not report %s", want)
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("WatchPackage() error = %v, want %v", err, context.Canceled)
+	}
+	select {
+	case number := <-got:
+		t.Errorf("WatchPackage() reported %s again", number)
+	default:
+	}
+}
+
+func TestWatchPackageInvalidArguments(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	tests := []struct {
+		name     string
+		purl     string
+		interval time.Duration
+	}{
+		{"invalid PURL", "pkg:gem", time.Second},
+		{"zero interval", "pkg:gem/rails", 0},
+		{"negative interval", "pkg:gem/rails", -time.Second},
+		{"unsupported type", "pkg:unknown/thing", time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.WatchPackage(context.Background(), tt.purl, tt.interval, func(packages.Version) {})
+			if err == nil {
+				t.Error("WatchPackage() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestWatchPackageCatalogueType(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddRegistry(packages.Registry{Name: "registry.example", PurlType: "example"})
+	srv.AddPackage("registry.example", packages.PackageWithRegistry{Name: "widget"})
+	srv.AddVersion("registry.example", "widget", packages.VersionWithDependencies{Number: "1.0.0"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.WatchPackage(ctx, "pkg:example/widget", 5*time.Millisecond, func(packages.Version) {})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WatchPackage() error = %v, want context.DeadlineExceeded", err)
+	}
+	if got := countRequests(srv.Requests(), "/registries/registry.example/packages/widget/versions"); got == 0 {
+		t.Error("WatchPackage() did not fetch the versions of registry.example/widget")
+	}
+}